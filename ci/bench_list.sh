@@ -0,0 +1,57 @@
+#!/usr/bin/env bash
+# Time `aw list` on a throwaway repository with many worktrees, reading the
+# per-worktree git status one at a time and then in parallel batches.
+# Usage: ci/bench_list.sh [worktree_count]   (default 30)
+
+set -e
+
+count="${1:-30}"
+repo_root=$(git rev-parse --show-toplevel 2>/dev/null || pwd)
+
+work_dir=$(mktemp -d "${TMPDIR:-/tmp}/aw-bench.XXXXXX")
+trap 'rm -rf "$work_dir"' EXIT
+
+bench_repo="$work_dir/repo"
+git init -q -b main "$bench_repo"
+git -C "$bench_repo" -c user.name=bench -c user.email=bench@example.com commit -q --allow-empty -m "initial"
+for i in $(seq 1 "$count"); do
+  git -C "$bench_repo" worktree add -q -b "work/$i-bench" "$work_dir/wt-$i" main
+  git -C "$work_dir/wt-$i" -c user.name=bench -c user.email=bench@example.com commit -q --allow-empty -m "work $i"
+done
+
+# Source the tool with gum and network-backed provider checks stubbed out
+gum() { [[ "$1" == "confirm" ]] && return 1; return 0; }
+# shellcheck source=../src/lib/utils.sh
+source "$repo_root/src/lib/utils.sh"
+# shellcheck source=../src/lib/config.sh
+source "$repo_root/src/lib/config.sh"
+# shellcheck source=../src/providers/common.sh
+source "$repo_root/src/providers/common.sh"
+# shellcheck source=../src/lib/worktree.sh
+source "$repo_root/src/lib/worktree.sh"
+# shellcheck source=../src/lib/metadata.sh
+source "$repo_root/src/lib/metadata.sh"
+# shellcheck source=../src/commands/list.sh
+source "$repo_root/src/commands/list.sh"
+_aw_check_branch_pr_merged() { return 1; }
+_aw_check_issue_merged() { return 1; }
+_aw_check_issue_closed() { return 1; }
+
+cd "$bench_repo"
+
+_bench_list() {
+  # Print the milliseconds one `aw list` takes with the given batch size
+  local jobs="$1"
+  local start end
+  start=$(bash "$repo_root/ci/get_timestamp.sh")
+  _AW_LIST_STATUS_JOBS="$jobs" _aw_list >/dev/null
+  end=$(bash "$repo_root/ci/get_timestamp.sh")
+  echo $((end - start))
+}
+
+sequential_ms=$(_bench_list 1)
+parallel_ms=$(_bench_list "$_AW_LIST_STATUS_JOBS")
+
+echo "aw list with $count worktrees:"
+echo "  one at a time:       ${sequential_ms}ms"
+echo "  $_AW_LIST_STATUS_JOBS at a time:         ${parallel_ms}ms"
@@ -16,6 +16,7 @@ SOURCE_FILES=(
   "$SRC_DIR/lib/deps.sh"
   "$SRC_DIR/lib/utils.sh"
   "$SRC_DIR/lib/config.sh"
+  "$SRC_DIR/lib/metadata.sh"
   "$SRC_DIR/lib/hooks.sh"
   "$SRC_DIR/lib/environment.sh"
   "$SRC_DIR/lib/ai.sh"
@@ -27,12 +28,22 @@ SOURCE_FILES=(
   "$SRC_DIR/providers/linear.sh"
   "$SRC_DIR/lib/worktree.sh"
   "$SRC_DIR/commands/list.sh"
+  "$SRC_DIR/commands/status.sh"
   "$SRC_DIR/commands/new.sh"
   "$SRC_DIR/commands/issue.sh"
   "$SRC_DIR/commands/create_issue.sh"
   "$SRC_DIR/commands/pr.sh"
   "$SRC_DIR/commands/resume.sh"
   "$SRC_DIR/commands/cleanup.sh"
+  "$SRC_DIR/commands/remove.sh"
+  "$SRC_DIR/commands/prune.sh"
+  "$SRC_DIR/commands/grep.sh"
+  "$SRC_DIR/commands/edit.sh"
+  "$SRC_DIR/commands/rename.sh"
+  "$SRC_DIR/commands/lock.sh"
+  "$SRC_DIR/commands/sessions.sh"
+  "$SRC_DIR/commands/version.sh"
+  "$SRC_DIR/commands/doctor.sh"
   "$SRC_DIR/commands/milestone.sh"
   "$SRC_DIR/commands/menu.sh"
 )
@@ -46,17 +57,55 @@ cat > "$OUTPUT" << 'HEADER'
 # Usage:
 #   auto-worktree                    # Interactive menu
 #   auto-worktree new                # Create new worktree
+#   auto-worktree new --detach <sha> # Worktree at a commit with a detached HEAD (no branch)
+#   auto-worktree new --batch < branches.txt  # One worktree per branch name on stdin, with a summary
+#   auto-worktree new --write-branch <file>  # Save the created branch name for later CI steps (also issue)
 #   auto-worktree resume             # Resume existing worktree
+#   auto-worktree resume --list      # Pick a recent worktree (tmux attach or cd path)
 #   auto-worktree issue [id]         # Work on an issue (GitHub #123, GitLab #456, or JIRA PROJ-123)
+#   auto-worktree issue --preview    # Preview issue descriptions while picking
+#   auto-worktree issue --milestone <name> --all  # Worktrees for a whole milestone
+#   auto-worktree issue --jql '<query>'  # Pick from a one-off JIRA query
+#   auto-worktree issue --include-closed  # Also list closed issues (GitHub, GitLab)
+#   auto-worktree issue <id> --resume  # Reattach to the issue's worktree (tmux session or cd path)
 #   auto-worktree milestone          # Work on a Milestone/Epic
 #   auto-worktree pr [num]           # Review a GitHub PR or GitLab MR
+#   auto-worktree pr [num] --stat    # ...and show its diff stat
+#   auto-worktree pr --not-author @me  # Pick from PRs opened by someone else (--author <login> for one person's)
+#   auto-worktree pr --create --draft  # Open a draft PR/MR for the current branch
 #   auto-worktree list               # List existing worktrees
+#   auto-worktree list --since 24h   # Only worktrees committed to or opened in the last day
+#   auto-worktree list --all-repos   # List worktrees across every repository used with auto-worktree
+#   auto-worktree list --format '{branch}\t{path}'  # One templated line per worktree, for scripts
+#   auto-worktree list --count-only  # Just the number of worktrees, for shell prompts
+#   auto-worktree list --no-status   # Only name, branch and age; skip merge and sync checks
+#   auto-worktree status             # Summarize dirty, unpushed, stale and merged worktrees
+#   auto-worktree remove <branch>    # Remove a worktree by branch name or path
+#   auto-worktree remove -D <branch> # Remove a worktree and force-delete its branch
+#   auto-worktree remove --interactive  # Pick a worktree to remove from a list
+#   auto-worktree prune [--all]      # Prune orphaned worktrees (--all: also merged ones)
+#   auto-worktree grep <pattern>     # Search every worktree, prefixed by branch
+#   auto-worktree edit [<branch>]    # Open a worktree (default: the current one) in your editor
+#   auto-worktree rename [<old>] <new>  # Rename a worktree's branch and move it to match
+#   auto-worktree lock [<branch>]    # Protect a worktree from cleanup, prune and list (unlock to undo)
+#   auto-worktree sessions keep [<branch>]  # Never report a worktree as stale (--off to undo)
 #   auto-worktree settings           # Configure per-repository settings
+#   auto-worktree settings export    # Print settings as JSON grouped by category
+#   auto-worktree settings import <file>  # Apply settings from an exported JSON file
+#   auto-worktree doctor             # Validate configuration and repository state
+#   auto-worktree doctor --repair    # Fix worktree registrations after directories moved
+#   auto-worktree doctor --self-test # Time git and the provider CLI to diagnose slow or broken setups
+#   auto-worktree version [--json]   # Print the version (JSON includes git and gh versions)
 #
 # Configuration (per-repository via git config):
 #   git config auto-worktree.issue-provider github|gitlab|jira  # Set issue provider
 #   git config auto-worktree.jira-server <URL>                  # Set JIRA server URL
 #   git config auto-worktree.jira-project <KEY>                 # Set default JIRA project
+#   git config auto-worktree.jira-jql '<query>'                 # JQL for the JIRA issue list (project filter still applied)
+#   git config auto-worktree.issue-list-labels <bool>           # false to list GitHub issues without labels (smaller, faster)
+#   git config auto-worktree.issue-list-limit <n>               # How many issues, milestones and PRs to fetch (default: 100)
+#   git config auto-worktree.github-host <HOST>                 # Set GitHub Enterprise hostname (default: github.com)
+#   git config auto-worktree.github-remote <remote>             # Remote whose GitHub repo has the issues (default: origin)
 #   git config auto-worktree.gitlab-server <URL>                # Set GitLab server URL (for self-hosted)
 #   git config auto-worktree.gitlab-project <GROUP/PROJECT>     # Set default GitLab project path
 #   git config auto-worktree.linear-team <TEAM>                 # Set default Linear team
@@ -66,8 +115,26 @@ cat > "$OUTPUT" << 'HEADER'
 #   git config auto-worktree.run-hooks <bool>                   # true/false to enable/disable git hooks (default: true)
 #   git config auto-worktree.fail-on-hook-error <bool>          # true/false to fail on hook errors (default: false)
 #   git config auto-worktree.custom-hooks "<hook1> <hook2>"     # Space or comma-separated list of custom hooks to run
+#   git config auto-worktree.hook-path "<dir>:<dir>"            # Appended to PATH for hooks (default: OS-specific tool dirs)
+#   git config auto-worktree.install-deps <bool>                # true/false to install project dependencies (default: true)
+#   git config auto-worktree.copy-files "<glob> <glob>"         # Untracked files to copy into new worktrees, e.g. ".env"
+#   git config auto-worktree.branch-prefix <prefix>             # Prefix for generated branch names (default: work)
+#   git config auto-worktree.default-branch <branch>            # Override default branch detection (origin/HEAD, gh, main, master)
+#   git config auto-worktree.editor <command>                   # Editor for 'edit' (default: $VISUAL, then $EDITOR)
+#   git config auto-worktree.prune-no-confirm <bool>            # true to skip the 'prune --all' confirmation (default: false)
+#   git config auto-worktree.fetch-before-create <bool>         # true to branch new worktrees from origin's latest base (default: false)
+#   git config auto-worktree.list-no-status <bool>              # true to make 'list' skip merge and sync checks (default: false)
+#   git config auto-worktree.age-warn-days <n>                  # Days before a worktree's age shows in yellow (default: 1)
+#   git config auto-worktree.age-stale-days <n>                 # Days before it shows in red and counts as stale (default: 4)
+#   git config auto-worktree.worktree-naming "{repo}-{branch}"  # Worktree directory name (default: {branch})
+#   git config auto-worktree.tmux-window-name "{issue} {branch-basename}"  # tmux window name (default: {branch-basename})
+#   git config auto-worktree.post-create-message "<template>"   # Hint after creating a worktree; {path}, {branch} (default: To start working: cd {path})
 HEADER
 
+# Stamp the version reported by `auto-worktree version`
+echo "" >> "$OUTPUT"
+echo "_AW_VERSION=\"$(git -C "$REPO_ROOT" describe --tags --always --dirty 2>/dev/null || echo unknown)\"" >> "$OUTPUT"
+
 # Concatenate each source module (stripping individual shebangs)
 for src_file in "${SOURCE_FILES[@]}"; do
   if [[ ! -f "$src_file" ]]; then
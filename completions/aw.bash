@@ -6,7 +6,7 @@ _auto_worktree_completions() {
   _init_completion || return
 
   # Define available commands
-  local commands="new resume issue milestone create pr list cleanup settings help"
+  local commands="new resume issue milestone create pr list status cleanup remove prune edit rename lock unlock sessions grep settings doctor version help"
 
   # If we're completing the first argument (the command)
   if [[ $cword -eq 1 ]]; then
@@ -19,8 +19,10 @@ _auto_worktree_completions() {
 
   case "$command" in
     issue)
+      if [[ "$cur" == -* ]]; then
+        mapfile -t COMPREPLY < <(compgen -W "--preview --milestone --all --hooks --no-hooks --jql --include-closed --resume --fetch --no-fetch --write-branch" -- "$cur")
       # Provide dynamic issue number completion from GitHub
-      if command -v gh &>/dev/null; then
+      elif command -v gh &>/dev/null; then
         local issues
         # Fetch open issues and format as "number - title"
         mapfile -t issues < <(gh issue list --limit 100 --state open --json number,title \
@@ -31,9 +33,14 @@ _auto_worktree_completions() {
         fi
       fi
       ;;
+    new)
+      mapfile -t COMPREPLY < <(compgen -W "--hooks --no-hooks --fetch --no-fetch --depth --dry-run --detach --batch --write-branch" -- "$cur")
+      ;;
     pr)
+      if [[ "$cur" == -* ]]; then
+        mapfile -t COMPREPLY < <(compgen -W "--create --draft --suggest-reviewers --stat --author --not-author" -- "$cur")
       # Provide dynamic PR number completion from GitHub
-      if command -v gh &>/dev/null; then
+      elif command -v gh &>/dev/null; then
         local prs
         # Fetch open PRs and format as "number - title"
         mapfile -t prs < <(gh pr list --limit 100 --state open --json number,title \
@@ -44,14 +51,87 @@ _auto_worktree_completions() {
         fi
       fi
       ;;
+    remove)
+      if [[ "$cur" == -* ]]; then
+        mapfile -t COMPREPLY < <(compgen -W "--keep-branch --delete-branch --force -D --interactive -i" -- "$cur")
+      else
+        local branches
+        branches=$(git worktree list --porcelain 2>/dev/null | sed -n 's|^branch refs/heads/||p')
+        mapfile -t COMPREPLY < <(compgen -W "$branches" -- "$cur")
+      fi
+      ;;
+    lock)
+      if [[ "$cur" == -* ]]; then
+        mapfile -t COMPREPLY < <(compgen -W "--reason" -- "$cur")
+      elif [[ "$prev" != "--reason" ]]; then
+        local branches
+        branches=$(git worktree list --porcelain 2>/dev/null | sed -n 's|^branch refs/heads/||p')
+        mapfile -t COMPREPLY < <(compgen -W "$branches" -- "$cur")
+      fi
+      ;;
+    edit|rename|unlock)
+      # Complete branch names that have a worktree checked out
+      if [[ $cword -eq 2 ]]; then
+        local branches
+        branches=$(git worktree list --porcelain 2>/dev/null | sed -n 's|^branch refs/heads/||p')
+        mapfile -t COMPREPLY < <(compgen -W "$branches" -- "$cur")
+      fi
+      ;;
+    sessions)
+      if [[ $cword -eq 2 ]]; then
+        mapfile -t COMPREPLY < <(compgen -W "list keep" -- "$cur")
+      elif [[ "${words[2]}" == "keep" ]]; then
+        if [[ "$cur" == -* ]]; then
+          mapfile -t COMPREPLY < <(compgen -W "--off" -- "$cur")
+        else
+          local branches
+          branches=$(git worktree list --porcelain 2>/dev/null | sed -n 's|^branch refs/heads/||p')
+          mapfile -t COMPREPLY < <(compgen -W "$branches" -- "$cur")
+        fi
+      fi
+      ;;
+    version)
+      mapfile -t COMPREPLY < <(compgen -W "--json" -- "$cur")
+      ;;
+    grep)
+      if [[ "$prev" == "--branch" ]]; then
+        local branches
+        branches=$(git worktree list --porcelain 2>/dev/null | sed -n 's|^branch refs/heads/||p')
+        mapfile -t COMPREPLY < <(compgen -W "$branches" -- "$cur")
+      elif [[ "$cur" == -* ]]; then
+        mapfile -t COMPREPLY < <(compgen -W "--branch --ignore-case -i" -- "$cur")
+      fi
+      ;;
+    prune)
+      mapfile -t COMPREPLY < <(compgen -W "--all" -- "$cur")
+      ;;
+    doctor)
+      mapfile -t COMPREPLY < <(compgen -W "--check-config --repair --self-test" -- "$cur")
+      ;;
     settings)
       # Provide settings subcommands
       if [[ $cword -eq 2 ]]; then
-        local settings_commands="set get list reset"
+        local settings_commands="export --json import"
         mapfile -t COMPREPLY < <(compgen -W "$settings_commands" -- "$cur")
+      elif [[ "$cur" == -* ]]; then
+        mapfile -t COMPREPLY < <(compgen -W "--local --global" -- "$cur")
+      elif [[ "${words[2]}" == "import" ]]; then
+        mapfile -t COMPREPLY < <(compgen -f -- "$cur")
       fi
       ;;
-    new|resume|milestone|create|list|cleanup|help)
+    resume)
+      mapfile -t COMPREPLY < <(compgen -W "--list" -- "$cur")
+      ;;
+    list)
+      mapfile -t COMPREPLY < <(compgen -W "--size --all-repos --format --since --count-only --no-status" -- "$cur")
+      ;;
+    cleanup)
+      mapfile -t COMPREPLY < <(compgen -W "--force --include-detached" -- "$cur")
+      ;;
+    create)
+      mapfile -t COMPREPLY < <(compgen -W "--title --body --template --no-template --no-worktree --label --assignee" -- "$cur")
+      ;;
+    new|milestone|help)
       # These commands don't have specific completions
       COMPREPLY=()
       ;;
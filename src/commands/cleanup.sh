@@ -4,19 +4,42 @@
 # Cleanup worktrees
 # ============================================================================
 _aw_cleanup_interactive() {
-  _aw_ensure_git_repo || return 1
+  _aw_ensure_git_repo || return $?
   _aw_get_repo_info
 
+  # --force allows removing worktrees with uncommitted changes (after an
+  # extra confirmation); without it they are always skipped.
+  # --include-detached offers detached-HEAD worktrees too; with no branch to
+  # check for a merge, they are judged by age alone.
+  local flag_force=false
+  local include_detached=false
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --force)
+        flag_force=true
+        shift
+        ;;
+      --include-detached)
+        include_detached=true
+        shift
+        ;;
+      *)
+        gum style --foreground 1 "Unknown option: $1" >&2
+        return $AW_EXIT_USAGE
+        ;;
+    esac
+  done
+
   local current_path=$(pwd)
   local provider
-  provider=$(_aw_init_issue_provider) || return 1
+  provider=$(_aw_init_issue_provider) || return $AW_EXIT_PROVIDER
   local worktree_list
   worktree_list=$(_aw_get_worktree_list)
   local worktree_count
   worktree_count=$(_aw_count_worktrees "$worktree_list")
 
   if [[ $worktree_count -le 1 ]]; then
-    gum style --foreground 8 "No additional worktrees to clean up for $_AW_SOURCE_FOLDER"
+    _aw_is_quiet || gum style --foreground 8 "No additional worktrees to clean up for $_AW_SOURCE_FOLDER"
     return 0
   fi
 
@@ -26,12 +49,31 @@ _aw_cleanup_interactive() {
   local -a wt_branches=()
   local -a wt_warnings=()
   local -a wt_dirty=()
+  local -a skipped_detached=()
+  local -a skipped_locked=()
+  local locked_list=$(_aw_get_locked_worktrees)
+  _aw_cache_default_branch
 
   while IFS= read -r wt_path; do
     _aw_validate_worktree_path "$wt_path" || continue
     [[ "$wt_path" == "$current_path" ]] && continue
 
+    # `aw lock` protects a worktree from cleanup altogether
+    if _aw_worktree_is_locked "$wt_path" "$locked_list"; then
+      skipped_locked+=("$(basename "$wt_path")")
+      continue
+    fi
+
     local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "unknown")
+    # Detached worktrees (bisects, reviews) have no branch to check for a merge
+    local is_detached=false
+    if [[ "$wt_branch" == "HEAD" ]]; then
+      if [[ "$include_detached" != "true" ]]; then
+        skipped_detached+=("$(basename "$wt_path")")
+        continue
+      fi
+      is_detached=true
+    fi
     local commit_timestamp
     commit_timestamp=$(_aw_get_worktree_timestamp "$wt_path" "$wt_branch")
 
@@ -52,6 +94,8 @@ _aw_cleanup_interactive() {
       local dirty_count=$(echo "$dirty_files" | grep -c . 2>/dev/null || echo 0)
       status_tag="[dirty: $dirty_count uncommitted file(s)]"
       warning_msg="⚠ HAS UNCOMMITTED CHANGES"
+    elif [[ "$is_detached" == "true" ]]; then
+      status_tag="[detached]"
     elif [[ -n "$issue_num" ]] && _aw_check_issue_merged "$issue_num"; then
       status_tag="[merged #$issue_num]"
     elif _aw_check_branch_pr_merged "$wt_branch"; then
@@ -74,7 +118,12 @@ _aw_cleanup_interactive() {
     age_str=$(_aw_format_worktree_age "$commit_timestamp")
 
     # Build display string
-    local display_name="$(basename "$wt_path") ($wt_branch) $age_str"
+    local display_branch="$wt_branch"
+    if [[ "$is_detached" == "true" ]]; then
+      display_branch="detached@$(git -C "$wt_path" rev-parse --short HEAD 2>/dev/null)"
+      wt_branch=""
+    fi
+    local display_name="$(basename "$wt_path") ($display_branch) $age_str"
     if [[ -n "$status_tag" ]]; then
       display_name="$display_name $status_tag"
     fi
@@ -86,20 +135,29 @@ _aw_cleanup_interactive() {
     wt_dirty+=("$is_dirty")
   done <<< "$worktree_list"
 
+  if [[ ${#skipped_locked[@]} -gt 0 ]]; then
+    _aw_is_quiet || gum style --foreground 8 "Skipped ${#skipped_locked[@]} locked worktree(s): ${skipped_locked[*]} (use 'aw unlock' to include them)"
+  fi
+  if [[ ${#skipped_detached[@]} -gt 0 ]]; then
+    _aw_is_quiet || gum style --foreground 8 "Skipped ${#skipped_detached[@]} detached worktree(s): ${skipped_detached[*]} (use --include-detached to include them)"
+  fi
+
   if [[ ${#wt_choices[@]} -eq 0 ]]; then
-    gum style --foreground 8 "No worktrees available to clean up (excluding current worktree)"
+    _aw_is_quiet || gum style --foreground 8 "No worktrees available to clean up (excluding current worktree)"
     return 0
   fi
 
   # Show selection UI
-  gum style --border rounded --padding "0 1" --border-foreground 4 \
-    "Select worktrees to clean up (space to select, enter to confirm)"
-  echo ""
+  if ! _aw_is_quiet; then
+    gum style --border rounded --padding "0 1" --border-foreground 4 \
+      "Select worktrees to clean up (space to select, enter to confirm)"
+    echo ""
+  fi
 
   local selected=$(printf '%s\n' "${wt_choices[@]}" | gum choose --no-limit --height 15)
 
   if [[ -z "$selected" ]]; then
-    gum style --foreground 8 "No worktrees selected for cleanup"
+    _aw_is_quiet || gum style --foreground 8 "No worktrees selected for cleanup"
     return $AW_EXIT_CANCELLED
   fi
 
@@ -119,55 +177,77 @@ _aw_cleanup_interactive() {
   done <<< "$selected"
 
   # Show what will be deleted and confirm
-  echo ""
-  gum style --foreground 5 "Worktrees selected for cleanup:"
-  echo ""
+  if ! _aw_is_quiet; then
+    echo ""
+    gum style --foreground 5 "Worktrees selected for cleanup:"
+    echo ""
+  fi
 
   local has_warnings=false
   local has_dirty=false
   for idx in "${selected_indices[@]}"; do
-    local display="${wt_choices[$idx]}"
     local warning="${wt_warnings[$idx]}"
-    local dirty="${wt_dirty[$idx]}"
+    [[ "${wt_dirty[$idx]}" == "true" ]] && has_dirty=true
+    [[ "${wt_dirty[$idx]}" != "true" ]] && [[ -n "$warning" ]] && has_warnings=true
+    _aw_is_quiet && continue
 
-    if [[ "$dirty" == "true" ]]; then
-      echo "  • $display"
+    echo "  • ${wt_choices[$idx]}"
+    if [[ "${wt_dirty[$idx]}" == "true" ]]; then
       echo "    $(gum style --foreground 1 "$warning")"
-      has_dirty=true
     elif [[ -n "$warning" ]]; then
-      echo "  • $display"
       echo "    $(gum style --foreground 3 "$warning")"
-      has_warnings=true
-    else
-      echo "  • $display"
     fi
   done
 
-  echo ""
+  _aw_is_quiet || echo ""
+  local include_dirty=false
   if [[ "$has_dirty" == "true" ]]; then
-    gum style --foreground 1 "✗ Cannot clean up worktrees with uncommitted changes. Commit or stash your changes first."
-    echo ""
+    if [[ "$flag_force" == "true" ]] && gum confirm "Discard uncommitted changes in the dirty worktrees too?" --default=false; then
+      include_dirty=true
+    else
+      gum style --foreground 1 "✗ Cannot clean up worktrees with uncommitted changes. Commit or stash your changes first." >&2
+      [[ "$flag_force" != "true" ]] && gum style --foreground 8 "To remove them anyway, run: auto-worktree cleanup --force" >&2
+      _aw_is_quiet || echo ""
+    fi
   fi
   if [[ "$has_warnings" == "true" ]]; then
-    gum style --foreground 3 "⚠ Warning: Some worktrees have unpushed commits!"
-    echo ""
+    gum style --foreground 3 "⚠ Warning: Some worktrees have unpushed commits!" >&2
+    _aw_is_quiet || echo ""
   fi
 
-  # Filter out dirty worktrees - build safe indices list
+  # Filter out dirty worktrees unless forced - build safe indices list
   local -a clean_indices=()
   for idx in "${selected_indices[@]}"; do
-    if [[ "${wt_dirty[$idx]}" != "true" ]]; then
+    if [[ "${wt_dirty[$idx]}" != "true" ]] || [[ "$include_dirty" == "true" ]]; then
       clean_indices+=($idx)
     fi
   done
 
   if [[ ${#clean_indices[@]} -eq 0 ]]; then
-    gum style --foreground 8 "No worktrees eligible for cleanup (dirty worktrees were skipped)"
+    _aw_is_quiet || gum style --foreground 8 "No worktrees eligible for cleanup (dirty worktrees were skipped)"
     return 0
   fi
 
-  if ! gum confirm "Delete these worktrees and their branches?"; then
-    gum style --foreground 8 "Cleanup cancelled"
+  # Total disk space the selected worktrees take up
+  local total_kb=0
+  local size_known=true
+  for idx in "${clean_indices[@]}"; do
+    local size_kb
+    if size_kb=$(_aw_get_dir_size_kb "${wt_paths[$idx]}" 10); then
+      total_kb=$((total_kb + size_kb))
+    else
+      size_known=false
+    fi
+  done
+  local total_label="${#clean_indices[@]} worktree(s)"
+  if [[ "$size_known" == "true" ]]; then
+    total_label+=", $(_aw_format_size "$total_kb")"
+  else
+    total_label+=", at least $(_aw_format_size "$total_kb")"
+  fi
+
+  if ! gum confirm "Delete ${total_label} and their branches?"; then
+    _aw_is_quiet || gum style --foreground 8 "Cleanup cancelled"
     return $AW_EXIT_CANCELLED
   fi
 
@@ -177,8 +257,11 @@ _aw_cleanup_interactive() {
     local c_branch="${wt_branches[$idx]}"
 
     _aw_remove_worktree_and_branch "$c_path" "$c_branch" || return 1
+    # Each removed path is the only output scripts need
+    _aw_is_quiet && echo "$c_path"
   done
 
+  _aw_is_quiet && return 0
   echo ""
-  gum style --foreground 2 "Cleanup complete!"
+  gum style --foreground 2 "Cleanup complete! Removed ${total_label}"
 }
@@ -25,6 +25,36 @@ _aw_parse_template() {
   cat "$template_file"
 }
 
+_aw_resolve_issue_template() {
+  # Resolve --template to a template file: either a path, or the name of one
+  # of the provider's templates with or without its extension
+  # (e.g. "bug_report" for .github/ISSUE_TEMPLATE/bug_report.md)
+  # Args: $1 = path or name, $2 = provider
+  # Returns 1 if nothing matches
+  local name="$1"
+  local provider="$2"
+
+  if [[ -f "$name" ]]; then
+    echo "$name"
+    return 0
+  fi
+
+  # Template directories are relative to the repository root
+  local root="${_AW_GIT_ROOT:-.}"
+  local tmpl
+  while IFS= read -r tmpl; do
+    [[ -z "$tmpl" ]] && continue
+    local tmpl_name=$(basename "$tmpl")
+    if [[ "$tmpl_name" == "$name" ]] || [[ "${tmpl_name%.md}" == "$name" ]]; then
+      [[ "$tmpl" != /* ]] && tmpl="$root/$tmpl"
+      echo "$tmpl"
+      return 0
+    fi
+  done < <(cd "$root" && _aw_detect_issue_templates "$provider")
+
+  return 1
+}
+
 _aw_extract_template_sections() {
   # Extract section headers from a markdown template
   # Args: $1 = template file path
@@ -78,33 +108,47 @@ _aw_extract_section_content() {
 
 _aw_create_issue_github() {
   # Create a GitHub issue
-  # Args: $1 = title, $2 = body
+  # Args: $1 = title, $2 = body, $3 = comma-separated assignees (may be empty),
+  #       $4... = labels to apply
   local title="$1"
   local body="$2"
+  local assignees="$3"
+  shift 3
 
   _aw_validate_required "$title" "Title" || return 1
 
-  local issue_url=$(gh issue create --title "$title" --body "$body" 2>&1)
+  local args=(issue create $(_aw_github_repo_flag) --title "$title" --body "$body")
+  [[ -n "$assignees" ]] && args+=(--assignee "$assignees")
+  local label
+  for label in "$@"; do
+    args+=(--label "$label")
+  done
 
-  if [[ $? -eq 0 ]]; then
+  # gh's error message goes straight to stderr
+  local issue_url
+  if issue_url=$(_aw_gh "${args[@]}"); then
+    issue_url=$(echo "$issue_url" | tail -1)
     gum style --foreground 2 "✓ Issue created: $issue_url"
     echo "$issue_url"
     return 0
   else
-    gum style --foreground 1 "Error creating issue: $issue_url"
-    return 1
+    gum style --foreground 1 "Error creating issue" >&2
+    return $AW_EXIT_PROVIDER
   fi
 }
 
 _aw_create_issue_gitlab() {
   # Create a GitLab issue
-  # Args: $1 = title, $2 = body
+  # Args: $1 = title, $2 = body, $3 = comma-separated assignees (may be empty)
   local title="$1"
   local body="$2"
+  local assignees="${3:-}"
 
   _aw_validate_required "$title" "Title" || return 1
 
-  local issue_url=$(glab issue create --title "$title" --description "$body" 2>&1)
+  local args=(issue create --title "$title" --description "$body")
+  [[ -n "$assignees" ]] && args+=(--assignee "$assignees")
+  local issue_url=$(glab "${args[@]}" 2>&1)
 
   if [[ $? -eq 0 ]]; then
     gum style --foreground 2 "✓ Issue created: $issue_url"
@@ -112,21 +156,22 @@ _aw_create_issue_gitlab() {
     return 0
   else
     gum style --foreground 1 "Error creating issue: $issue_url"
-    return 1
+    return $AW_EXIT_PROVIDER
   fi
 }
 
 _aw_create_issue_jira() {
   # Create a JIRA issue
-  # Args: $1 = title, $2 = body
+  # Args: $1 = title, $2 = body, $3 = assignee (may be empty)
   local title="$1"
   local body="$2"
+  local assignee="${3:-}"
 
   _aw_validate_required "$title" "Summary" || return 1
 
   if ! command -v jira &>/dev/null; then
     gum style --foreground 1 "Error: 'jira' CLI not found. Install from https://github.com/ankitpokhrel/jira-cli"
-    return 1
+    return $AW_EXIT_PROVIDER
   fi
 
   # Get default project
@@ -142,8 +187,9 @@ _aw_create_issue_jira() {
     fi
   fi
 
-  local issue_key=$(jira issue create --project "$project" --type "Task" \
-    --summary "$title" --body "$body" --plain --no-input 2>&1 | grep -oE '[A-Z]+-[0-9]+' | head -1)
+  local args=(issue create --project "$project" --type "Task" --summary "$title" --body "$body")
+  [[ -n "$assignee" ]] && args+=(--assignee "$assignee")
+  local issue_key=$(jira "${args[@]}" --plain --no-input 2>&1 | grep -oE '[A-Z]+-[0-9]+' | head -1)
 
   if [[ -n "$issue_key" ]]; then
     gum style --foreground 2 "✓ Issue created: $issue_key"
@@ -151,21 +197,22 @@ _aw_create_issue_jira() {
     return 0
   else
     gum style --foreground 1 "Error creating JIRA issue"
-    return 1
+    return $AW_EXIT_PROVIDER
   fi
 }
 
 _aw_create_issue_linear() {
   # Create a Linear issue
-  # Args: $1 = title, $2 = body
+  # Args: $1 = title, $2 = body, $3 = assignee (may be empty)
   local title="$1"
   local body="$2"
+  local assignee="${3:-}"
 
   _aw_validate_required "$title" "Title" || return 1
 
   if ! command -v linear &>/dev/null; then
     gum style --foreground 1 "Error: 'linear' CLI not found. Install from https://github.com/linear/linear-cli"
-    return 1
+    return $AW_EXIT_PROVIDER
   fi
 
   # Get default team
@@ -182,8 +229,10 @@ _aw_create_issue_linear() {
   fi
 
   # Create issue using Linear CLI
-  # Format: linear issue create -t "title" -d "description" --team TEAM
-  local issue_id=$(linear issue create -t "$title" -d "$body" --team "$team" 2>&1 | grep -oE '[A-Z]+-[0-9]+' | head -1)
+  # Format: linear issue create -t "title" -d "description" --team TEAM [-a ASSIGNEE]
+  local args=(issue create -t "$title" -d "$body" --team "$team")
+  [[ -n "$assignee" ]] && args+=(-a "$assignee")
+  local issue_id=$(linear "${args[@]}" 2>&1 | grep -oE '[A-Z]+-[0-9]+' | head -1)
 
   if [[ -n "$issue_id" ]]; then
     gum style --foreground 2 "✓ Issue created: $issue_id"
@@ -191,8 +240,74 @@ _aw_create_issue_linear() {
     return 0
   else
     gum style --foreground 1 "Error creating Linear issue"
-    return 1
+    return $AW_EXIT_PROVIDER
+  fi
+}
+
+_aw_resolve_assignee() {
+  # Translate an --assignee value for the provider's CLI. "@me" becomes the
+  # current user: gh understands it as is, Linear calls it "self", GitLab and
+  # JIRA need the username looked up.
+  # Args: $1 = provider, $2 = assignee
+  # Returns 1 if the current user can't be determined
+  local provider="$1"
+  local assignee="$2"
+
+  if [[ "$assignee" != "@me" ]]; then
+    echo "$assignee"
+    return 0
   fi
+
+  local me=""
+  case "$provider" in
+    github) me="@me" ;;
+    linear) me="self" ;;
+    gitlab) me=$(glab api user 2>/dev/null | jq -r '.username // empty' 2>/dev/null) ;;
+    jira)   me=$(jira me 2>/dev/null | head -n 1) ;;
+  esac
+
+  [[ -z "$me" ]] && return 1
+  echo "$me"
+}
+
+_aw_resolve_issue_labels() {
+  # Check --label values against the repository's GitHub labels, offering to
+  # create any that are missing. Labels are left unchecked if gh can't list
+  # them; gh issue create then reports unknown ones itself.
+  # Args: label names
+  # Returns: 1 if a missing label wasn't created
+  local existing
+  existing=$(_aw_github_list_labels) || return 0
+
+  local label
+  for label in "$@"; do
+    # GitHub label names are case-insensitive
+    if grep -qixF -- "$label" <<< "$existing"; then
+      continue
+    fi
+    gum style --foreground 3 "Label '$label' doesn't exist in this repository"
+    if ! gum confirm "Create label '$label'?"; then
+      gum style --foreground 1 "Error: Unknown label: $label"
+      return 1
+    fi
+    if ! _aw_github_create_label "$label"; then
+      gum style --foreground 1 "Error: Failed to create label: $label"
+      return $AW_EXIT_PROVIDER
+    fi
+    gum style --foreground 2 "✓ Created label: $label"
+  done
+}
+
+_aw_choose_issue_labels() {
+  # Let the user pick any number of the repository's GitHub labels
+  # Outputs the chosen labels, one per line (nothing if none or unavailable)
+  local existing
+  existing=$(_aw_github_list_labels) || return 0
+  [[ -z "$existing" ]] && return 0
+
+  echo "" >&2
+  gum style --foreground 6 "Labels (space to select, enter to confirm, none to skip):" >&2
+  echo "$existing" | gum choose --no-limit --height 10 || true
 }
 
 _aw_manual_template_walkthrough() {
@@ -394,7 +509,7 @@ ${section_content}
 _aw_create_issue() {
   # Create a new issue interactively
   # Supports both interactive mode and CLI flags
-  _aw_ensure_git_repo || return 1
+  _aw_ensure_git_repo || return $?
   _aw_get_repo_info
 
   # Parse CLI flags
@@ -403,6 +518,8 @@ _aw_create_issue() {
   local flag_template=""
   local flag_no_template=false
   local flag_no_worktree=false
+  local labels=()
+  local assignees=()
 
   while [[ $# -gt 0 ]]; do
     case "$1" in
@@ -426,16 +543,60 @@ _aw_create_issue() {
         flag_no_worktree=true
         shift
         ;;
+      --label)
+        if [[ -z "${2:-}" ]]; then
+          gum style --foreground 1 "Error: --label needs a label name"
+          return $AW_EXIT_USAGE
+        fi
+        labels+=("$2")
+        shift 2
+        ;;
+      --assignee)
+        if [[ -z "${2:-}" ]]; then
+          gum style --foreground 1 "Error: --assignee needs a username (or @me)"
+          return $AW_EXIT_USAGE
+        fi
+        assignees+=("$2")
+        shift 2
+        ;;
       *)
         gum style --foreground 1 "Unknown option: $1"
-        return 1
+        return $AW_EXIT_USAGE
         ;;
     esac
   done
 
   # Determine issue provider
   local provider
-  provider=$(_aw_init_issue_provider) || return 1
+  provider=$(_aw_init_issue_provider) || return $AW_EXIT_PROVIDER
+
+  if [[ ${#labels[@]} -gt 0 ]] && [[ "$provider" != "github" ]]; then
+    gum style --foreground 3 "Warning: --label is only supported for GitHub issues; ignoring"
+    labels=()
+  fi
+
+  # Usernames as the provider's CLI expects them, with @me resolved
+  local assignee
+  local resolved_assignees=()
+  for assignee in "${assignees[@]}"; do
+    local resolved
+    if ! resolved=$(_aw_resolve_assignee "$provider" "$assignee"); then
+      gum style --foreground 1 "Error: Could not determine the current $(_aw_provider_display_name "$provider") user for @me"
+      return $AW_EXIT_PROVIDER
+    fi
+    resolved_assignees+=("$resolved")
+  done
+  assignees=("${resolved_assignees[@]}")
+
+  # JIRA and Linear issues have a single assignee. ${array[@]:0:1} is the
+  # first element in both zsh and bash.
+  if [[ ${#assignees[@]} -gt 1 ]] && [[ "$provider" == "jira" || "$provider" == "linear" ]]; then
+    local first_assignee="${assignees[@]:0:1}"
+    gum style --foreground 3 "Warning: $(_aw_provider_display_name "$provider") issues take one assignee; using $first_assignee"
+    assignees=("$first_assignee")
+  fi
+  local assignee_list=$(printf '%s,' "${assignees[@]}")
+  assignee_list="${assignee_list%,}"
 
   # Variables for issue creation
   local title=""
@@ -448,12 +609,24 @@ _aw_create_issue() {
     title="$flag_title"
     body="$flag_body"
 
+    # Take the body from a pipe: cat notes.md | auto-worktree create --title "Bug"
+    if [[ -z "$body" ]] && [[ -z "$flag_template" ]] && [[ ! -t 0 ]]; then
+      body=$(cat)
+    fi
+
     if [[ -n "$flag_template" ]]; then
-      if [[ -f "$flag_template" ]]; then
-        template_file="$flag_template"
+      if template_file=$(_aw_resolve_issue_template "$flag_template" "$provider"); then
         body=$(_aw_parse_template "$template_file")
       else
-        gum style --foreground 1 "Error: Template file not found: $flag_template"
+        gum style --foreground 1 "Error: Template not found: $flag_template"
+        local available=$(cd "${_AW_GIT_ROOT:-.}" && _aw_detect_issue_templates "$provider")
+        if [[ -n "$available" ]]; then
+          echo "Available templates:"
+          echo "$available" | while IFS= read -r tmpl; do
+            local tmpl_name=$(basename "$tmpl")
+            echo "  ${tmpl_name%.md}"
+          done
+        fi
         return 1
       fi
     fi
@@ -739,11 +912,29 @@ _aw_create_issue() {
     fi
   fi
 
+  if [[ "$provider" == "github" ]]; then
+    if [[ ${#labels[@]} -gt 0 ]]; then
+      _aw_resolve_issue_labels "${labels[@]}" || return $?
+    elif [[ -z "$flag_title" ]]; then
+      local chosen_label
+      while IFS= read -r chosen_label; do
+        [[ -n "$chosen_label" ]] && labels+=("$chosen_label")
+      done < <(_aw_choose_issue_labels)
+    fi
+  fi
+
   # Show preview and confirm before creating the issue
   echo ""
   gum style --foreground 6 --bold "Issue Preview:"
   echo ""
   gum style --foreground 4 "Title: $title"
+  if [[ ${#labels[@]} -gt 0 ]]; then
+    local label_list=$(printf '%s, ' "${labels[@]}")
+    gum style --foreground 4 "Labels: ${label_list%, }"
+  fi
+  if [[ -n "$assignee_list" ]]; then
+    gum style --foreground 4 "Assignees: ${assignee_list//,/, }"
+  fi
   echo ""
   gum style --foreground 8 "Body:"
   echo "$body" | head -20
@@ -767,16 +958,16 @@ _aw_create_issue() {
   local result=""
   case "$provider" in
     github)
-      result=$(_aw_create_issue_github "$title" "$body")
+      result=$(_aw_create_issue_github "$title" "$body" "$assignee_list" "${labels[@]}")
       ;;
     gitlab)
-      result=$(_aw_create_issue_gitlab "$title" "$body")
+      result=$(_aw_create_issue_gitlab "$title" "$body" "$assignee_list")
       ;;
     jira)
-      result=$(_aw_create_issue_jira "$title" "$body")
+      result=$(_aw_create_issue_jira "$title" "$body" "$assignee_list")
       ;;
     linear)
-      result=$(_aw_create_issue_linear "$title" "$body")
+      result=$(_aw_create_issue_linear "$title" "$body" "$assignee_list")
       ;;
     *)
       gum style --foreground 1 "Error: Unknown provider: $provider"
@@ -784,8 +975,14 @@ _aw_create_issue() {
       ;;
   esac
 
-  if [[ $? -ne 0 ]]; then
-    return 1
+  local create_status=$?
+  if [[ $create_status -ne 0 ]]; then
+    return $create_status
+  fi
+
+  # In quiet mode, print just the issue URL/key (last line of the result)
+  if _aw_is_quiet; then
+    echo "$result" | tail -1
   fi
 
   # Post-creation options
@@ -0,0 +1,377 @@
+#!/bin/bash
+
+# ============================================================================
+# Repository diagnostics
+# ============================================================================
+
+_aw_doctor_problem() {
+  # Report a config problem along with the command that fixes it
+  # Args: $1 = description, $2 = fix command
+  gum style --foreground 1 "✗ $1"
+  echo "    Fix: $2"
+  _AW_DOCTOR_PROBLEMS=$((_AW_DOCTOR_PROBLEMS + 1))
+}
+
+_aw_doctor_check_config() {
+  # Validate auto-worktree.* settings for this repository
+  # Returns 1 if any problem was found
+  _AW_DOCTOR_PROBLEMS=0
+
+  local provider=$(_aw_get_configured_issue_provider)
+  case "$provider" in
+    ""|github|gitlab|jira|linear) ;;
+    *)
+      if [[ -n "${AW_ISSUE_PROVIDER:-}" ]]; then
+        _aw_doctor_problem "AW_ISSUE_PROVIDER is '$provider' (expected github, gitlab, jira or linear)" \
+          "export AW_ISSUE_PROVIDER=github"
+      else
+        _aw_doctor_problem "auto-worktree.issue-provider is '$provider' (expected github, gitlab, jira or linear)" \
+          "git config auto-worktree.issue-provider github"
+      fi
+      ;;
+  esac
+
+  # Provider-specific keys
+  local jira_server=$(_aw_get_jira_server)
+  local gitlab_server=$(_aw_get_gitlab_server)
+  if [[ "$provider" == "jira" ]] && [[ -z "$jira_server" ]]; then
+    _aw_doctor_problem "Issue provider is jira but auto-worktree.jira-server is not set" \
+      "git config auto-worktree.jira-server https://your-company.atlassian.net"
+  fi
+  if [[ -n "$jira_server" ]] && [[ ! "$jira_server" =~ ^https?:// ]]; then
+    _aw_doctor_problem "auto-worktree.jira-server '$jira_server' is not an http(s) URL" \
+      "git config auto-worktree.jira-server https://$jira_server"
+  fi
+  if [[ -n "$gitlab_server" ]] && [[ ! "$gitlab_server" =~ ^https?:// ]]; then
+    _aw_doctor_problem "auto-worktree.gitlab-server '$gitlab_server' is not an http(s) URL" \
+      "git config auto-worktree.gitlab-server https://$gitlab_server"
+  fi
+
+  local github_remote=$(_aw_get_config "github-remote")
+  if [[ -n "$github_remote" ]] && ! git config --get "remote.${github_remote}.url" &>/dev/null; then
+    _aw_doctor_problem "auto-worktree.github-remote is '$github_remote' but there is no such remote" \
+      "git config --unset auto-worktree.github-remote"
+  fi
+
+  # Settings that only apply to a provider other than the configured one
+  local key
+  for key in jira-server jira-project jira-jql gitlab-server gitlab-project linear-team; do
+    local key_provider="${key%%-*}"
+    if [[ -n "$provider" ]] && [[ "$provider" != "$key_provider" ]] && [[ -n "$(_aw_get_config "$key")" ]]; then
+      _aw_doctor_problem "auto-worktree.$key is set but the issue provider is $provider" \
+        "git config --unset auto-worktree.$key"
+    fi
+  done
+
+  # Boolean settings
+  for key in $(echo "$_AW_BOOL_SETTINGS"); do
+    local value=$(_aw_get_config "$key")
+    if [[ -n "$value" ]] && ! git config --get --bool "auto-worktree.$key" &>/dev/null; then
+      _aw_doctor_problem "auto-worktree.$key is '$value' (expected true or false)" \
+        "git config auto-worktree.$key true"
+    fi
+  done
+
+  local list_limit=$(_aw_get_config "issue-list-limit")
+  if [[ -n "$list_limit" ]] && { ! [[ "$list_limit" =~ ^[0-9]+$ ]] || [[ $((10#$list_limit)) -eq 0 ]]; }; then
+    _aw_doctor_problem "auto-worktree.issue-list-limit is '$list_limit' (expected a positive number)" \
+      "git config auto-worktree.issue-list-limit 100"
+  fi
+
+  local age_key
+  for age_key in age-warn-days age-stale-days; do
+    local age_days=$(_aw_get_config "$age_key")
+    if [[ -n "$age_days" ]] && { ! [[ "$age_days" =~ ^[0-9]+$ ]] || [[ $((10#$age_days)) -eq 0 ]]; }; then
+      _aw_doctor_problem "auto-worktree.$age_key is '$age_days' (expected a positive number of days)" \
+        "git config --unset auto-worktree.$age_key"
+    fi
+  done
+  if [[ $(_aw_get_age_warn_days) -gt $(_aw_get_age_stale_days) ]]; then
+    _aw_doctor_problem "auto-worktree.age-warn-days is greater than auto-worktree.age-stale-days, so no worktree shows in yellow" \
+      "git config auto-worktree.age-warn-days 1"
+  fi
+
+  local ai_tool=$(_load_ai_preference)
+  case "$ai_tool" in
+    ""|claude|codex|gemini|jules|skip) ;;
+    *)
+      _aw_doctor_problem "auto-worktree.ai-tool is '$ai_tool' (expected claude, codex, gemini, jules or skip)" \
+        "git config auto-worktree.ai-tool claude"
+      ;;
+  esac
+
+  local templates_dir=$(_aw_get_issue_templates_dir)
+  if [[ -n "$templates_dir" ]] && [[ ! -d "$templates_dir" ]]; then
+    _aw_doctor_problem "auto-worktree.issue-templates-dir '$templates_dir' does not exist" \
+      "git config --unset auto-worktree.issue-templates-dir"
+  fi
+
+  local default_branch=$(_aw_get_config "default-branch")
+  if [[ -n "$default_branch" ]] && \
+    ! git show-ref --verify --quiet "refs/heads/$default_branch" && \
+    ! git show-ref --verify --quiet "refs/remotes/origin/$default_branch"; then
+    _aw_doctor_problem "auto-worktree.default-branch '$default_branch' does not exist" \
+      "git config --unset auto-worktree.default-branch"
+  fi
+
+  local naming=$(_aw_get_config "worktree-naming")
+  if [[ -n "$naming" ]]; then
+    local unknown=$(printf '%s' "$naming" | grep -o '{[^}]*}' | grep -v -x -e '{repo}' -e '{branch}' -e '{branch-basename}' | head -n 1)
+    if [[ -n "$unknown" ]]; then
+      _aw_doctor_problem "auto-worktree.worktree-naming uses unknown placeholder '$unknown' (expected {repo}, {branch} or {branch-basename})" \
+        "git config auto-worktree.worktree-naming \"{branch}\""
+    elif [[ "$naming" != *"{branch}"* ]] && [[ "$naming" != *"{branch-basename}"* ]]; then
+      _aw_doctor_problem "auto-worktree.worktree-naming '$naming' has no {branch} or {branch-basename}, so every worktree gets the same directory" \
+        "git config auto-worktree.worktree-naming \"{repo}-{branch}\""
+    fi
+  fi
+
+  # Custom hooks must exist in one of the hook directories
+  local custom_hooks=$(_aw_get_config "custom-hooks")
+  if [[ -n "$custom_hooks" ]]; then
+    local hook_dirs=()
+    local hook_dir
+    while IFS= read -r hook_dir; do
+      [[ -n "$hook_dir" ]] && hook_dirs+=("$hook_dir")
+    done < <(_aw_find_hook_paths "$_AW_GIT_ROOT")
+
+    local hook_name
+    local missing_hooks=()
+    local existing_hooks=()
+    for hook_name in $(echo "$custom_hooks" | tr ',' ' '); do
+      local found=false
+      for hook_dir in "${hook_dirs[@]}"; do
+        if [[ -x "$hook_dir/$hook_name" ]]; then
+          found=true
+          break
+        fi
+      done
+      if [[ "$found" == "true" ]]; then
+        existing_hooks+=("$hook_name")
+      else
+        missing_hooks+=("$hook_name")
+      fi
+    done
+
+    for hook_name in "${missing_hooks[@]}"; do
+      _aw_doctor_problem "auto-worktree.custom-hooks lists '$hook_name', but no executable hook with that name exists" \
+        "git config auto-worktree.custom-hooks \"${existing_hooks[*]}\""
+    done
+  fi
+
+  # Worktree base directory must be creatable/writable
+  local base_check="$_AW_WORKTREE_BASE"
+  while [[ ! -d "$base_check" ]] && [[ "$base_check" != "/" ]]; do
+    base_check=$(dirname "$base_check")
+  done
+  if [[ ! -w "$base_check" ]]; then
+    _aw_doctor_problem "Worktree directory $_AW_WORKTREE_BASE is not writable" \
+      "chmod u+w \"$base_check\""
+  fi
+
+  # Unknown keys (anything not in _AW_SETTING_CATEGORIES) are usually typos
+  local config_key
+  while IFS= read -r config_key; do
+    [[ -z "$config_key" ]] && continue
+    local name="${config_key#auto-worktree.}"
+    if ! _aw_is_known_setting "$name"; then
+      _aw_doctor_problem "Unknown setting auto-worktree.$name" \
+        "git config --unset auto-worktree.$name"
+    fi
+  done < <(git config --get-regexp '^auto-worktree\.' 2>/dev/null | awk '{print $1}' | sort -u)
+
+  if [[ $_AW_DOCTOR_PROBLEMS -gt 0 ]]; then
+    echo ""
+    gum style --foreground 1 "Found $_AW_DOCTOR_PROBLEMS configuration problem(s)"
+    return 1
+  fi
+
+  gum style --foreground 2 "✓ Configuration looks good"
+  return 0
+}
+
+_aw_doctor_repair() {
+  # Fix worktree registrations after directories were moved or a disk was
+  # remounted, then offer to prune registrations whose directories are gone
+  # Returns 1 if stale registrations were left in place
+  local common_dir=$(_aw_git_common_dir)
+  local registered=$(git worktree list --porcelain 2>/dev/null | grep "^worktree " | sed 's/^worktree //')
+
+  # Every registered worktree that still exists, plus this repository's
+  # worktrees that were moved elsewhere inside the worktree base
+  local -a candidates=()
+  local wt_path
+  while IFS= read -r wt_path; do
+    [[ -n "$wt_path" ]] && [[ -d "$wt_path" ]] && candidates+=("$wt_path")
+  done <<< "$registered"
+
+  if [[ -n "${_AW_WORKTREE_BASE:-}" ]] && [[ -d "$_AW_WORKTREE_BASE" ]]; then
+    local git_file
+    while IFS= read -r git_file; do
+      [[ -z "$git_file" ]] && continue
+      local admin_dir=$(sed -n 's/^gitdir: //p' "$git_file")
+      [[ "$(_aw_physical_path "$admin_dir")" == "$common_dir/worktrees/"* ]] || continue
+      wt_path=$(_aw_physical_path "$(dirname "$git_file")")
+      [[ $'\n'"$registered"$'\n' == *$'\n'"$wt_path"$'\n'* ]] && continue
+      candidates+=("$wt_path")
+    done <<< "$(find "$_AW_WORKTREE_BASE" -mindepth 2 -maxdepth 2 -name .git -type f 2>/dev/null)"
+  fi
+
+  # git reports each fix on stderr as "repair: <what was wrong>: <file>"
+  local repair_output
+  repair_output=$(_aw_with_lock git worktree repair "${candidates[@]}" 2>&1)
+  local repaired=0
+  local line
+  while IFS= read -r line; do
+    case "$line" in
+      "repair: "*)
+        gum style --foreground 2 "✓ Repaired ${line#repair: }"
+        repaired=$((repaired + 1))
+        ;;
+      "error: "*)
+        gum style --foreground 3 "⚠ ${line#error: }"
+        ;;
+    esac
+  done <<< "$repair_output"
+  [[ $repaired -eq 0 ]] && gum style --foreground 8 "No worktree registrations needed repair"
+
+  local -a missing=()
+  while IFS= read -r wt_path; do
+    [[ -n "$wt_path" ]] && [[ ! -d "$wt_path" ]] && missing+=("$wt_path")
+  done <<< "$(git worktree list --porcelain 2>/dev/null | grep "^worktree " | sed 's/^worktree //')"
+
+  if [[ ${#missing[@]} -eq 0 ]]; then
+    gum style --foreground 2 "✓ Every registered worktree exists"
+    return 0
+  fi
+
+  echo ""
+  gum style --foreground 3 "${#missing[@]} registered worktree(s) no longer exist:"
+  for wt_path in "${missing[@]}"; do
+    echo "  • $wt_path"
+  done
+
+  if _aw_is_quiet || ! gum confirm "Prune these registrations? Their branches are kept."; then
+    gum style --foreground 8 "Run 'git worktree prune' to remove them later"
+    return 1
+  fi
+
+  _aw_with_lock git worktree prune || return $?
+  gum style --foreground 2 "✓ Pruned ${#missing[@]} stale registration(s)"
+}
+
+# Self-test steps slower than this (in milliseconds) are flagged
+_AW_DOCTOR_SLOW_MS=1000
+
+_aw_doctor_time() {
+  # Run one self-test step and report whether it worked and how long it took
+  # Returns the command's exit status
+  # Usage: _aw_doctor_time label cmd [args...]
+  local label="$1"
+  shift
+
+  # The provider checks explain failures on stdout, so keep both streams
+  local out_file
+  out_file=$(mktemp "${TMPDIR:-/tmp}/aw-doctor.XXXXXX") || return 1
+  local start=$(_aw_now_ms)
+  "$@" >"$out_file" 2>&1
+  local exit_code=$?
+  local elapsed=$(($(_aw_now_ms) - start))
+
+  if [[ $exit_code -ne 0 ]]; then
+    gum style --foreground 1 "✗ $label failed (exit $exit_code, ${elapsed}ms)"
+    sed -n '1,3s/^/    /p' "$out_file"
+  elif [[ $elapsed -gt $_AW_DOCTOR_SLOW_MS ]]; then
+    gum style --foreground 3 "⚠ $label took ${elapsed}ms (slow disk or network filesystem?)"
+  else
+    gum style --foreground 2 "✓ $label (${elapsed}ms)"
+  fi
+  rm -f "$out_file"
+  return $exit_code
+}
+
+_aw_doctor_self_test() {
+  # Run harmless git commands and the issue provider's CLI checks, timing
+  # each, to diagnose slow checkouts or a misconfigured PATH
+  # Returns 1 if any step failed
+  local failed=false
+
+  _aw_doctor_time "git --version ($(command -v git))" git --version || failed=true
+  _aw_doctor_time "git rev-parse" git rev-parse --show-toplevel || failed=true
+  _aw_doctor_time "git worktree list" git worktree list --porcelain || failed=true
+  _aw_doctor_time "git config --get" git config --get core.bare || failed=true
+
+  local provider=$(_aw_get_issue_provider)
+  if [[ -z "$provider" ]]; then
+    gum style --foreground 8 "No issue provider configured; skipping the provider check"
+  else
+    local provider_name=$(_aw_provider_display_name "$provider")
+    if _aw_doctor_time "$provider_name CLI install check" _aw_check_issue_provider_deps "$provider"; then
+      local cli_version=$(_aw_provider_cli_version "$provider")
+      if _aw_check_provider_cli_version "$provider"; then
+        gum style --foreground 2 "✓ $(_aw_provider_cli "$provider") ${cli_version:-(version unknown)}"
+      fi
+      _aw_doctor_time "$provider_name CLI auth check" _aw_check_provider_auth "$provider" || failed=true
+    else
+      failed=true
+    fi
+  fi
+
+  [[ "$failed" == "false" ]]
+}
+
+_aw_doctor() {
+  _aw_ensure_git_repo || return $?
+  _aw_get_repo_info
+
+  local check_config=false
+  local repair=false
+  local self_test=false
+
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --check-config)
+        check_config=true
+        shift
+        ;;
+      --repair)
+        repair=true
+        shift
+        ;;
+      --self-test)
+        self_test=true
+        shift
+        ;;
+      *)
+        gum style --foreground 1 "Unknown option: $1"
+        return $AW_EXIT_USAGE
+        ;;
+    esac
+  done
+
+  # With no specific checks requested, run them all. Repairs change the
+  # repository and the self-test talks to the provider, so they only run
+  # on request.
+  if [[ "$check_config" == "false" ]] && [[ "$repair" == "false" ]] && [[ "$self_test" == "false" ]]; then
+    check_config=true
+  fi
+
+  local failed=false
+
+  if [[ "$check_config" == "true" ]]; then
+    gum style --foreground 6 "Checking configuration..."
+    _aw_doctor_check_config || failed=true
+  fi
+
+  if [[ "$repair" == "true" ]]; then
+    gum style --foreground 6 "Repairing worktree registrations..."
+    _aw_doctor_repair || failed=true
+  fi
+
+  if [[ "$self_test" == "true" ]]; then
+    gum style --foreground 6 "Running self-test..."
+    _aw_doctor_self_test || failed=true
+  fi
+
+  [[ "$failed" == "false" ]]
+}
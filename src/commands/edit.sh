@@ -0,0 +1,69 @@
+#!/bin/bash
+
+# ============================================================================
+# Open a worktree in an editor
+# ============================================================================
+
+_aw_get_editor() {
+  # Editor command for `edit`: auto-worktree.editor, then $VISUAL, then $EDITOR
+  local editor=$(_aw_get_config "editor")
+  echo "${editor:-${VISUAL:-${EDITOR:-}}}"
+}
+
+_aw_editor_is_gui() {
+  # GUI editors open their own window, so they are started in the background
+  # instead of taking over the terminal
+  local editor_bin=$(basename "${1%% *}")
+  case "$editor_bin" in
+    code|code-insiders|codium|cursor|windsurf|zed|subl|mate|atom|idea|fleet|gvim|mvim|gedit|kate)
+      return 0
+      ;;
+  esac
+  return 1
+}
+
+_aw_edit() {
+  _aw_ensure_git_repo || return $?
+  _aw_get_repo_info
+
+  # Without a target, open the worktree we're standing in
+  local target="${1:-}"
+  [[ -z "$target" ]] && target=$(_aw_current_worktree)
+
+  if [[ -z "$target" ]]; then
+    gum style --foreground 1 "Usage: auto-worktree edit [<branch|path>] (defaults to the current worktree)"
+    return $AW_EXIT_USAGE
+  fi
+
+  local wt_path
+  wt_path=$(_aw_resolve_worktree_target "$target")
+
+  if [[ -z "$wt_path" ]]; then
+    gum style --foreground 1 "Error: No worktree found for branch or path: $target"
+    return 1
+  fi
+
+  local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "")
+  if [[ -n "$wt_branch" ]] && [[ "$wt_branch" != "HEAD" ]]; then
+    _aw_touch_last_accessed "$wt_branch"
+  fi
+
+  local editor=$(_aw_get_editor)
+  if [[ -z "$editor" ]]; then
+    _aw_is_quiet || gum style --foreground 3 "No editor configured (set auto-worktree.editor, \$VISUAL or \$EDITOR)" >&2
+    echo "$wt_path"
+    return 0
+  fi
+
+  # Like git, run the editor through sh so values such as "code --wait" or
+  # "emacs -nw" keep their arguments
+  if _aw_editor_is_gui "$editor"; then
+    _aw_is_quiet || gum style --foreground 2 "Opening $wt_path in ${editor%% *}..."
+    (cd "$wt_path" && sh -c "$editor \"\$1\"" "$editor" "$wt_path" >/dev/null 2>&1 &)
+    return 0
+  fi
+
+  # Terminal editors take over the terminal from inside the worktree
+  cd "$wt_path" || return 1
+  sh -c "$editor \"\$1\"" "$editor" "$wt_path"
+}
@@ -0,0 +1,140 @@
+#!/bin/bash
+
+# ============================================================================
+# Search across worktrees
+# ============================================================================
+
+# Maximum number of worktrees searched at once (override with AW_GREP_JOBS)
+_AW_GREP_DEFAULT_JOBS=4
+
+_aw_grep_worktree() {
+  # Search one worktree, prefixing each match with its branch
+  # Uses ripgrep when available, otherwise git grep.
+  # Args: $1 = worktree path, $2 = pattern, $3 = "true" for case-insensitive,
+  #       $4 = directory to skip (the worktree base, if nested in the checkout)
+  local wt_path="$1"
+  local pattern="$2"
+  local ignore_case="${3:-false}"
+  local exclude_dir="${4:-}"
+
+  local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "unknown")
+  [[ "$wt_branch" == "HEAD" ]] && wt_branch="detached@$(git -C "$wt_path" rev-parse --short HEAD 2>/dev/null)"
+
+  local case_flag=()
+  [[ "$ignore_case" == "true" ]] && case_flag=(-i)
+
+  # git grep only searches tracked files, but rg would walk into worktrees
+  # nested in the checkout and report their matches twice
+  local exclude_flag=()
+  if [[ -n "$exclude_dir" ]] && [[ "$(_aw_worktree_base_overlap "$wt_path" "$exclude_dir")" == "inside" ]]; then
+    local wt_real=$(_aw_physical_path "$wt_path")
+    local exclude_real=$(_aw_physical_path "$exclude_dir")
+    [[ "$exclude_real" != "$wt_real" ]] && exclude_flag=(-g "!${exclude_real#"$wt_real"/}/**")
+  fi
+
+  if command -v rg &>/dev/null; then
+    (cd "$wt_path" && rg -n --no-heading --color never "${case_flag[@]}" "${exclude_flag[@]}" -e "$pattern" 2>/dev/null)
+  else
+    git -C "$wt_path" grep -n "${case_flag[@]}" -e "$pattern" 2>/dev/null
+  fi | sed "s|^|[${wt_branch}] |"
+}
+
+_aw_grep() {
+  _aw_ensure_git_repo || return $?
+  _aw_get_repo_info
+
+  local pattern=""
+  local branch_filter=""
+  local ignore_case=false
+
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --branch)
+        if [[ -z "${2:-}" ]]; then
+          gum style --foreground 1 "Error: --branch requires a branch name" >&2
+          return $AW_EXIT_USAGE
+        fi
+        branch_filter="$2"
+        shift 2
+        ;;
+      -i|--ignore-case)
+        ignore_case=true
+        shift
+        ;;
+      --)
+        # Everything after -- is the pattern, so it may start with "-"
+        shift
+        [[ $# -gt 0 ]] && pattern="$1"
+        break
+        ;;
+      -*)
+        gum style --foreground 1 "Unknown option: $1" >&2
+        return $AW_EXIT_USAGE
+        ;;
+      *)
+        pattern="$1"
+        shift
+        ;;
+    esac
+  done
+
+  if [[ -z "$pattern" ]]; then
+    gum style --foreground 1 "Usage: auto-worktree grep [--branch NAME] [-i] [--] <pattern>" >&2
+    return $AW_EXIT_USAGE
+  fi
+
+  local max_jobs="${AW_GREP_JOBS:-$_AW_GREP_DEFAULT_JOBS}"
+  if ! [[ "$max_jobs" =~ ^[0-9]+$ ]] || [[ $max_jobs -lt 1 ]]; then
+    max_jobs=$_AW_GREP_DEFAULT_JOBS
+  fi
+
+  # Don't print job-control noise for the background searches in zsh
+  if [[ -n "$ZSH_VERSION" ]]; then
+    setopt local_options no_monitor no_notify
+  fi
+
+  local results_dir
+  results_dir=$(mktemp -d "${TMPDIR:-/tmp}/aw-grep.XXXXXX") || return 1
+
+  # Search worktrees in batches of max_jobs, writing each worktree's matches
+  # to its own numbered file so output order follows the worktree list.
+  # Only our own jobs are waited for, not the user's other background jobs.
+  local index=0
+  local -a pids=()
+  local wt_path
+  while IFS= read -r wt_path; do
+    [[ -z "$wt_path" || ! -d "$wt_path" ]] && continue
+
+    if [[ -n "$branch_filter" ]]; then
+      local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null)
+      [[ "$wt_branch" != "$branch_filter" ]] && continue
+    fi
+
+    index=$((index + 1))
+    _aw_grep_worktree "$wt_path" "$pattern" "$ignore_case" "$_AW_WORKTREE_BASE" > "$results_dir/$(printf '%05d' "$index")" &
+    pids+=($!)
+
+    if [[ ${#pids[@]} -ge $max_jobs ]]; then
+      wait "${pids[@]}"
+      pids=()
+    fi
+  done <<< "$(_aw_get_worktree_list)"
+  [[ ${#pids[@]} -gt 0 ]] && wait "${pids[@]}"
+
+  if [[ $index -eq 0 ]] && [[ -n "$branch_filter" ]]; then
+    rm -rf "$results_dir"
+    gum style --foreground 1 "Error: No worktree found for branch: $branch_filter" >&2
+    return 1
+  fi
+
+  local found=false
+  local result_file
+  for result_file in "$results_dir"/*; do
+    [[ -s "$result_file" ]] || continue
+    cat "$result_file"
+    found=true
+  done
+  rm -rf "$results_dir"
+
+  [[ "$found" == "true" ]]
+}
@@ -3,111 +3,388 @@
 # ============================================================================
 # Issue integration
 # ============================================================================
+_aw_issue_preview() {
+  # Show an issue's title and body and ask whether to work on it
+  # Details are cached in the caller's preview_titles/preview_bodies/
+  # preview_urls associative arrays so browsing back and forth doesn't refetch.
+  # Returns 0 if the user picked this issue, 1 to go back to the list
+  local issue_id="$1"
+  local provider="$2"
+  local title=""
+  local body=""
+  local url=""
+
+  if [[ -n "${preview_titles[$issue_id]:-}" ]]; then
+    title="${preview_titles[$issue_id]}"
+    body="${preview_bodies[$issue_id]}"
+  else
+    _aw_get_issue_details "$issue_id" "$provider" || title=""
+    if [[ -z "$title" ]]; then
+      gum style --foreground 1 "Could not fetch $(_aw_provider_display_name "$provider") issue $(_aw_format_issue_ref "$issue_id" "$provider")" >&2
+      return 1
+    fi
+    preview_titles[$issue_id]="$title"
+    preview_bodies[$issue_id]="$body"
+    preview_urls[$issue_id]="$url"
+  fi
+
+  echo ""
+  gum style --border rounded --padding "0 1" --border-foreground 5 -- \
+    "$(_aw_provider_display_name "$provider") $(_aw_format_issue_ref "$issue_id" "$provider")" \
+    "$title"
+  echo ""
+  if [[ -n "$body" ]]; then
+    echo "$body" | gum format
+  else
+    gum style --foreground 8 "(no description)"
+  fi
+  echo ""
+
+  gum confirm "Work on this issue?"
+}
+
+_aw_issue_suggested_branch() {
+  # Suggested branch name for an issue: <branch-prefix>/<id>-<sanitized-title>
+  # Args: $1 = issue_id, $2 = provider, $3 = title
+  local sanitized=$(_aw_sanitize_branch_name "$3" | cut -c1-40)
+  echo "$(_aw_get_branch_prefix)/$(_aw_issue_branch_suffix "$1" "$2")-${sanitized}"
+}
+
+_aw_issue_link_branch() {
+  # For GitHub: register branch-issue link so PRs created from this branch
+  # automatically associate with the issue in the Development section
+  # Args: $1 = issue_id, $2 = provider, $3 = branch_name
+  [[ "$2" == "github" ]] || return 0
+
+  local base_branch=$(git symbolic-ref --short HEAD 2>/dev/null)
+  if [[ -z "$base_branch" ]]; then
+    _aw_cache_default_branch
+    base_branch=$(_aw_get_default_branch)
+  fi
+  if gh issue develop $(_aw_github_repo_flag) "$1" --name "$3" --base "$base_branch" >/dev/null 2>&1; then
+    _aw_is_quiet || gum style --foreground 2 "Branch linked to issue #${1}"
+  fi
+}
+
+_aw_issue_create_all() {
+  # Create worktrees for every open issue in a milestone without launching
+  # the AI tool, skipping issues that already have one and continuing past
+  # failures. Prints a created/skipped/failed summary.
+  # Args: $1 = provider, $2 = milestone_id, $3 = milestone_title
+  # Returns 1 if any worktree failed to be created
+  local provider="$1"
+  local ms_id="$2"
+  local ms_title="$3"
+  local term_lower=$(_aw_milestone_terminology "$provider" | tr '[:upper:]' '[:lower:]')
+
+  local issues
+  issues=$(_aw_list_issues_by_milestone "$provider" "$ms_id" "$ms_title")
+
+  if [[ -z "$issues" ]]; then
+    _aw_is_quiet || gum style --foreground 3 "No open issues found in ${term_lower} \"${ms_title}\""
+    return 0
+  fi
+
+  # Collect IDs up front so provider and git commands in the loop
+  # can't consume the list from stdin
+  local issue_ids=()
+  local issue_line
+  while IFS= read -r issue_line; do
+    [[ -n "$issue_line" ]] && issue_ids+=("$(_aw_extract_id_from_selection "$issue_line")")
+  done <<< "$issues"
+
+  # Summary rows: "status|ref|detail"
+  local results=()
+  local created=0 skipped=0 failed=0
+  _aw_cache_default_branch
+  local issue_id
+  for issue_id in "${issue_ids[@]}"; do
+    local issue_ref=$(_aw_format_issue_ref "$issue_id" "$provider")
+
+    local existing_worktree
+    if existing_worktree=$(_aw_find_worktree_for_issue "$issue_id" "$provider"); then
+      results+=("skipped|$issue_ref|worktree exists at $existing_worktree")
+      skipped=$((skipped + 1))
+      continue
+    fi
+
+    local title="" body="" url=""
+    if ! _aw_get_issue_details "$issue_id" "$provider" </dev/null || [[ -z "$title" ]]; then
+      results+=("failed|$issue_ref|could not fetch issue details")
+      failed=$((failed + 1))
+      continue
+    fi
+
+    local branch_name=$(_aw_issue_suggested_branch "$issue_id" "$provider" "$title")
+    _aw_issue_link_branch "$issue_id" "$provider" "$branch_name" </dev/null
+
+    local add_status=0
+    _aw_add_worktree "$branch_name" </dev/null || add_status=$?
+    if [[ $add_status -eq 0 ]]; then
+      _aw_record_issue_metadata "$branch_name" "$provider" "$issue_id" "$title" "$url"
+      results+=("created|$issue_ref|$branch_name")
+      created=$((created + 1))
+    elif [[ $add_status -eq $AW_EXIT_EXISTS ]]; then
+      results+=("skipped|$issue_ref|branch $branch_name already has a worktree")
+      skipped=$((skipped + 1))
+    else
+      results+=("failed|$issue_ref|could not create worktree for $branch_name")
+      failed=$((failed + 1))
+    fi
+  done
+
+  if ! _aw_is_quiet; then
+    echo ""
+    gum style --border rounded --padding "0 1" --border-foreground 4 \
+      "$(_aw_milestone_terminology "$provider"): $ms_title" \
+      "Created: $created  Skipped: $skipped  Failed: $failed"
+    echo ""
+  fi
+
+  local row
+  for row in "${results[@]}"; do
+    local row_status="${row%%|*}"
+    local rest="${row#*|}"
+    local row_ref="${rest%%|*}"
+    local row_detail="${rest#*|}"
+    # Quiet mode already printed each created path; only report failures
+    if _aw_is_quiet; then
+      [[ "$row_status" == "failed" ]] && echo "$row_ref: $row_detail" >&2
+      continue
+    fi
+    local color=2
+    [[ "$row_status" == "skipped" ]] && color=3
+    [[ "$row_status" == "failed" ]] && color=1
+    gum style --foreground "$color" "$(printf '  %-8s %-12s %s' "$row_status" "$row_ref" "$row_detail")"
+  done
+
+  [[ $failed -eq 0 ]]
+}
+
 _aw_issue() {
-  _aw_ensure_git_repo || return 1
+  _aw_ensure_git_repo || return $?
   _aw_get_repo_info
 
+  # Keep the original arguments for re-displaying the list after
+  # toggling auto-select
+  local issue_args=("$@")
+  local issue_id=""
+  local flag_preview=false
+  local flag_all=false
+  local flag_resume=false
+  local milestone_name=""
+  local _AW_HOOKS_OVERRIDE="${_AW_HOOKS_OVERRIDE:-}"
+  local _AW_FETCH_OVERRIDE="${_AW_FETCH_OVERRIDE:-}"
+  # --write-branch <file>, read by _aw_write_branch_file
+  local _AW_WRITE_BRANCH_FILE="${_AW_WRITE_BRANCH_FILE:-}"
+  # One-off JQL for the JIRA issue list, read by _aw_jira_list_issues
+  local _AW_JIRA_JQL="${_AW_JIRA_JQL:-}"
+  # Also list closed issues, read by the GitHub and GitLab issue lists
+  local _AW_ISSUE_INCLUDE_CLOSED="${_AW_ISSUE_INCLUDE_CLOSED:-false}"
+
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --no-hooks)
+        _AW_HOOKS_OVERRIDE=false
+        shift
+        ;;
+      --hooks)
+        _AW_HOOKS_OVERRIDE=true
+        shift
+        ;;
+      --fetch)
+        _AW_FETCH_OVERRIDE=true
+        shift
+        ;;
+      --no-fetch)
+        _AW_FETCH_OVERRIDE=false
+        shift
+        ;;
+      --preview)
+        flag_preview=true
+        shift
+        ;;
+      --milestone)
+        if [[ -z "${2:-}" ]]; then
+          gum style --foreground 1 "Error: --milestone requires a name, e.g. --milestone \"v2.0\"" >&2
+          return $AW_EXIT_USAGE
+        fi
+        milestone_name="$2"
+        shift 2
+        ;;
+      --all)
+        flag_all=true
+        shift
+        ;;
+      --include-closed)
+        _AW_ISSUE_INCLUDE_CLOSED=true
+        shift
+        ;;
+      --resume)
+        flag_resume=true
+        shift
+        ;;
+      --write-branch|--write-branch=*)
+        if [[ "$1" == --write-branch=* ]]; then
+          _AW_WRITE_BRANCH_FILE="${1#--write-branch=}"
+          shift
+        else
+          _AW_WRITE_BRANCH_FILE="${2:-}"
+          shift $(( $# > 1 ? 2 : 1 ))
+        fi
+        if [[ -z "$_AW_WRITE_BRANCH_FILE" ]]; then
+          gum style --foreground 1 "Error: --write-branch needs a file, e.g. --write-branch \"\$GITHUB_OUTPUT\"" >&2
+          return $AW_EXIT_USAGE
+        fi
+        ;;
+      --jql)
+        if [[ -z "${2:-}" ]]; then
+          gum style --foreground 1 "Error: --jql requires a query, e.g. --jql 'sprint in openSprints()'" >&2
+          return $AW_EXIT_USAGE
+        fi
+        _AW_JIRA_JQL="$2"
+        shift 2
+        ;;
+      -*)
+        gum style --foreground 1 "Unknown option: $1" >&2
+        return $AW_EXIT_USAGE
+        ;;
+      *)
+        issue_id="$1"
+        shift
+        ;;
+    esac
+  done
+
+  if [[ "$flag_all" == "true" ]] && [[ -z "$milestone_name" ]]; then
+    gum style --foreground 1 "Usage: auto-worktree issue --milestone <name> --all" >&2
+    return $AW_EXIT_USAGE
+  fi
+
+  if [[ -n "$_AW_WRITE_BRANCH_FILE" ]] && [[ "$flag_all" == "true" ]]; then
+    gum style --foreground 1 "Error: --write-branch needs a single new branch; it can't be combined with --all" >&2
+    return $AW_EXIT_USAGE
+  fi
+
+  if [[ "$flag_resume" == "true" ]] && [[ -z "$issue_id" ]]; then
+    gum style --foreground 1 "Usage: auto-worktree issue <id> --resume" >&2
+    return $AW_EXIT_USAGE
+  fi
+
+  if [[ -n "$_AW_JIRA_JQL" ]] && { [[ -n "$issue_id" ]] || [[ -n "$milestone_name" ]]; }; then
+    gum style --foreground 1 "Error: --jql filters the issue list; it can't be combined with an issue ID or --milestone" >&2
+    return $AW_EXIT_USAGE
+  fi
+
   # Determine issue provider
   local provider
-  provider=$(_aw_init_issue_provider) || return 1
+  provider=$(_aw_init_issue_provider) || return $AW_EXIT_PROVIDER
 
-  # Detect if argument is GitHub/GitLab issue number or JIRA key
-  local issue_id="${1:-}"
-  local issue_type=""
+  if [[ "$_AW_ISSUE_INCLUDE_CLOSED" == "true" ]] && [[ "$provider" != "github" ]] && [[ "$provider" != "gitlab" ]]; then
+    gum style --foreground 1 "Error: --include-closed is only supported for GitHub and GitLab issues" >&2
+    return $AW_EXIT_USAGE
+  fi
 
-  if [[ -n "$issue_id" ]]; then
-    # Auto-detect issue type from input
-    if [[ "$issue_id" =~ ^[A-Z][A-Z0-9]+-[0-9]+$ ]]; then
-      issue_type="jira"
-    elif [[ "$issue_id" =~ ^[0-9]+$ ]]; then
-      # Both GitHub and GitLab use numbers, so use the configured provider
-      issue_type="$provider"
-    else
-      gum style --foreground 1 "Invalid issue format. Expected: issue number (e.g., 123) or JIRA key (e.g., PROJ-123)"
+  if [[ -n "$_AW_JIRA_JQL" ]] && [[ "$provider" != "jira" ]]; then
+    gum style --foreground 1 "Error: --jql only applies to JIRA; this repository uses $(_aw_provider_display_name "$provider")" >&2
+    return $AW_EXIT_USAGE
+  fi
+
+  if [[ -n "$milestone_name" ]]; then
+    local milestone_id=""
+    local milestone_title=""
+    local terminology=$(_aw_milestone_terminology "$provider")
+    if ! _aw_resolve_milestone "$provider" "$milestone_name"; then
+      gum style --foreground 1 "Error: No open ${terminology} named \"${milestone_name}\"" >&2
       return 1
     fi
 
-    # Validate issue type matches provider (only warn for JIRA mismatch)
-    if [[ "$issue_type" == "jira" ]] && [[ "$provider" != "jira" ]]; then
-      gum style --foreground 3 "Warning: This repository is configured for $provider, but you provided a JIRA issue ID"
+    if [[ "$flag_all" == "true" ]]; then
+      _aw_issue_create_all "$provider" "$milestone_id" "$milestone_title"
+      return $?
+    fi
+
+    # Pick a single issue from the milestone, then continue as usual
+    _aw_select_issue_by_milestone "$provider" "$milestone_id" "$milestone_title" "$terminology" || return $?
+    # Nothing to pick in an empty milestone
+    [[ -z "$issue_id" ]] && return 0
+  fi
+
+  # Issue details fetched for --preview, keyed by issue ID
+  typeset -A preview_titles preview_bodies preview_urls
+
+  # Detect if argument is a GitHub/GitLab issue number or a JIRA/Linear key
+  local issue_type=""
+
+  if [[ -n "$issue_id" ]]; then
+    issue_type=$(_aw_detect_issue_type "$issue_id" "$provider") || {
+      gum style --foreground 1 "Invalid issue format. Expected: issue number (e.g., 123) or issue key (e.g., PROJ-123)" >&2
+      return $AW_EXIT_USAGE
+    }
+    issue_id="${issue_id#\#}"
+
+    # Validate issue type matches provider (only warn for key/number family mismatch)
+    if [[ "$issue_type" != "$provider" ]]; then
+      gum style --foreground 3 "Warning: This repository is configured for $(_aw_provider_display_name "$provider"), but you provided a $(_aw_provider_display_name "$issue_type") issue ID" >&2
       if ! gum confirm "Continue anyway?"; then
         return 0
       fi
-      provider="jira"
+      provider="$issue_type"
+    fi
+
+    # With --resume, or with no terminal to ask on, go straight to the
+    # issue's worktree instead of offering it
+    local resume_worktree
+    if resume_worktree=$(_aw_find_worktree_for_issue "$issue_id" "$provider"); then
+      if [[ "$flag_resume" == "true" ]] || ! _aw_stdin_is_tty; then
+        _aw_reattach_worktree "$resume_worktree"
+        return $?
+      fi
+    elif [[ "$flag_resume" == "true" ]]; then
+      gum style --foreground 1 "Error: No worktree for $(_aw_provider_display_name "$provider") issue $(_aw_format_issue_ref "$issue_id" "$provider")" >&2
+      echo "Create one with: auto-worktree issue $issue_id" >&2
+      return 1
     fi
   fi
 
+  local provider_name=$(_aw_provider_display_name "$provider")
+
   if [[ -z "$issue_id" ]]; then
-    gum spin --spinner dot --title "Fetching issues..." -- sleep 0.1
-
-    local issues=""
-    if [[ "$provider" == "jira" ]]; then
-      issues=$(_aw_jira_list_issues)
-    elif [[ "$provider" == "gitlab" ]]; then
-      issues=$(_aw_gitlab_list_issues)
-    elif [[ "$provider" == "linear" ]]; then
-      issues=$(_aw_linear_list_issues)
-    else
-      issues=$(gh issue list --limit 100 --state open --json number,title,labels \
-        --template '{{range .}}#{{.number}} | {{.title}}{{if .labels}} |{{range .labels}} [{{.name}}]{{end}}{{end}}{{"\n"}}{{end}}' 2>/dev/null)
+    _aw_is_quiet || gum spin --spinner dot --title "Fetching issues..." -- sleep 0.1
+
+    local _AW_LABEL_COLORS_FILE=$(_aw_label_colors_file "$provider")
+    local issues list_status=0
+    issues=$(_aw_list_issues "$provider") || list_status=$?
+    local label_colors=$(_aw_take_label_colors)
+    if [[ $list_status -ne 0 ]]; then
+      gum style --foreground 1 "Error: Could not list $provider_name issues" >&2
+      return $AW_EXIT_PROVIDER
     fi
 
+    # An empty backlog is nothing to pick from, not a failure
     if [[ -z "$issues" ]]; then
-      if [[ "$provider" == "jira" ]]; then
-        gum style --foreground 1 "No open JIRA issues found"
-      elif [[ "$provider" == "gitlab" ]]; then
-        gum style --foreground 1 "No open GitLab issues found"
-      elif [[ "$provider" == "linear" ]]; then
-        gum style --foreground 1 "No open Linear issues found"
-      else
-        gum style --foreground 1 "No open GitHub issues found"
+      if ! _aw_is_quiet; then
+        gum style --foreground 3 "No open $provider_name issues found"
+        gum style --foreground 8 "Create one with 'aw create', or start a worktree with 'aw new'"
       fi
-      return 1
+      return 0
     fi
 
-    # Detect which issues have active worktrees
-    local active_issues=()
-    local worktree_list
-    worktree_list=$(_aw_get_worktree_list)
-    if [[ -n "$worktree_list" ]]; then
-      while IFS= read -r wt_path; do
-        _aw_validate_worktree_path "$wt_path" || continue
-        local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "")
-        if [[ -n "$wt_branch" ]]; then
-          local wt_issue=$(_aw_extract_issue_id_from_branch "$wt_branch" "$provider")
-          if [[ -n "$wt_issue" ]]; then
-            active_issues+=("$wt_issue")
-          fi
-        fi
-      done <<< "$worktree_list"
+    # Mark issues with active worktrees and list them first; picking one
+    # offers to resume it below
+    local highlighted_issues="$(_aw_mark_active_issues "$issues" "$provider")"$'\n'
+
+    # Keep rows on one line in narrow terminals (less gum filter's indicator)
+    local term_width
+    if term_width=$(_aw_terminal_width); then
+      highlighted_issues="$(_aw_compact_issue_list "$highlighted_issues" $((term_width - 4)))"$'\n'
     fi
 
-    # Add highlighting for issues with active worktrees
-    local highlighted_issues=""
-    while IFS= read -r issue_line; do
-      if [[ -n "$issue_line" ]]; then
-        # Extract issue ID from the line
-        local line_issue=$(_aw_extract_id_from_selection "$issue_line")
-        # Check if this issue has an active worktree
-        local is_active=false
-        for active in "${active_issues[@]}"; do
-          if [[ "$active" == "$line_issue" ]]; then
-            is_active=true
-            break
-          fi
-        done
-        # Add indicator if active
-        if [[ "$is_active" == "true" ]]; then
-          if [[ "$provider" == "jira" ]] || [[ "$provider" == "linear" ]]; then
-            highlighted_issues+="● $issue_line"$'\n'
-          else
-            highlighted_issues+="$(echo "$issue_line" | sed 's/^#/● #/')"$'\n'
-          fi
-        else
-          highlighted_issues+="$issue_line"$'\n'
-        fi
-      fi
-    done <<< "$issues"
+    # Draw label chips in their own colors; the AI sees plain rows
+    local display_issues="$highlighted_issues"
+    if [[ -n "$label_colors" ]]; then
+      display_issues="$(_aw_colorize_issue_labels "$highlighted_issues" "$label_colors")"$'\n'
+    fi
 
     # Build the selection list with auto-select options
     local selection_list=""
@@ -115,23 +392,39 @@ _aw_issue() {
       # Auto-select is enabled - show auto-select options at the top
       selection_list="⚡ Auto select"$'\n'
       selection_list+="🚫 Do not show me auto select again"$'\n'
-      selection_list+="$highlighted_issues"
+      selection_list+="$display_issues"
     else
       # Auto-select is disabled - add re-enable option at the end
-      selection_list="$highlighted_issues"
+      selection_list="$display_issues"
       selection_list+="⚡ Auto select next issue"$'\n'
     fi
 
-    local selection=$(echo "$selection_list" | gum filter --placeholder "Type to filter issues... (● = active worktree)")
+    local selection=""
+    while true; do
+      selection=$(echo "$selection_list" | gum filter --placeholder "Type to filter issues... (● = active worktree)")
+      selection=$(_aw_strip_ansi "$selection")
 
-    if [[ -z "$selection" ]]; then
-      gum style --foreground 3 "Cancelled"
-      return $AW_EXIT_CANCELLED
-    fi
+      if [[ -z "$selection" ]]; then
+        gum style --foreground 3 "Cancelled" >&2
+        return $AW_EXIT_CANCELLED
+      fi
+
+      # With --preview, show the issue body before committing to it and
+      # return to the list if the user declines
+      if [[ "$flag_preview" != "true" ]]; then
+        break
+      fi
+      case "$selection" in
+        "⚡ "*|"🚫 "*) break ;;
+      esac
+      if _aw_issue_preview "$(_aw_extract_id_from_selection "$selection")" "$provider"; then
+        break
+      fi
+    done
 
     # Handle special auto-select options (GitHub and Linear)
     if [[ ("$provider" == "github" || "$provider" == "linear") ]] && [[ "$selection" == "⚡ Auto select" ]]; then
-      gum spin --spinner dot --title "AI is selecting best issues..." -- sleep 0.5
+      _aw_is_quiet || gum spin --spinner dot --title "AI is selecting best issues..." -- sleep 0.5
 
       local filtered_issues=""
       if [[ "$provider" == "github" ]]; then
@@ -141,9 +434,9 @@ _aw_issue() {
       fi
 
       if [[ -z "$filtered_issues" ]]; then
-        gum style --foreground 1 "AI selection failed, showing all issues"
+        gum style --foreground 1 "AI selection failed, showing all issues" >&2
         filtered_issues="$highlighted_issues"
-      else
+      elif ! _aw_is_quiet; then
         echo ""
         gum style --foreground 2 "✓ AI selected top 5 issues in priority order"
         echo ""
@@ -153,7 +446,7 @@ _aw_issue() {
       selection=$(echo "$filtered_issues" | gum filter --placeholder "Select an issue from AI recommendations")
 
       if [[ -z "$selection" ]]; then
-        gum style --foreground 3 "Cancelled"
+        gum style --foreground 3 "Cancelled" >&2
         return $AW_EXIT_CANCELLED
       fi
 
@@ -161,16 +454,16 @@ _aw_issue() {
 
     elif [[ ("$provider" == "github" || "$provider" == "linear") ]] && [[ "$selection" == "🚫 Do not show me auto select again" ]]; then
       _disable_autoselect
-      gum style --foreground 3 "Auto-select disabled. You can re-enable it from the bottom of the issue list."
+      _aw_is_quiet || gum style --foreground 3 "Auto-select disabled. You can re-enable it from the bottom of the issue list."
       # Recursively call to show the updated list
-      _aw_issue "$@"
+      _aw_issue "${issue_args[@]}"
       return $?
 
     elif [[ "$provider" == "github" ]] && [[ "$selection" == "⚡ Auto select next issue" ]]; then
       _enable_autoselect
-      gum style --foreground 2 "Auto-select re-enabled!"
+      _aw_is_quiet || gum style --foreground 2 "Auto-select re-enabled!"
       # Recursively call to show the updated list
-      _aw_issue "$@"
+      _aw_issue "${issue_args[@]}"
       return $?
 
     else
@@ -182,30 +475,17 @@ _aw_issue() {
   # Fetch issue details including body
   local title=""
   local body=""
+  local url=""
 
-  if [[ "$provider" == "jira" ]]; then
-    _aw_jira_get_issue_details "$issue_id" || {
-      gum style --foreground 1 "Could not fetch JIRA issue $issue_id"
-      return 1
-    }
-  elif [[ "$provider" == "gitlab" ]]; then
-    _aw_gitlab_get_issue_details "$issue_id" || {
-      gum style --foreground 1 "Could not fetch GitLab issue #$issue_id"
-      return 1
-    }
-  elif [[ "$provider" == "linear" ]]; then
-    _aw_linear_get_issue_details "$issue_id" || {
-      gum style --foreground 1 "Could not fetch Linear issue $issue_id"
-      return 1
-    }
-  else
-    title=$(gh issue view "$issue_id" --json title --jq '.title' 2>/dev/null)
-    body=$(gh issue view "$issue_id" --json body --jq '.body // ""' 2>/dev/null)
+  local issue_ref=$(_aw_format_issue_ref "$issue_id" "$provider")
 
-    if [[ -z "$title" ]]; then
-      gum style --foreground 1 "Could not fetch GitHub issue #$issue_id"
-      return 1
-    fi
+  if [[ -n "${preview_titles[$issue_id]:-}" ]]; then
+    title="${preview_titles[$issue_id]}"
+    body="${preview_bodies[$issue_id]}"
+    url="${preview_urls[$issue_id]}"
+  elif ! _aw_get_issue_details "$issue_id" "$provider" || [[ -z "$title" ]]; then
+    gum style --foreground 1 "Could not fetch $provider_name issue $issue_ref" >&2
+    return $AW_EXIT_PROVIDER
   fi
 
   # Check if a worktree already exists for this issue
@@ -214,108 +494,104 @@ _aw_issue() {
 
   # If an active worktree exists for this issue, offer to resume it
   if [[ -n "$existing_worktree" ]]; then
-    echo ""
-    if [[ "$provider" == "jira" ]]; then
-      gum style --foreground 3 "Active worktree found for JIRA issue $issue_id:"
+    if ! _aw_is_quiet; then
+      echo ""
+      gum style --foreground 3 "Active worktree found for $provider_name issue $issue_ref:"
+      echo "  $existing_worktree"
+    fi
+
+    # The issue may have been finished since the worktree was created
+    local issue_state=$(_aw_issue_finished_state "$issue_id" "$provider")
+    if [[ -n "$issue_state" ]]; then
+      gum style --foreground 1 "⚠ $provider_name issue $issue_ref is already $issue_state" >&2
+      _aw_is_quiet || gum style --foreground 8 "  Resuming may mean working on something that's done"
     else
-      gum style --foreground 3 "Active worktree found for GitHub issue #$issue_id:"
+      _aw_is_quiet || gum style --foreground 8 "  Issue is still open"
     fi
-    echo "  $existing_worktree"
-    echo ""
+    _aw_is_quiet || echo ""
 
     if gum confirm "Resume existing worktree?"; then
       cd "$existing_worktree" || return 1
+      local existing_branch=$(git rev-parse --abbrev-ref HEAD 2>/dev/null)
+      _aw_touch_last_accessed "$existing_branch"
 
-      # Set terminal title
-      if [[ "$provider" == "jira" ]]; then
-        printf '\033]0;JIRA %s - %s\007' "$issue_id" "$title"
+      # The resumed path is the only output scripts need
+      if _aw_is_quiet; then
+        echo "$existing_worktree"
       else
-        printf '\033]0;GitHub Issue #%s - %s\007' "$issue_id" "$title"
+        # Set terminal title
+        printf '\033]0;%s %s - %s\007' "$provider_name" "$issue_ref" "$title"
       fi
+      _aw_name_tmux_window "$existing_branch"
 
       _resolve_ai_command || return 1
 
       if [[ "${AI_CMD[1]}" != "skip" ]]; then
-        gum style --foreground 2 "Starting $AI_CMD_NAME..."
+        _aw_is_quiet || gum style --foreground 2 "Starting $AI_CMD_NAME..."
         "${AI_CMD[@]}"
       else
-        gum style --foreground 3 "Skipping AI tool - worktree is ready for manual work"
+        _aw_is_quiet || gum style --foreground 3 "Skipping AI tool - worktree is ready for manual work"
       fi
       return 0
-    else
+    elif ! _aw_is_quiet; then
       echo ""
       gum style --foreground 3 "Continuing to create new worktree..."
       echo ""
     fi
   fi
 
-  # Generate suggested branch name
-  local sanitized=$(_aw_sanitize_branch_name "$title" | cut -c1-40)
-  local suggested=""
-
-  if [[ "$provider" == "jira" ]]; then
-    suggested="work/${issue_id}-${sanitized}"
-  else
-    suggested="work/${issue_id}-${sanitized}"
+  # A closed issue was picked on purpose with --include-closed; still say so
+  if [[ "$_AW_ISSUE_INCLUDE_CLOSED" == "true" ]]; then
+    local finished_state=$(_aw_issue_finished_state "$issue_id" "$provider")
+    if [[ -n "$finished_state" ]]; then
+      gum style --foreground 3 "⚠ $provider_name issue $issue_ref is already $finished_state" >&2
+      if ! gum confirm "Create a worktree for it anyway?"; then
+        gum style --foreground 3 "Cancelled" >&2
+        return $AW_EXIT_CANCELLED
+      fi
+    fi
   fi
 
-  echo ""
-  if [[ "$provider" == "jira" ]]; then
-    gum style --border rounded --padding "0 1" --border-foreground 5 -- \
-      "JIRA ${issue_id}" \
-      "$title"
-  else
+  # Generate suggested branch name
+  local suggested=$(_aw_issue_suggested_branch "$issue_id" "$provider" "$title")
+
+  if ! _aw_is_quiet; then
+    echo ""
     gum style --border rounded --padding "0 1" --border-foreground 5 -- \
-      "Issue #${issue_id}" \
+      "$provider_name ${issue_ref}" \
       "$title"
-  fi
 
-  echo ""
-  gum style --foreground 6 "Confirm branch name:"
+    echo ""
+    gum style --foreground 6 "Confirm branch name:"
+  fi
   local branch_name=$(gum input --value "$suggested" --placeholder "Branch name")
 
   if [[ -z "$branch_name" ]]; then
-    gum style --foreground 3 "Cancelled"
+    gum style --foreground 3 "Cancelled" >&2
     return $AW_EXIT_CANCELLED
   fi
 
   # Prepare context to pass to AI tool
-  local ai_context=""
-  if [[ "$provider" == "jira" ]]; then
-    ai_context="I'm working on JIRA issue ${issue_id}.
+  local ai_context="I'm working on $provider_name issue ${issue_ref}.
 
 Title: ${title}
 
 ${body}
 
 Ask clarifying questions about the intended work if you can think of any."
-  else
-    ai_context="I'm working on GitHub issue #${issue_id}.
 
-Title: ${title}
-
-${body}
+  # Set terminal title
+  _aw_is_quiet || printf '\033]0;%s %s - %s\007' "$provider_name" "$issue_ref" "$title"
 
-Ask clarifying questions about the intended work if you can think of any."
-  fi
+  _aw_issue_link_branch "$issue_id" "$provider" "$branch_name"
 
-  # Set terminal title
-  if [[ "$provider" == "jira" ]]; then
-    printf '\033]0;JIRA %s - %s\007' "$issue_id" "$title"
-  else
-    printf '\033]0;GitHub Issue #%s - %s\007' "$issue_id" "$title"
-  fi
+  _aw_add_worktree "$branch_name" || return $?
 
-  # For GitHub: register branch-issue link so PRs created from this branch
-  # automatically associate with the issue in the Development section
-  if [[ "$provider" == "github" ]]; then
-    local base_branch
-    base_branch=$(git symbolic-ref --short HEAD 2>/dev/null || echo "main")
-    if gh issue develop "$issue_id" --name "$branch_name" --base "$base_branch" >/dev/null 2>&1; then
-      gum style --foreground 2 "Branch linked to issue #${issue_id}"
-    fi
-  fi
+  # Remember which issue this worktree came from
+  _aw_record_issue_metadata "$branch_name" "$provider" "$issue_id" "$title" "$url"
+  _aw_write_branch_file "$branch_name" || return $?
 
-  _aw_create_worktree "$branch_name" "$ai_context"
+  _aw_events_enabled && return 0
+  _aw_launch_worktree "$_AW_CREATED_WORKTREE_PATH" "$branch_name" "$ai_context"
 }
 
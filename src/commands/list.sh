@@ -3,22 +3,380 @@
 # ============================================================================
 # List worktrees
 # ============================================================================
+
+# Seconds to wait for du on a single worktree before giving up
+_AW_LIST_SIZE_TIMEOUT=30
+# Maximum number of worktrees measured at once
+_AW_LIST_SIZE_JOBS=4
+
+_aw_list_compute_sizes() {
+  # Measure every worktree in the list concurrently (in batches), writing
+  # each human-readable size to results_dir/<line number>. Worktrees that
+  # time out or can't be read get "?".
+  # Usage: _aw_list_compute_sizes results_dir worktree_list
+  local results_dir="$1"
+  local worktree_list="$2"
+
+  if [[ -n "$ZSH_VERSION" ]]; then
+    setopt local_options no_monitor no_notify
+  fi
+
+  # Only these jobs are waited for, not the user's other background jobs
+  local index=0
+  local -a pids=()
+  local wt_path
+  while IFS= read -r wt_path; do
+    index=$((index + 1))
+    [[ -d "$wt_path" ]] || continue
+    (
+      local size_kb
+      if size_kb=$(_aw_get_dir_size_kb "$wt_path" "$_AW_LIST_SIZE_TIMEOUT" "$_AW_WORKTREE_BASE"); then
+        _aw_format_size "$size_kb"
+      else
+        echo "?"
+      fi
+    ) > "$results_dir/$index" &
+    pids+=($!)
+    if [[ ${#pids[@]} -ge $_AW_LIST_SIZE_JOBS ]]; then
+      wait "${pids[@]}"
+      pids=()
+    fi
+  done <<< "$worktree_list"
+  if [[ ${#pids[@]} -gt 0 ]]; then
+    wait "${pids[@]}"
+  fi
+}
+
+# Maximum number of worktrees whose git status is read at once
+_AW_LIST_STATUS_JOBS=8
+
+_aw_list_compute_status() {
+  # Read the local git state of every worktree in the list concurrently (in
+  # batches), writing results_dir/<line number> with one value per line:
+  # branch, last commit timestamp, unpushed commit count (0 if none),
+  # "true" if it has no changes from the default branch, and the ahead/behind
+  # label. Each worktree's result keeps its position in the list.
+  # With with_status "false" only the branch and timestamp are read.
+  # Usage: _aw_list_compute_status results_dir worktree_list [with_status]
+  local results_dir="$1"
+  local worktree_list="$2"
+  local with_status="${3:-true}"
+
+  if [[ -n "$ZSH_VERSION" ]]; then
+    setopt local_options no_monitor no_notify
+  fi
+
+  # Only these jobs are waited for, not the user's other background jobs
+  local index=0
+  local -a pids=()
+  local wt_path
+  while IFS= read -r wt_path; do
+    index=$((index + 1))
+    [[ -d "$wt_path" ]] || continue
+    (
+      local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "unknown")
+      echo "$wt_branch"
+      _aw_get_worktree_timestamp "$wt_path" "$wt_branch"
+      if [[ "$with_status" != "true" ]]; then
+        printf '0\nfalse\n\n'
+        exit 0
+      fi
+      if _aw_has_unpushed_commits "$wt_path"; then
+        echo "$_AW_UNPUSHED_COUNT"
+      else
+        echo 0
+      fi
+      if _aw_check_no_changes_from_default "$wt_path"; then
+        echo true
+      else
+        echo false
+      fi
+      _aw_format_ahead_behind "$wt_path"
+    ) > "$results_dir/$index" &
+    pids+=($!)
+    if [[ ${#pids[@]} -ge $_AW_LIST_STATUS_JOBS ]]; then
+      wait "${pids[@]}"
+      pids=()
+    fi
+  done <<< "$worktree_list"
+  if [[ ${#pids[@]} -gt 0 ]]; then
+    wait "${pids[@]}"
+  fi
+}
+
+# Placeholders available to list --format
+_AW_LIST_FORMAT_FIELDS="name branch path timestamp age opened issue"
+
+_aw_list_format_check() {
+  # Validate a list --format template before any worktree is read
+  # Returns 1 (after explaining) if it uses an unknown placeholder
+  local template="$1"
+  local placeholder
+  while IFS= read -r placeholder; do
+    [[ -z "$placeholder" ]] && continue
+    if [[ " $_AW_LIST_FORMAT_FIELDS " != *" ${placeholder:1:${#placeholder}-2} "* ]]; then
+      gum style --foreground 1 "Error: Unknown field '$placeholder' in --format" >&2
+      echo "  Available fields: $(echo "$_AW_LIST_FORMAT_FIELDS" | sed 's/[a-z-]*/{&}/g')" >&2
+      return 1
+    fi
+  done <<< "$(printf '%s' "$template" | grep -o '{[^}]*}')"
+  return 0
+}
+
+_aw_list_format() {
+  # Print one line per worktree from a template such as "{branch}\t{path}".
+  # \t and \n in the template are expanded; the template must already have
+  # passed _aw_list_format_check.
+  # Usage: _aw_list_format template worktree_list
+  local template="$1"
+  local worktree_list="$2"
+
+  # Expand the template's own escapes, not any in branch names or titles
+  template="${template//\\t/$'\t'}"
+  template="${template//\\n/$'\n'}"
+
+  local wt_path
+  while IFS= read -r wt_path; do
+    _aw_validate_worktree_path "$wt_path" || continue
+
+    local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "unknown")
+    local timestamp=$(_aw_get_worktree_timestamp "$wt_path" "$wt_branch")
+    # "[3d ago]" without the brackets
+    local age=$(_aw_format_worktree_age "$timestamp")
+    age="${age#\[}"
+    age="${age%\]}"
+
+    local wt_name=$(basename "$wt_path")
+    local opened=$(_aw_get_access_count "$wt_branch")
+    local issue=$(_aw_format_issue_metadata "$wt_branch")
+
+    # Replacements are quoted so an "&" in a title stays literal
+    local line="$template"
+    line="${line//"{name}"/"$wt_name"}"
+    line="${line//"{branch}"/"$wt_branch"}"
+    line="${line//"{path}"/"$wt_path"}"
+    line="${line//"{timestamp}"/"$timestamp"}"
+    line="${line//"{age}"/"$age"}"
+    line="${line//"{opened}"/"$opened"}"
+    line="${line//"{issue}"/"$issue"}"
+    echo "$line"
+  done <<< "$worktree_list"
+}
+
+_aw_list_last_active() {
+  # Echo when a worktree was last active: its last commit or the last time
+  # it was opened, whichever is later
+  # Usage: _aw_list_last_active wt_path branch
+  local commit_timestamp=$(_aw_get_worktree_timestamp "$1" "$2")
+  local accessed=$(_aw_get_branch_metadata "$2" "last-accessed")
+  [[ "$commit_timestamp" =~ ^[0-9]+$ ]] || commit_timestamp=0
+  [[ "$accessed" =~ ^[0-9]+$ ]] || accessed=0
+  if [[ $accessed -gt $commit_timestamp ]]; then
+    echo "$accessed"
+  else
+    echo "$commit_timestamp"
+  fi
+}
+
+_aw_list_filter_since() {
+  # Drop worktrees that haven't been active since the cutoff timestamp.
+  # The main checkout is kept so the list keeps its usual shape.
+  # Usage: _aw_list_filter_since cutoff worktree_list
+  local cutoff="$1"
+  local worktree_list="$2"
+
+  local wt_path
+  while IFS= read -r wt_path; do
+    [[ -z "$wt_path" ]] && continue
+    if _aw_validate_worktree_path "$wt_path"; then
+      local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "unknown")
+      [[ $(_aw_list_last_active "$wt_path" "$wt_branch") -ge $cutoff ]] || continue
+    fi
+    echo "$wt_path"
+  done <<< "$worktree_list"
+}
+
+_aw_list_all_repos() {
+  # List the worktrees of every registered repository, grouped by repository.
+  # Works from anywhere, not just inside a repository.
+  local repos=$(_aw_registered_repos)
+  if [[ -z "$repos" ]]; then
+    if ! _aw_is_quiet; then
+      gum style --foreground 8 "No repositories registered yet"
+      gum style --foreground 8 "Repositories are added when auto-worktree runs in them"
+    fi
+    return 0
+  fi
+
+  # Paths are shortened to fit a terminal; piped output keeps them whole
+  local term_width=""
+  _aw_stdout_is_tty && term_width=$(_aw_terminal_width)
+
+  local repo_count=0
+  local worktree_count=0
+  local repo_root
+  while IFS= read -r repo_root; do
+    repo_count=$((repo_count + 1))
+
+    local output=""
+    local is_main=true
+    local wt_path
+    while IFS= read -r wt_path; do
+      # The first entry is the repository's own checkout
+      if [[ "$is_main" == "true" ]]; then
+        is_main=false
+        continue
+      fi
+      [[ -d "$wt_path" ]] || continue
+      worktree_count=$((worktree_count + 1))
+
+      local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "unknown")
+      local age_label=$(_aw_format_worktree_age "$(_aw_get_worktree_timestamp "$wt_path" "$wt_branch")")
+      output+="  $(basename "$wt_path") ($wt_branch) $(gum style --foreground 8 "$age_label")\n"
+      local path_label="$wt_path"
+      [[ -n "$term_width" ]] && path_label=$(_aw_truncate "$wt_path" $((term_width - 4)) start)
+      output+="    $(gum style --foreground 8 "$path_label")\n"
+    done < <(git -C "$repo_root" worktree list --porcelain 2>/dev/null | grep "^worktree " | sed 's/^worktree //')
+
+    if [[ -z "$output" ]]; then
+      _aw_is_quiet || gum style --foreground 8 "$(basename "$repo_root"): no additional worktrees"
+      continue
+    fi
+
+    if _aw_is_quiet; then
+      printf '%b' "$output"
+      continue
+    fi
+    gum style --border rounded --padding "0 1" --border-foreground 4 \
+      "$(basename "$repo_root")  $(gum style --foreground 8 "$repo_root")"
+    echo -e "$output"
+  done <<< "$repos"
+
+  _aw_is_quiet || gum style --foreground 8 "$worktree_count worktree(s) across $repo_count repositories"
+}
+
 _aw_list() {
-  _aw_ensure_git_repo || return 1
+  local flag_size=false
+  local flag_all_repos=false
+  local format=""
+  local has_format=false
+  local since=""
+  local since_seconds=""
+  local flag_count_only=false
+  local flag_no_status=false
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --since)
+        if ! since_seconds=$(_aw_parse_duration "${2:-}"); then
+          gum style --foreground 1 "Error: --since requires a duration such as 24h, 7d or 1h30m" >&2
+          return $AW_EXIT_USAGE
+        fi
+        since="$2"
+        shift 2
+        ;;
+      --format)
+        if [[ -z "${2:-}" ]]; then
+          gum style --foreground 1 "Error: --format requires a template, e.g. '{branch}\\t{path}'" >&2
+          return $AW_EXIT_USAGE
+        fi
+        format="$2"
+        has_format=true
+        shift 2
+        ;;
+      --size)
+        flag_size=true
+        shift
+        ;;
+      --count-only)
+        flag_count_only=true
+        shift
+        ;;
+      --no-status)
+        flag_no_status=true
+        shift
+        ;;
+      --all-repos)
+        flag_all_repos=true
+        shift
+        ;;
+      *)
+        gum style --foreground 1 "Unknown option: $1" >&2
+        return $AW_EXIT_USAGE
+        ;;
+    esac
+  done
+
+  if [[ "$flag_count_only" == "true" ]]; then
+    if [[ "$has_format" == "true" ]] || [[ "$flag_size" == "true" ]] || [[ "$flag_all_repos" == "true" ]]; then
+      gum style --foreground 1 "Error: --count-only can't be combined with --format, --size or --all-repos" >&2
+      return $AW_EXIT_USAGE
+    fi
+  fi
+
+  if [[ "$has_format" == "true" ]]; then
+    if [[ "$flag_size" == "true" ]] || [[ "$flag_all_repos" == "true" ]]; then
+      gum style --foreground 1 "Error: --format can't be combined with --size or --all-repos" >&2
+      return $AW_EXIT_USAGE
+    fi
+    _aw_list_format_check "$format" || return $AW_EXIT_USAGE
+  fi
+
+  if [[ "$flag_all_repos" == "true" ]]; then
+    if [[ "$flag_size" == "true" ]] || [[ -n "$since" ]]; then
+      gum style --foreground 1 "Error: --size and --since can't be combined with --all-repos" >&2
+      return $AW_EXIT_USAGE
+    fi
+    _aw_list_all_repos
+    return $?
+  fi
+
+  _aw_ensure_git_repo || return $?
   _aw_get_repo_info
+
+  # --since keeps worktrees committed to or opened within the window
+  local cutoff=""
+  [[ -n "$since" ]] && cutoff=$(($(date +%s) - since_seconds))
+
+  # Just the number of worktrees (main checkout included), for prompts;
+  # no pruning or per-worktree git calls unless --since asks for them
+  if [[ "$flag_count_only" == "true" ]]; then
+    local count_list=$(_aw_get_worktree_list)
+    [[ -n "$cutoff" ]] && count_list=$(_aw_list_filter_since "$cutoff" "$count_list")
+    _aw_count_worktrees "$count_list"
+    return 0
+  fi
+
+  # Formatted output is for scripts: just the lines, nothing else
+  if [[ "$has_format" == "true" ]]; then
+    local format_list=$(_aw_get_worktree_list)
+    [[ -n "$cutoff" ]] && format_list=$(_aw_list_filter_since "$cutoff" "$format_list")
+    _aw_list_format "$format" "$format_list"
+    return 0
+  fi
+
   _aw_prune_worktrees
 
   local worktree_list=$(_aw_get_worktree_list)
   local worktree_count=$(_aw_count_worktrees "$worktree_list")
 
   if [[ $worktree_count -le 1 ]]; then
-    gum style --foreground 8 "No additional worktrees for $_AW_SOURCE_FOLDER"
+    _aw_is_quiet || gum style --foreground 8 "No additional worktrees for $_AW_SOURCE_FOLDER"
     return 0
   fi
 
+  if [[ -n "$cutoff" ]]; then
+    worktree_list=$(_aw_list_filter_since "$cutoff" "$worktree_list")
+    if [[ $(_aw_count_worktrees "$worktree_list") -le 1 ]]; then
+      _aw_is_quiet || gum style --foreground 8 "No worktrees active in the last $since for $_AW_SOURCE_FOLDER"
+      return 0
+    fi
+  fi
+
   local now=$(date +%s)
   local one_day=$((24 * 60 * 60))
-  local four_days=$((4 * 24 * 60 * 60))
+  # Green below age-warn-days, yellow up to age-stale-days, red past it
+  local warn_age=$(($(_aw_get_age_warn_days) * one_day))
+  local stale_age=$(($(_aw_get_age_stale_days) * one_day))
 
   local oldest_wt_path=""
   local oldest_wt_branch=""
@@ -31,11 +389,54 @@ _aw_list() {
 
   local output=""
 
+  # Worktree names are shortened to fit a terminal; piped output keeps the
+  # full layout
+  local term_width=""
+  _aw_stdout_is_tty && term_width=$(_aw_terminal_width)
+
+  # Sizes are slow to compute, so only measure them on request
+  local sizes_dir=""
+  if [[ "$flag_size" == "true" ]]; then
+    sizes_dir=$(mktemp -d "${TMPDIR:-/tmp}/aw-sizes.XXXXXX") || return 1
+    _aw_is_quiet || gum spin --spinner dot --title "Measuring worktree sizes..." -- sleep 0.1
+    _aw_list_compute_sizes "$sizes_dir" "$worktree_list"
+  fi
+
+  # --no-status (or auto-worktree.list-no-status) shows only name, branch and
+  # age: no merge, unpushed or ahead/behind checks
+  local show_status=true
+  if [[ "$flag_no_status" == "true" ]] || [[ "$(git config --bool auto-worktree.list-no-status 2>/dev/null)" == "true" ]]; then
+    show_status=false
+  fi
+
+  # Branch, age, unpushed and ahead/behind counts take a few git calls per
+  # worktree, so read them for all worktrees at once up front
+  local status_dir
+  status_dir=$(mktemp -d "${TMPDIR:-/tmp}/aw-status.XXXXXX") || return 1
+  _aw_cache_default_branch
+  _aw_list_compute_status "$status_dir" "$worktree_list" "$show_status"
+  _AW_DEFAULT_BRANCH_NAME=$(_aw_get_default_branch)
+  local locked_list=$(_aw_get_locked_worktrees)
+
+  local wt_index=0
   while IFS= read -r wt_path; do
+    wt_index=$((wt_index + 1))
     _aw_validate_worktree_path "$wt_path" || continue
 
-    local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "unknown")
-    local commit_timestamp=$(_aw_get_worktree_timestamp "$wt_path" "$wt_branch")
+    # Leading size column when --size is given
+    local size_col=""
+    if [[ -n "$sizes_dir" ]]; then
+      size_col="$(printf '%6s' "$(cat "$sizes_dir/$wt_index" 2>/dev/null || echo "?")")  "
+    fi
+
+    local wt_branch="unknown" commit_timestamp="" unpushed_count=0 no_changes=false sync_label=""
+    {
+      read -r wt_branch
+      read -r commit_timestamp
+      read -r unpushed_count
+      read -r no_changes
+      read -r sync_label
+    } < "$status_dir/$wt_index"
 
     # Check if this worktree is linked to a merged/resolved issue or has a merged PR
     # Use _aw_extract_issue_id (not the provider-bound variant) so that branches
@@ -46,7 +447,7 @@ _aw_list() {
     local merged_indicator=""
     local merge_reason=""
 
-    if [[ -n "$issue_id" ]]; then
+    if [[ "$show_status" == "true" ]] && [[ -n "$issue_id" ]]; then
       if [[ "$_AW_DETECTED_ISSUE_TYPE" == "jira" ]]; then
         # Check if JIRA issue is resolved
         if _aw_jira_check_resolved "$issue_id"; then
@@ -58,10 +459,10 @@ _aw_list() {
         # Check if GitLab issue is closed
         if _aw_gitlab_check_closed "$issue_id" "issue"; then
           # Check for unpushed commits
-          if _aw_has_unpushed_commits "$wt_path"; then
+          if [[ $unpushed_count -gt 0 ]]; then
             # Has unpushed work - mark as closed but with warning
             is_merged=true
-            merge_reason="issue #$issue_id closed (⚠ $_AW_UNPUSHED_COUNT unpushed)"
+            merge_reason="issue #$issue_id closed (⚠ $unpushed_count unpushed)"
             merged_indicator=" $(gum style --foreground 3 "[closed #$issue_id ⚠]")"
           else
             # No unpushed work - safe to clean up
@@ -87,10 +488,10 @@ _aw_list() {
           # Issue is closed but no PR (either open or merged)
           if [[ "$_AW_ISSUE_HAS_PR" == "false" ]]; then
             # Check for unpushed commits
-            if _aw_has_unpushed_commits "$wt_path"; then
+            if [[ $unpushed_count -gt 0 ]]; then
               # Has unpushed work - mark as closed but with warning
               is_merged=true
-              merge_reason="issue #$issue_id closed (⚠ $_AW_UNPUSHED_COUNT unpushed)"
+              merge_reason="issue #$issue_id closed (⚠ $unpushed_count unpushed)"
               merged_indicator=" $(gum style --foreground 3 "[closed #$issue_id ⚠]")"
             else
               # No unpushed work - safe to clean up
@@ -104,7 +505,7 @@ _aw_list() {
     fi
 
     # Also check for merged PRs/MRs if no issue was detected
-    if [[ "$is_merged" == "false" ]]; then
+    if [[ "$show_status" == "true" ]] && [[ "$is_merged" == "false" ]]; then
       # Check for GitLab MRs (mr-{number} pattern in path)
       if [[ "$wt_path" =~ mr-([0-9]+) ]]; then
         local mr_num="${BASH_REMATCH[1]}"
@@ -122,13 +523,17 @@ _aw_list() {
     fi
 
     # Check for worktrees with no changes from default branch (only if not already flagged as merged/closed)
-    if [[ "$is_merged" == "false" ]] && ! _aw_has_unpushed_commits "$wt_path" && _aw_check_no_changes_from_default "$wt_path"; then
+    if [[ "$is_merged" == "false" ]] && [[ $unpushed_count -eq 0 ]] && [[ "$no_changes" == "true" ]]; then
       is_merged=true
       merge_reason="no changes from $_AW_DEFAULT_BRANCH_NAME"
       merged_indicator=" $(gum style --foreground 8 "[no changes]")"
     fi
 
-    if [[ "$is_merged" == "true" ]]; then
+    # Locked worktrees are shown as they are but never offered for cleanup
+    local is_locked=false
+    _aw_worktree_is_locked "$wt_path" "$locked_list" && is_locked=true
+
+    if [[ "$is_merged" == "true" ]] && [[ "$is_locked" == "false" ]]; then
       merged_wt_paths+=("$wt_path")
       merged_wt_branches+=("$wt_branch")
       merged_wt_issues+=("$merge_reason")
@@ -136,22 +541,53 @@ _aw_list() {
 
     local age_label=$(_aw_format_worktree_age "$commit_timestamp")
 
+    # ↑ahead ↓behind relative to the upstream, if there is one
+    [[ -n "$sync_label" ]] && merged_indicator=" $(gum style --foreground 6 "$sync_label")${merged_indicator}"
+
+    # How often the worktree was opened, to tell used worktrees from abandoned ones
+    local access_count=$(_aw_get_access_count "$wt_branch")
+    [[ $access_count -gt 0 ]] && merged_indicator+=" $(gum style --foreground 8 "[opened ${access_count}×]")"
+
+    # `sessions keep` worktrees are never stale, however old
+    local is_kept=false
+    if _aw_is_kept_alive "$wt_branch"; then
+      is_kept=true
+      merged_indicator+=" $(gum style --foreground 6 "[keep-alive]")"
+    fi
+    [[ "$is_locked" == "true" ]] && merged_indicator+=" $(gum style --foreground 4 "[locked]")"
+
+    # Second line describing the issue this worktree was created from
+    local issue_line=""
+    local issue_desc=$(_aw_format_issue_metadata "$wt_branch")
+    if [[ -n "$issue_desc" ]]; then
+      [[ -n "$term_width" ]] && issue_desc=$(_aw_truncate "$issue_desc" $((term_width - 6)))
+      issue_line="    $(gum style --foreground 8 "↳ $issue_desc")\n"
+    fi
+
+    local wt_name=$(basename "$wt_path")
+    if [[ -n "$term_width" ]]; then
+      local rest_width=$(_aw_visible_length "  ${size_col} ($wt_branch) ${age_label}${merged_indicator}")
+      local name_width=$((term_width - rest_width))
+      [[ $name_width -lt 10 ]] && name_width=10
+      wt_name=$(_aw_truncate "$wt_name" "$name_width")
+    fi
+
     if [[ "$age_label" == "[unknown]" ]]; then
-      output+="  $(gum style --foreground 8 "$(basename "$wt_path")") ($wt_branch) [unknown]${merged_indicator}\n"
+      output+="  ${size_col}$(gum style --foreground 8 "$wt_name") ($wt_branch) [unknown]${merged_indicator}\n${issue_line}"
       continue
     fi
 
     local age=$((now - commit_timestamp))
 
     # Build age string and color inline to avoid zsh variable assignment echo bug
-    if [[ $age -lt $one_day ]]; then
-      output+="  $(basename "$wt_path") ($wt_branch) $(gum style --foreground 2 "$age_label")${merged_indicator}\n"
-    elif [[ $age -lt $four_days ]]; then
-      output+="  $(basename "$wt_path") ($wt_branch) $(gum style --foreground 3 "$age_label")${merged_indicator}\n"
+    if [[ $age -lt $warn_age ]]; then
+      output+="  ${size_col}${wt_name} ($wt_branch) $(gum style --foreground 2 "$age_label")${merged_indicator}\n${issue_line}"
+    elif [[ $age -lt $stale_age ]] || [[ "$is_kept" == "true" ]]; then
+      output+="  ${size_col}${wt_name} ($wt_branch) $(gum style --foreground 3 "$age_label")${merged_indicator}\n${issue_line}"
     else
-      output+="  $(basename "$wt_path") ($wt_branch) $(gum style --foreground 1 "$age_label")${merged_indicator}\n"
-      # Only track as stale if not already marked as merged
-      if [[ "$is_merged" == "false" ]] && [[ $age -gt $oldest_age ]]; then
+      output+="  ${size_col}${wt_name} ($wt_branch) $(gum style --foreground 1 "$age_label")${merged_indicator}\n${issue_line}"
+      # Only track as stale if not already marked as merged (or locked)
+      if [[ "$is_merged" == "false" ]] && [[ "$is_locked" == "false" ]] && [[ $age -gt $oldest_age ]]; then
         oldest_age=$age
         oldest_wt_path="$wt_path"
         oldest_wt_branch="$wt_branch"
@@ -159,6 +595,15 @@ _aw_list() {
     fi
   done <<< "$worktree_list"
 
+  [[ -n "$sizes_dir" ]] && rm -rf "$sizes_dir"
+  rm -rf "$status_dir"
+
+  # Quiet mode prints just the worktree rows, without the cleanup offer
+  if _aw_is_quiet; then
+    printf '%b' "$output"
+    return 0
+  fi
+
   if [[ -n "$output" ]]; then
     gum style --border rounded --padding "0 1" --border-foreground 4 \
       "Worktrees for $_AW_SOURCE_FOLDER"
@@ -0,0 +1,114 @@
+#!/bin/bash
+
+# ============================================================================
+# Lock worktrees so cleanup, prune and list never remove them
+# ============================================================================
+
+_aw_lock_resolve_target() {
+  # Echo the path of the worktree to lock or unlock: the given branch or path,
+  # or the current worktree when none is given. Refuses the main checkout.
+  # Usage: _aw_lock_resolve_target command [target]
+  local command="$1"
+  local target="${2:-}"
+  [[ -z "$target" ]] && target=$(_aw_current_worktree)
+
+  if [[ -z "$target" ]]; then
+    gum style --foreground 1 "Usage: auto-worktree $command [<branch|path>] (defaults to the current worktree)" >&2
+    return $AW_EXIT_USAGE
+  fi
+
+  local wt_path
+  wt_path=$(_aw_resolve_worktree_target "$target")
+  if [[ -z "$wt_path" ]]; then
+    gum style --foreground 1 "Error: No worktree found for branch or path: $target" >&2
+    return 1
+  fi
+
+  local main_path=$(_aw_get_worktree_list | head -n 1)
+  if [[ "$(_aw_physical_path "$wt_path")" == "$(_aw_physical_path "$main_path")" ]]; then
+    gum style --foreground 1 "Error: The main worktree can't be locked; it is never cleaned up" >&2
+    return 1
+  fi
+
+  echo "$wt_path"
+}
+
+_aw_lock_worktree() {
+  # Lock a worktree with `git worktree lock`, optionally recording why
+  _aw_ensure_git_repo || return $?
+  _aw_get_repo_info
+
+  local target=""
+  local reason=""
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --reason)
+        if [[ -z "${2:-}" ]]; then
+          gum style --foreground 1 "Error: --reason requires a value"
+          return $AW_EXIT_USAGE
+        fi
+        reason="$2"
+        shift 2
+        ;;
+      -*)
+        gum style --foreground 1 "Unknown option: $1"
+        return $AW_EXIT_USAGE
+        ;;
+      *)
+        if [[ -n "$target" ]]; then
+          gum style --foreground 1 "Usage: auto-worktree lock [--reason <text>] [<branch|path>]"
+          return $AW_EXIT_USAGE
+        fi
+        target="$1"
+        shift
+        ;;
+    esac
+  done
+
+  local wt_path
+  wt_path=$(_aw_lock_resolve_target lock "$target") || return $?
+
+  if _aw_worktree_is_locked "$wt_path"; then
+    _aw_is_quiet || gum style --foreground 8 "Already locked: $(basename "$wt_path")"
+    return 0
+  fi
+
+  local -a lock_args=()
+  [[ -n "$reason" ]] && lock_args=(--reason "$reason")
+  if ! _aw_with_lock git worktree lock "${lock_args[@]}" "$wt_path" 2>/dev/null; then
+    gum style --foreground 1 "Error: Failed to lock worktree: $wt_path"
+    return 1
+  fi
+
+  gum style --foreground 2 "✓ Locked $(basename "$wt_path"); cleanup, prune and list will leave it alone"
+}
+
+_aw_unlock_worktree() {
+  # Unlock a worktree locked with `aw lock` or `git worktree lock`
+  _aw_ensure_git_repo || return $?
+  _aw_get_repo_info
+
+  if [[ "${1:-}" == -* ]]; then
+    gum style --foreground 1 "Unknown option: $1"
+    return $AW_EXIT_USAGE
+  fi
+  if [[ $# -gt 1 ]]; then
+    gum style --foreground 1 "Usage: auto-worktree unlock [<branch|path>]"
+    return $AW_EXIT_USAGE
+  fi
+
+  local wt_path
+  wt_path=$(_aw_lock_resolve_target unlock "${1:-}") || return $?
+
+  if ! _aw_worktree_is_locked "$wt_path"; then
+    _aw_is_quiet || gum style --foreground 8 "Not locked: $(basename "$wt_path")"
+    return 0
+  fi
+
+  if ! _aw_with_lock git worktree unlock "$wt_path" 2>/dev/null; then
+    gum style --foreground 1 "Error: Failed to unlock worktree: $wt_path"
+    return 1
+  fi
+
+  gum style --foreground 2 "✓ Unlocked $(basename "$wt_path")"
+}
@@ -4,7 +4,7 @@
 # Main interactive menu
 # ============================================================================
 _aw_menu() {
-  _aw_ensure_git_repo || return 1
+  _aw_ensure_git_repo || return $?
   _aw_get_repo_info
 
   # Show existing worktrees
@@ -4,12 +4,12 @@
 # Milestone / Epic integration
 # ============================================================================
 _aw_milestone() {
-  _aw_ensure_git_repo || return 1
+  _aw_ensure_git_repo || return $?
   _aw_get_repo_info
 
   # Determine issue provider
   local provider
-  provider=$(_aw_init_issue_provider) || return 1
+  provider=$(_aw_init_issue_provider) || return $AW_EXIT_PROVIDER
 
   local terminology=$(_aw_milestone_terminology "$provider")
   local term_lower=$(echo "$terminology" | tr '[:upper:]' '[:lower:]')
@@ -60,6 +60,55 @@ _aw_milestone() {
   done
 }
 
+_aw_list_milestones() {
+  # List open milestones/epics for a provider
+  # Output format: "ID | Title | ..." one per line
+  # Args: $1 = provider
+  case "$1" in
+    github) _aw_github_list_milestones ;;
+    gitlab) _aw_gitlab_list_milestones ;;
+    jira)   _aw_jira_list_epics ;;
+    linear) _aw_linear_list_milestones ;;
+  esac
+}
+
+_aw_list_issues_by_milestone() {
+  # List open issues in a milestone/epic
+  # GitHub and GitLab look issues up by milestone title, JIRA and Linear by ID
+  # Args: $1 = provider, $2 = milestone_id, $3 = milestone_title
+  case "$1" in
+    github) _aw_github_list_issues_by_milestone "$3" ;;
+    gitlab) _aw_gitlab_list_issues_by_milestone "$3" ;;
+    jira)   _aw_jira_list_issues_by_epic "$2" ;;
+    linear) _aw_linear_list_issues_by_milestone "$2" ;;
+  esac
+}
+
+_aw_resolve_milestone() {
+  # Find a milestone/epic by ID or title (case-insensitive)
+  # Args: $1 = provider, $2 = name or ID
+  # Sets: milestone_id, milestone_title (in caller scope)
+  # Returns 1 if no open milestone matches
+  local provider="$1"
+  local wanted=$(echo "$2" | tr '[:upper:]' '[:lower:]')
+
+  local line
+  while IFS= read -r line; do
+    [[ -z "$line" ]] && continue
+    local ms_id=$(echo "$line" | cut -d'|' -f1 | tr -d ' ')
+    local ms_title=$(echo "$line" | cut -d'|' -f2 | sed 's/^ *//;s/ *$//')
+    local ms_id_lower=$(echo "$ms_id" | tr '[:upper:]' '[:lower:]')
+    local ms_title_lower=$(echo "$ms_title" | tr '[:upper:]' '[:lower:]')
+    if [[ "$ms_id_lower" == "$wanted" ]] || [[ "$ms_title_lower" == "$wanted" ]]; then
+      milestone_id="$ms_id"
+      milestone_title="$ms_title"
+      return 0
+    fi
+  done <<< "$(_aw_list_milestones "$provider")"
+
+  return 1
+}
+
 _aw_select_milestone() {
   # Interactive milestone/epic selector
   # Args: $1 = provider, $2 = terminology
@@ -72,12 +121,7 @@ _aw_select_milestone() {
   local milestones=""
   gum spin --spinner dot --title "Fetching ${term_lower}s..." -- sleep 0.1
 
-  case "$provider" in
-    github) milestones=$(_aw_github_list_milestones) ;;
-    gitlab) milestones=$(_aw_gitlab_list_milestones) ;;
-    jira)   milestones=$(_aw_jira_list_epics) ;;
-    linear) milestones=$(_aw_linear_list_milestones) ;;
-  esac
+  milestones=$(_aw_list_milestones "$provider")
 
   if [[ -z "$milestones" ]]; then
     gum style --foreground 1 "No open ${term_lower}s found"
@@ -125,33 +169,32 @@ _aw_select_issue_by_milestone() {
   local issues=""
   gum spin --spinner dot --title "Fetching issues for ${term_lower} \"${ms_title}\"..." -- sleep 0.1
 
-  case "$provider" in
-    github) issues=$(_aw_github_list_issues_by_milestone "$ms_title") ;;
-    gitlab) issues=$(_aw_gitlab_list_issues_by_milestone "$ms_title") ;;
-    jira)   issues=$(_aw_jira_list_issues_by_epic "$ms_id") ;;
-    linear)
-      gum style --foreground 1 "Linear does not support filtering issues by project via CLI"
-      return 1
-      ;;
-  esac
+  local _AW_LABEL_COLORS_FILE=$(_aw_label_colors_file "$provider")
+  issues=$(_aw_list_issues_by_milestone "$provider" "$ms_id" "$ms_title")
+  local label_colors=$(_aw_take_label_colors)
+  issues=$(_aw_mark_active_issues "$issues" "$provider")
 
+  # An empty milestone isn't an error; issue_id stays empty
   if [[ -z "$issues" ]]; then
-    gum style --foreground 1 "No open issues found in ${term_lower} \"${ms_title}\""
-    return 1
+    gum style --foreground 3 "No open issues found in ${term_lower} \"${ms_title}\""
+    return 0
   fi
 
-  # Show filterable list
+  # Show filterable list, with label chips in their own colors
+  local display_issues="$issues"
+  [[ -n "$label_colors" ]] && display_issues=$(_aw_colorize_issue_labels "$issues" "$label_colors")
   local selection
-  selection=$(echo "$issues" | gum filter --placeholder "Select an issue from ${term_lower} \"${ms_title}\"")
+  selection=$(echo "$display_issues" | gum filter --placeholder "Select an issue from ${term_lower} \"${ms_title}\" (● = active worktree)")
+  selection=$(_aw_strip_ansi "$selection")
 
   if [[ -z "$selection" ]]; then
     return "${AW_EXIT_CANCELLED:-130}"
   fi
 
   # Extract issue ID from selection
-  # GitHub/GitLab format: "#123 | Title..." -> extract number
+  # GitHub/GitLab format: "● #123 | Title..." -> extract number
   # JIRA format: "PROJ-123 | Title..." -> extract key
-  issue_id=$(echo "$selection" | sed 's/^#//' | cut -d'|' -f1 | tr -d ' ')
+  issue_id=$(_aw_extract_id_from_selection "$selection")
 
   return 0
 }
@@ -3,19 +3,198 @@
 # ============================================================================
 # New worktree
 # ============================================================================
+_aw_new_batch() {
+  # new --batch: create a worktree for each branch name read from stdin, one
+  # per line, carrying on past failures. Blank lines and #comments are skipped.
+  # Returns 1 if any worktree couldn't be created.
+  if _aw_stdin_is_tty; then
+    gum style --foreground 1 "Error: --batch reads branch names from stdin, e.g. aw new --batch < branches.txt"
+    return $AW_EXIT_USAGE
+  fi
+
+  # Read every name up front; hooks and installers run per worktree and
+  # mustn't swallow the rest of the list
+  local -a branches=()
+  local line
+  while IFS= read -r line || [[ -n "$line" ]]; do
+    line="${line%%#*}"
+    line="${line#"${line%%[![:space:]]*}"}"
+    line="${line%"${line##*[![:space:]]}"}"
+    [[ -n "$line" ]] && branches+=("$line")
+  done
+
+  if [[ ${#branches[@]} -eq 0 ]]; then
+    gum style --foreground 3 "No branch names on stdin"
+    return 0
+  fi
+
+  _aw_cache_default_branch
+  local created=0
+  local -a failures=()
+  local branch
+  for branch in "${branches[@]}"; do
+    local add_status=0
+    _aw_add_worktree "$branch" || add_status=$?
+
+    if [[ $add_status -eq 0 ]]; then
+      created=$((created + 1))
+      if [[ "${_AW_DRY_RUN:-}" != "true" ]]; then
+        _aw_is_quiet || gum style --foreground 2 "✓ $branch → $_AW_CREATED_WORKTREE_PATH"
+      fi
+      continue
+    fi
+
+    local reason="failed"
+    case "$add_status" in
+      "$AW_EXIT_EXISTS") reason="already exists" ;;
+      "$AW_EXIT_USAGE") reason="invalid branch name" ;;
+      "$AW_EXIT_LOCKED") reason="repository locked" ;;
+    esac
+    failures+=("$branch: $reason")
+    gum style --foreground 1 "✗ $branch: $reason" >&2
+  done
+
+  if ! _aw_is_quiet; then
+    echo ""
+    if [[ "${_AW_DRY_RUN:-}" == "true" ]]; then
+      gum style --foreground 6 "Dry run: $created of ${#branches[@]} worktree(s) would be created"
+    else
+      gum style --foreground 2 "Created $created of ${#branches[@]} worktree(s)"
+    fi
+  fi
+  if [[ ${#failures[@]} -gt 0 ]]; then
+    gum style --foreground 1 "${#failures[@]} failed:" >&2
+    local failure
+    for failure in "${failures[@]}"; do
+      echo "  • $failure" >&2
+    done
+    return 1
+  fi
+  return 0
+}
+
 _aw_new() {
-  local skip_list="${1:-false}"
+  local skip_list=false
+  local batch=false
+  local _AW_HOOKS_OVERRIDE="${_AW_HOOKS_OVERRIDE:-}"
+  local _AW_FETCH_OVERRIDE="${_AW_FETCH_OVERRIDE:-}"
+  local _AW_WORKTREE_DEPTH="${_AW_WORKTREE_DEPTH:-}"
+  local _AW_DRY_RUN="${_AW_DRY_RUN:-}"
+  # Set once the user types a branch name, which may exist only on a remote
+  local _AW_EXPLICIT_BRANCH="${_AW_EXPLICIT_BRANCH:-}"
+  # --write-branch <file>, read by _aw_write_branch_file
+  local _AW_WRITE_BRANCH_FILE="${_AW_WRITE_BRANCH_FILE:-}"
+  local detach_commit=""
+
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --detach)
+        if [[ -z "${2:-}" ]]; then
+          gum style --foreground 1 "Error: --detach needs a commit, e.g. --detach a1b2c3d"
+          return $AW_EXIT_USAGE
+        fi
+        detach_commit="$2"
+        shift 2
+        ;;
+      --no-hooks)
+        _AW_HOOKS_OVERRIDE=false
+        shift
+        ;;
+      --hooks)
+        _AW_HOOKS_OVERRIDE=true
+        shift
+        ;;
+      --fetch)
+        _AW_FETCH_OVERRIDE=true
+        shift
+        ;;
+      --no-fetch)
+        _AW_FETCH_OVERRIDE=false
+        shift
+        ;;
+      --dry-run)
+        _AW_DRY_RUN=true
+        shift
+        ;;
+      --batch)
+        batch=true
+        shift
+        ;;
+      --write-branch|--write-branch=*)
+        if [[ "$1" == --write-branch=* ]]; then
+          _AW_WRITE_BRANCH_FILE="${1#--write-branch=}"
+          shift
+        else
+          _AW_WRITE_BRANCH_FILE="${2:-}"
+          shift $(( $# > 1 ? 2 : 1 ))
+        fi
+        if [[ -z "$_AW_WRITE_BRANCH_FILE" ]]; then
+          gum style --foreground 1 "Error: --write-branch needs a file, e.g. --write-branch \"\$GITHUB_OUTPUT\""
+          return $AW_EXIT_USAGE
+        fi
+        ;;
+      --depth|--depth=*)
+        if [[ "$1" == --depth=* ]]; then
+          _AW_WORKTREE_DEPTH="${1#--depth=}"
+          shift
+        else
+          _AW_WORKTREE_DEPTH="${2:-}"
+          shift $(( $# > 1 ? 2 : 1 ))
+        fi
+        if ! [[ "$_AW_WORKTREE_DEPTH" =~ ^[1-9][0-9]*$ ]]; then
+          gum style --foreground 1 "Error: --depth needs a positive number of commits"
+          return $AW_EXIT_USAGE
+        fi
+        ;;
+      true|false)
+        # The menu passes true since it has already shown the worktree list
+        skip_list="$1"
+        shift
+        ;;
+      *)
+        gum style --foreground 1 "Unknown option: $1"
+        return $AW_EXIT_USAGE
+        ;;
+    esac
+  done
+
+  if [[ -n "$detach_commit" ]] && [[ -n "$_AW_WORKTREE_DEPTH" ]]; then
+    gum style --foreground 1 "Error: --depth can't be combined with --detach"
+    return $AW_EXIT_USAGE
+  fi
+  if [[ -n "$detach_commit" ]] && [[ "$batch" == "true" ]]; then
+    gum style --foreground 1 "Error: --batch can't be combined with --detach"
+    return $AW_EXIT_USAGE
+  fi
+  if [[ -n "$_AW_WRITE_BRANCH_FILE" ]] && { [[ -n "$detach_commit" ]] || [[ "$batch" == "true" ]]; }; then
+    gum style --foreground 1 "Error: --write-branch needs a single new branch; it can't be combined with --detach or --batch"
+    return $AW_EXIT_USAGE
+  fi
 
-  _aw_ensure_git_repo || return 1
+  _aw_ensure_git_repo || return $?
   _aw_get_repo_info
-  _aw_prune_worktrees
+  [[ "$_AW_DRY_RUN" == "true" ]] || _aw_prune_worktrees
+
+  if [[ "$batch" == "true" ]]; then
+    _aw_new_batch
+    return $?
+  fi
+
+  # --detach: a worktree at a commit, with no branch to name
+  if [[ -n "$detach_commit" ]]; then
+    _aw_add_detached_worktree "$detach_commit" || return $?
+    [[ "$_AW_DRY_RUN" == "true" ]] && return 0
+    _aw_events_enabled && return 0
+    _aw_launch_worktree "$_AW_CREATED_WORKTREE_PATH" "$_AW_CREATED_WORKTREE_NAME"
+    return $?
+  fi
 
   # Show existing worktrees (unless called from menu which already showed them)
-  if [[ "$skip_list" == "false" ]]; then
+  if [[ "$skip_list" == "false" ]] && ! _aw_is_quiet; then
     _aw_list
   fi
 
-  echo ""
+  _aw_is_quiet || echo ""
 
   local branch_input=$(gum input --placeholder "Branch name (leave blank for random)")
 
@@ -26,9 +205,10 @@ _aw_new() {
     # Generate a unique random name
     local attempts=0
     local max_attempts=50
+    local prefix=$(_aw_get_branch_prefix)
     while [[ $attempts -lt $max_attempts ]]; do
       worktree_name="$(_aw_generate_random_name)"
-      branch_name="work/${worktree_name}"
+      branch_name="${prefix}/${worktree_name}"
 
       # Check if branch already exists
       if ! git show-ref --verify --quiet "refs/heads/${branch_name}" 2>/dev/null; then
@@ -43,9 +223,10 @@ _aw_new() {
       return 1
     fi
 
-    gum style --foreground 6 "Generated: $branch_name"
+    _aw_is_quiet || gum style --foreground 6 "Generated: $branch_name"
   else
     branch_name="$branch_input"
+    _AW_EXPLICIT_BRANCH=true
   fi
 
   _aw_create_worktree "$branch_name"
@@ -5,18 +5,41 @@
 # ============================================================================
 
 # Ensure worktree exists for a PR/MR, handling all states transparently
-# Usage: _aw_ensure_pr_worktree "$provider" "$pr_num" "$head_ref" "$base_ref" "$worktree_path"
+# A PR from a fork is checked out on a temporary pr-<n> branch, since its
+# head branch doesn't exist on origin and may clash with a local one. A pr-<n>
+# branch that wasn't created this way is never fetched into.
+# Usage: _aw_ensure_pr_worktree "$provider" "$pr_num" "$head_ref" "$base_ref" "$worktree_path" [is_fork]
 _aw_ensure_pr_worktree() {
   local provider="$1"
   local pr_num="$2"
   local head_ref="$3"
   local base_ref="$4"
   local worktree_path="$5"
+  local is_fork="${6:-false}"
 
   mkdir -p "$_AW_WORKTREE_BASE"
 
   # Fetch the PR/MR ref
-  if [[ "$provider" == "gitlab" ]]; then
+  if [[ "$is_fork" == "true" ]]; then
+    head_ref="pr-${pr_num}"
+    # Fetching into pr-<n> overwrites it and remove deletes it, so a branch
+    # of that name that isn't ours is left alone
+    if git show-ref --verify --quiet "refs/heads/${head_ref}" && \
+       [[ "$(_aw_get_branch_metadata "$head_ref" "fork-pr")" != "$pr_num" ]]; then
+      gum style --foreground 1 "Error: Branch '${head_ref}' already exists and wasn't created for PR #${pr_num}" >&2
+      gum style --foreground 8 "Rename or delete it to check out this PR" >&2
+      return $AW_EXIT_EXISTS
+    fi
+    if [[ -d "$worktree_path" ]]; then
+      # pr-<n> is checked out in the worktree, so it can't be fetched into;
+      # the worktree is reset to FETCH_HEAD below instead
+      gum spin --spinner dot --title "Fetching PR branch..." -- git fetch origin "pull/${pr_num}/head" 2>/dev/null
+    else
+      gum spin --spinner dot --title "Fetching PR branch from fork..." -- git fetch origin "+pull/${pr_num}/head:${head_ref}" 2>/dev/null
+    fi
+    git show-ref --verify --quiet "refs/heads/${head_ref}" && \
+      _aw_set_branch_metadata "$head_ref" "fork-pr" "$pr_num"
+  elif [[ "$provider" == "gitlab" ]]; then
     gum spin --spinner dot --title "Fetching MR branch..." -- git fetch origin "merge-requests/${pr_num}/head" 2>/dev/null || \
       git fetch origin "${head_ref}" 2>/dev/null
   else
@@ -49,7 +72,7 @@ _aw_ensure_pr_worktree() {
         if ! git -C "$worktree_path" diff --quiet 2>/dev/null || ! git -C "$worktree_path" diff --cached --quiet 2>/dev/null; then
           if ! gum confirm "Uncommitted changes in worktree will be discarded. Update to latest?"; then
             do_reset=false
-            gum style --foreground 3 "Keeping existing worktree state"
+            _aw_is_quiet || gum style --foreground 3 "Keeping existing worktree state"
           fi
         fi
         if [[ "$do_reset" == "true" ]]; then
@@ -73,27 +96,92 @@ _aw_ensure_pr_worktree() {
     fi
 
     # Create worktree
+    _aw_acquire_lock || return $?
     if ! gum spin --spinner dot --title "Creating worktree..." -- git worktree add "$worktree_path" "$head_ref" 2>/dev/null; then
       # Branch checked out elsewhere — fall back to detached worktree
-      if [[ "$provider" == "gitlab" ]]; then
-        gum style --foreground 6 "Branch already in use, creating detached worktree for MR..."
-      else
-        gum style --foreground 6 "Branch already in use, creating detached worktree for PR..."
+      if ! _aw_is_quiet; then
+        if [[ "$provider" == "gitlab" ]]; then
+          gum style --foreground 6 "Branch already in use, creating detached worktree for MR..."
+        else
+          gum style --foreground 6 "Branch already in use, creating detached worktree for PR..."
+        fi
       fi
 
       if ! gum spin --spinner dot --title "Creating worktree..." -- git worktree add --detach "$worktree_path" "$fetched_sha"; then
-        gum style --foreground 1 "Failed to create worktree"
+        _aw_release_lock
+        gum style --foreground 1 "Failed to create worktree" >&2
         return 1
       fi
     fi
+    _aw_release_lock
 
     cd "$worktree_path" || return 1
 
     # Set up environment only on first creation
-    _aw_setup_environment "$worktree_path"
+    _aw_quietable _aw_setup_environment "$worktree_path"
   fi
 }
 
+# Show a one-line CI summary for a PR; failing checks are a warning only
+# Usage: _aw_pr_show_checks "$provider" "$pr_num"
+_aw_pr_show_checks() {
+  local summary
+  summary=$(_aw_get_pr_checks "$2" "$1") || return 0
+
+  local passed failed pending
+  read -r passed failed pending <<< "$summary"
+  if [[ $((passed + failed + pending)) -eq 0 ]]; then
+    gum style --foreground 8 "No CI checks reported"
+    return 0
+  fi
+
+  local line="Checks: ✓ $passed passed, ✗ $failed failed, ● $pending pending"
+  if [[ "$failed" -gt 0 ]]; then
+    gum style --foreground 3 "⚠ $line"
+  elif [[ "$pending" -gt 0 ]]; then
+    gum style --foreground 6 "$line"
+  else
+    gum style --foreground 2 "$line"
+  fi
+}
+
+# Show `git diff --stat` for the PR's changes with additions in green and
+# deletions in red
+# Usage: _aw_pr_show_diff_stat "$base_ref"
+_aw_pr_show_diff_stat() {
+  local base_ref="$1"
+  local stat
+  stat=$(git --no-pager diff --stat "origin/${base_ref}...HEAD" 2>/dev/null)
+  # Without origin's copy of the base, compare against the local one; with
+  # no merge base at all there is nothing to show
+  [[ -z "$stat" ]] && stat=$(git --no-pager diff --stat "${base_ref}...HEAD" 2>/dev/null)
+  [[ -z "$stat" ]] && return 0
+
+  echo ""
+  gum style --border rounded --padding "0 1" --border-foreground 6 \
+    "Changes vs $base_ref"
+
+  local line
+  while IFS= read -r line; do
+    local graph="${line##* }"
+    if [[ "$line" == *" | "* ]] && [[ "$graph" =~ ^[+-]+$ ]]; then
+      # " path | 12 +++++---": color the graph
+      local plus="${graph//-/}"
+      local minus="${graph//+/}"
+      echo "${line% *} $([[ -n "$plus" ]] && gum style --foreground 2 "$plus")$([[ -n "$minus" ]] && gum style --foreground 1 "$minus")"
+    elif [[ "$line" =~ ^\ *([0-9]+)\ files?\ changed ]]; then
+      # " 3 files changed, 10 insertions(+), 2 deletions(-)"
+      local files="${BASH_REMATCH[1]}"
+      local insertions=0 deletions=0
+      [[ "$line" =~ ([0-9]+)\ insertions? ]] && insertions="${BASH_REMATCH[1]}"
+      [[ "$line" =~ ([0-9]+)\ deletions? ]] && deletions="${BASH_REMATCH[1]}"
+      echo " $files file(s) changed, $(gum style --foreground 2 "+$insertions") $(gum style --foreground 1 "-$deletions")"
+    else
+      echo "$line"
+    fi
+  done <<< "$stat"
+}
+
 # Show action menu for PR/MR workflow
 # Returns: "continue", "fix", "review", or "" (cancelled)
 _aw_pr_action_menu() {
@@ -170,24 +258,27 @@ _aw_pr_launch_ai() {
   local title="$5"
 
   # Set terminal title
-  if [[ "$provider" == "gitlab" ]]; then
-    printf '\033]0;GitLab MR !%s - %s\007' "$pr_num" "$title"
-  else
-    printf '\033]0;GitHub PR #%s - %s\007' "$pr_num" "$title"
+  if ! _aw_is_quiet; then
+    if [[ "$provider" == "gitlab" ]]; then
+      printf '\033]0;GitLab MR !%s - %s\007' "$pr_num" "$title"
+    else
+      printf '\033]0;GitHub PR #%s - %s\007' "$pr_num" "$title"
+    fi
   fi
+  _aw_name_tmux_window "$(git rev-parse --abbrev-ref HEAD 2>/dev/null)"
 
   _resolve_ai_command || return 1
 
   if [[ "${AI_CMD[1]}" == "skip" ]]; then
-    gum style --foreground 3 "Skipping AI tool - worktree is ready for manual work"
+    _aw_is_quiet || gum style --foreground 3 "Skipping AI tool - worktree is ready for manual work"
     return 0
   fi
 
-  echo ""
+  _aw_is_quiet || echo ""
 
   # For "continue" mode, try to resume an existing AI conversation
   if [[ "$action" == "continue" ]] && _ai_has_resumable_session; then
-    gum style --foreground 2 "Resuming $AI_CMD_NAME session..."
+    _aw_is_quiet || gum style --foreground 2 "Resuming $AI_CMD_NAME session..."
     "${AI_RESUME_CMD[@]}"
   else
     local mode_label
@@ -196,25 +287,185 @@ _aw_pr_launch_ai() {
       fix)      mode_label="fix issues" ;;
       review)   mode_label="review" ;;
     esac
-    gum style --foreground 2 "Starting $AI_CMD_NAME ($mode_label)..."
+    _aw_is_quiet || gum style --foreground 2 "Starting $AI_CMD_NAME ($mode_label)..."
     "${AI_CMD[@]}" "$prompt"
   fi
 }
 
+_aw_pr_suggest_reviewers() {
+  # Fill the caller's reviewers array from CODEOWNERS for the changed files
+  # Args: $1 = provider
+  local provider="$1"
+
+  if [[ "$provider" != "github" ]]; then
+    gum style --foreground 3 "Reviewer suggestions from CODEOWNERS are only supported for GitHub" >&2
+    return 0
+  fi
+
+  _aw_cache_default_branch
+  local suggested
+  if ! suggested=$(_aw_github_codeowners_reviewers "$(_aw_get_default_branch)"); then
+    _aw_is_quiet || gum style --foreground 8 "No CODEOWNERS file; not requesting reviewers" >&2
+    return 0
+  fi
+
+  local reviewer
+  while IFS= read -r reviewer; do
+    [[ -n "$reviewer" ]] && reviewers+=("$reviewer")
+  done <<< "$suggested"
+
+  if _aw_is_quiet; then
+    return 0
+  elif [[ ${#reviewers[@]} -eq 0 ]]; then
+    gum style --foreground 8 "No code owners for the changed files; not requesting reviewers" >&2
+  else
+    gum style --foreground 6 "Requesting reviews from CODEOWNERS: ${reviewers[*]}" >&2
+  fi
+}
+
+_aw_pr_create() {
+  # Open a PR/MR for the current worktree's branch, pushing it first if it
+  # has no upstream yet
+  # Args: $1 = provider, $2 = "true" to open it as a draft,
+  #       $3 = "true" to request reviewers from CODEOWNERS
+  local provider="$1"
+  local draft="${2:-false}"
+  local suggest_reviewers="${3:-false}"
+  local pr_term=$(_aw_pr_term "$provider")
+
+  local branch
+  branch=$(git rev-parse --abbrev-ref HEAD 2>/dev/null)
+  if [[ -z "$branch" ]] || [[ "$branch" == "HEAD" ]]; then
+    gum style --foreground 1 "Error: Not on a branch; check out the worktree's branch before opening a $pr_term" >&2
+    return 1
+  fi
+  _aw_cache_default_branch
+  if [[ "$branch" == "$(_aw_get_default_branch)" ]]; then
+    gum style --foreground 1 "Error: Cannot open a $pr_term from the default branch ($branch)" >&2
+    return $AW_EXIT_USAGE
+  fi
+
+  if ! git rev-parse --abbrev-ref --symbolic-full-name "@{u}" &>/dev/null; then
+    if ! gum spin --spinner dot --title "Pushing $branch..." -- git push -u origin "$branch"; then
+      gum style --foreground 1 "Error: Failed to push $branch to origin" >&2
+      return $AW_EXIT_PROVIDER
+    fi
+  fi
+
+  local -a reviewers=()
+  if [[ "$suggest_reviewers" == "true" ]]; then
+    _aw_pr_suggest_reviewers "$provider"
+  fi
+
+  local url
+  if ! url=$(_aw_create_pr "$provider" "$draft" "${reviewers[@]}"); then
+    gum style --foreground 1 "Error: Failed to create $pr_term for $branch" >&2
+    return $AW_EXIT_PROVIDER
+  fi
+
+  if _aw_is_quiet; then
+    echo "$url"
+  elif [[ "$draft" == "true" ]]; then
+    gum style --foreground 2 "✓ Created draft $pr_term: $url"
+  else
+    gum style --foreground 2 "✓ Created $pr_term: $url"
+  fi
+}
+
 _aw_pr() {
-  _aw_ensure_git_repo || return 1
+  _aw_ensure_git_repo || return $?
   _aw_get_repo_info
 
+  # Keep the original arguments for re-displaying the list after
+  # toggling auto-select
+  local pr_args=("$@")
+  local flag_create=false
+  local flag_draft=false
+  local flag_suggest_reviewers=false
+  local flag_stat=false
+  local author=""
+  local not_author=""
+  local pr_arg=""
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --author|--not-author)
+        if [[ -z "${2:-}" ]]; then
+          gum style --foreground 1 "Error: $1 requires a login (or @me)" >&2
+          return $AW_EXIT_USAGE
+        fi
+        if [[ "$1" == "--author" ]]; then
+          author="$2"
+        else
+          not_author="$2"
+        fi
+        shift 2
+        ;;
+      --create)
+        flag_create=true
+        shift
+        ;;
+      --draft)
+        flag_draft=true
+        shift
+        ;;
+      --suggest-reviewers)
+        flag_suggest_reviewers=true
+        shift
+        ;;
+      --stat)
+        flag_stat=true
+        shift
+        ;;
+      --*)
+        gum style --foreground 1 "Unknown option: $1" >&2
+        return $AW_EXIT_USAGE
+        ;;
+      *)
+        pr_arg="$1"
+        shift
+        ;;
+    esac
+  done
+
+  if [[ "$flag_draft" == "true" || "$flag_suggest_reviewers" == "true" ]] && [[ "$flag_create" != "true" ]]; then
+    gum style --foreground 1 "Usage: auto-worktree pr --create [--draft] [--suggest-reviewers]" >&2
+    return $AW_EXIT_USAGE
+  fi
+
+  # The author filters narrow the list to pick from
+  if [[ -n "$author" || -n "$not_author" ]] && [[ "$flag_create" == "true" || -n "$pr_arg" ]]; then
+    gum style --foreground 1 "Usage: auto-worktree pr [--author <login>] [--not-author <login>]" >&2
+    return $AW_EXIT_USAGE
+  fi
+
   # PR/MR commands use the git hosting provider (github/gitlab), not the issue
-  # tracker. Map jira/linear → github so users with JIRA/Linear as their issue
-  # provider can still review GitHub PRs without needing jira/linear CLIs.
+  # tracker. JIRA/Linear users still review GitHub PRs without needing
+  # jira/linear CLIs.
   local provider
-  provider=$(_aw_get_issue_provider)
-  if [[ "$provider" == "jira" ]] || [[ "$provider" == "linear" ]] || [[ -z "$provider" ]]; then
-    provider="github"
+  provider=$(_aw_get_pr_provider)
+  _aw_require_provider "$provider" || return $?
+  local pr_term=$(_aw_pr_term "$provider")
+
+  if [[ "$provider" == "gitlab" ]] && [[ -n "$not_author" ]]; then
+    gum style --foreground 1 "Error: --not-author is only supported for GitHub PRs" >&2
+    return $AW_EXIT_USAGE
+  fi
+  # glab wants a username, not @me
+  if [[ "$provider" == "gitlab" ]] && [[ "$author" == "@me" ]]; then
+    if ! author=$(_aw_resolve_assignee gitlab "$author"); then
+      gum style --foreground 1 "Error: Could not determine your GitLab username for --author @me" >&2
+      return $AW_EXIT_PROVIDER
+    fi
   fi
 
-  local pr_num="${1:-}"
+  if [[ "$flag_create" == "true" ]]; then
+    _aw_pr_create "$provider" "$flag_draft" "$flag_suggest_reviewers"
+    return $?
+  fi
+
+  local pr_num="$pr_arg"
+  pr_num="${pr_num#\#}"
+  pr_num="${pr_num#\!}"
 
   if [[ -z "$pr_num" ]]; then
     if [[ "$provider" == "gitlab" ]]; then
@@ -223,55 +474,15 @@ _aw_pr() {
       gum spin --spinner dot --title "Fetching pull requests..." -- sleep 0.1
     fi
 
-    local prs=""
-    if [[ "$provider" == "gitlab" ]]; then
-      # List GitLab MRs
-      local gitlab_server=$(_aw_get_gitlab_server)
-      local glab_cmd="glab"
-      if [[ -n "$gitlab_server" ]]; then
-        glab_cmd="glab --host $gitlab_server"
-      fi
-
-      prs=$($glab_cmd mr list --state opened --per-page 100 2>/dev/null | \
-        awk -F'\t' '{
-          if ($1 ~ /^![0-9]+/) {
-            number = substr($1, 2)  # Remove ! prefix
-            title = $2
-            branch = $3
-            gsub(/[()]/, "", branch)  # Remove parentheses
-            printf "#%s | ○ | %s | %s\n", number, title, branch
-          }
-        }')
-    else
-      # List GitHub PRs with detailed information for AI selection
-      prs=$(gh pr list --limit 100 --state open --json number,title,author,headRefName,baseRefName,labels,statusCheckRollup,reviews,additions,deletions,reviewRequests 2>/dev/null | \
-        jq -r '.[] | "#\(.number) | \(
-          if (.statusCheckRollup | length == 0) then "○"
-          elif (.statusCheckRollup | all(.state == "SUCCESS")) then "✓"
-          elif (.statusCheckRollup | any(.state == "FAILURE" or .state == "ERROR")) then "✗"
-          else "○"
-          end
-        ) | \(.title) | @\(.author.login)\(
-          if (.labels | length > 0) then " |" + ([.labels[].name] | map(" [\(.)]") | join(""))
-          else ""
-          end
-        ) | +\(.additions)/-\(.deletions) | \(
-          if (.reviews | length) > 0 then "reviews:\(.reviews | length)"
-          else "reviews:0"
-          end
-        )\(
-          if (.reviewRequests | length) > 0 then " | requested:[" + ([.reviewRequests[].login] | join(",")) + "]"
-          else ""
-          end
-        ) | \(.headRefName)"')
-    fi
+    local prs=$(_aw_list_prs "$provider" "$author" "$not_author")
 
     if [[ -z "$prs" ]]; then
-      if [[ "$provider" == "gitlab" ]]; then
-        gum style --foreground 1 "No open MRs found or not in a GitLab repository"
-      else
-        gum style --foreground 1 "No open PRs found or not in a GitHub repository"
+      if [[ -n "$author" || -n "$not_author" ]]; then
+        local filter_desc="${author:+ by $author}${not_author:+ not by $not_author}"
+        _aw_is_quiet || gum style --foreground 3 "No open ${pr_term}s${filter_desc} found"
+        return 0
       fi
+      gum style --foreground 1 "No open ${pr_term}s found or not in a $(_aw_provider_display_name "$provider") repository" >&2
       return 1
     fi
 
@@ -347,7 +558,7 @@ _aw_pr() {
     fi
 
     if [[ -z "$selection" ]]; then
-      gum style --foreground 3 "Cancelled"
+      gum style --foreground 3 "Cancelled" >&2
       return $AW_EXIT_CANCELLED
     fi
 
@@ -361,9 +572,9 @@ _aw_pr() {
       local filtered_prs=$(_ai_select_prs "$prs" "$highlighted_prs" "$current_user" "${REPO_OWNER}/${REPO_NAME}")
 
       if [[ -z "$filtered_prs" ]]; then
-        gum style --foreground 1 "AI selection failed, showing all PRs"
+        gum style --foreground 1 "AI selection failed, showing all PRs" >&2
         filtered_prs="$highlighted_prs"
-      else
+      elif ! _aw_is_quiet; then
         echo ""
         gum style --foreground 2 "✓ AI selected top 5 PRs in priority order"
         echo ""
@@ -373,7 +584,7 @@ _aw_pr() {
       selection=$(echo "$filtered_prs" | gum filter --placeholder "Select a PR from AI recommendations")
 
       if [[ -z "$selection" ]]; then
-        gum style --foreground 3 "Cancelled"
+        gum style --foreground 3 "Cancelled" >&2
         return $AW_EXIT_CANCELLED
       fi
 
@@ -381,16 +592,16 @@ _aw_pr() {
 
     elif [[ "$provider" == "github" ]] && [[ "$selection" == "🚫 Do not show me auto select again" ]]; then
       _disable_pr_autoselect
-      gum style --foreground 3 "Auto-select disabled. You can re-enable it from the bottom of the PR list."
+      _aw_is_quiet || gum style --foreground 3 "Auto-select disabled. You can re-enable it from the bottom of the PR list."
       # Recursively call to show the updated list
-      _aw_pr "$@"
+      _aw_pr "${pr_args[@]}"
       return $?
 
     elif [[ "$provider" == "github" ]] && [[ "$selection" == "⚡ Auto select next PR" ]]; then
       _enable_pr_autoselect
-      gum style --foreground 2 "Auto-select re-enabled!"
+      _aw_is_quiet || gum style --foreground 2 "Auto-select re-enabled!"
       # Recursively call to show the updated list
-      _aw_pr "$@"
+      _aw_pr "${pr_args[@]}"
       return $?
 
     else
@@ -403,77 +614,48 @@ _aw_pr() {
   local head_ref=""
   local base_ref=""
   local author=""
+  local body=""
+  local is_fork=false
+  local pr_ref=$(_aw_format_pr_ref "$pr_num" "$provider")
 
-  if [[ "$provider" == "gitlab" ]]; then
-    local gitlab_server=$(_aw_get_gitlab_server)
-    local glab_cmd="glab"
-    if [[ -n "$gitlab_server" ]]; then
-      glab_cmd="glab --host $gitlab_server"
-    fi
-
-    local mr_data=$($glab_cmd mr view "$pr_num" --json title,sourceBranch,targetBranch,author 2>/dev/null)
-
-    if [[ -z "$mr_data" ]]; then
-      gum style --foreground 1 "Could not fetch MR !$pr_num"
-      return 1
-    fi
-
-    title=$(echo "$mr_data" | jq -r '.title')
-    head_ref=$(echo "$mr_data" | jq -r '.sourceBranch')
-    base_ref=$(echo "$mr_data" | jq -r '.targetBranch')
-    author=$(echo "$mr_data" | jq -r '.author.username // .author.login // ""')
-  else
-    local pr_data=$(gh pr view "$pr_num" --json number,title,headRefName,baseRefName,author 2>/dev/null)
-
-    if [[ -z "$pr_data" ]]; then
-      gum style --foreground 1 "Could not fetch PR #$pr_num"
-      return 1
-    fi
-
-    title=$(echo "$pr_data" | jq -r '.title')
-    head_ref=$(echo "$pr_data" | jq -r '.headRefName')
-    base_ref=$(echo "$pr_data" | jq -r '.baseRefName')
-    author=$(echo "$pr_data" | jq -r '.author.login')
+  if ! _aw_get_pr_details "$pr_num" "$provider" || [[ -z "$head_ref" ]]; then
+    gum style --foreground 1 "Could not fetch $pr_term $pr_ref" >&2
+    return $AW_EXIT_PROVIDER
   fi
 
   # Compute worktree path
-  local worktree_prefix="pr"
-  if [[ "$provider" == "gitlab" ]]; then
-    worktree_prefix="mr"
-  fi
+  local worktree_prefix=$(echo "$pr_term" | tr '[:upper:]' '[:lower:]')
   local worktree_name="${worktree_prefix}-${pr_num}"
   local worktree_path="$_AW_WORKTREE_BASE/$worktree_name"
 
   # Display PR info
-  echo ""
-  if [[ "$provider" == "gitlab" ]]; then
-    gum style --border rounded --padding "0 1" --border-foreground 5 -- \
-      "MR !${pr_num} by @${author}" \
-      "$title" \
-      "" \
-      "$head_ref -> $base_ref"
-  else
+  if ! _aw_is_quiet; then
+    echo ""
     gum style --border rounded --padding "0 1" --border-foreground 5 -- \
-      "PR #${pr_num} by @${author}" \
+      "$pr_term ${pr_ref} by @${author}" \
       "$title" \
       "" \
       "$head_ref -> $base_ref"
+
+    [[ "$is_fork" == "true" ]] && gum style --foreground 8 "From a fork; checking out as pr-${pr_num}"
+
+    _aw_pr_show_checks "$provider" "$pr_num"
   fi
 
   # Ensure worktree exists (fetch, create/update, cd)
-  _aw_ensure_pr_worktree "$provider" "$pr_num" "$head_ref" "$base_ref" "$worktree_path" || return 1
+  _aw_ensure_pr_worktree "$provider" "$pr_num" "$head_ref" "$base_ref" "$worktree_path" "$is_fork" || return 1
 
-  # Show diff stats
-  echo ""
-  gum style --border rounded --padding "0 1" --border-foreground 6 \
-    "Changes vs $base_ref"
-  git --no-pager diff --stat "origin/${base_ref}...HEAD" 2>/dev/null || git --no-pager diff --stat HEAD~5...HEAD 2>/dev/null
+  # The worktree path is the only output scripts need
+  _aw_is_quiet && echo "$worktree_path"
+
+  # Per-file diff stats are long for big PRs, so only on request
+  [[ "$flag_stat" == "true" ]] && _aw_pr_show_diff_stat "$base_ref"
 
   # Action menu
   local action
   action=$(_aw_pr_action_menu)
   if [[ -z "$action" ]]; then
-    gum style --foreground 3 "Cancelled"
+    gum style --foreground 3 "Cancelled" >&2
     return $AW_EXIT_CANCELLED
   fi
 
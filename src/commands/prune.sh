@@ -0,0 +1,156 @@
+#!/bin/bash
+
+# ============================================================================
+# Prune orphaned worktree references (and, with --all, merged worktrees)
+# ============================================================================
+
+_aw_prune_branch_has_commits() {
+  # Returns 0 if the branch has moved on from the commit it was created at,
+  # according to its reflog. A branch without a reflog counts as untouched.
+  local branch="$1"
+  local created_at=$(git reflog show --format=%H "refs/heads/$branch" -- 2>/dev/null | tail -n 1)
+  [[ -z "$created_at" ]] && return 1
+  [[ "$(git rev-parse "refs/heads/$branch" 2>/dev/null)" != "$created_at" ]]
+}
+
+_aw_prune_merged_reason() {
+  # Echo why a worktree's branch counts as merged, or return 1 if it doesn't.
+  # A branch only counts once it has commits of its own that reached the
+  # default branch, or its PR/MR was merged, so fresh worktrees are kept.
+  # Usage: _aw_prune_merged_reason branch [provider]
+  local wt_branch="$1"
+  local provider="$2"
+  local default_branch=$(_aw_get_default_branch)
+
+  [[ -z "$default_branch" ]] && return 1
+  [[ "$wt_branch" == "$default_branch" ]] && return 1
+
+  if _aw_prune_branch_has_commits "$wt_branch" && _aw_branch_merged_into_default "$wt_branch"; then
+    echo "fully merged into $default_branch"
+    return 0
+  fi
+
+  # JIRA and Linear have no PRs of their own; their check is the git one above
+  case "$provider" in
+    github)
+      _aw_check_branch_pr_merged "$wt_branch" "$provider" && echo "PR merged" && return 0
+      ;;
+    gitlab)
+      _aw_check_branch_pr_merged "$wt_branch" "$provider" && echo "MR merged" && return 0
+      ;;
+  esac
+  return 1
+}
+
+_aw_prune() {
+  _aw_ensure_git_repo || return $?
+  _aw_get_repo_info
+
+  local flag_all=false
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --all)
+        flag_all=true
+        shift
+        ;;
+      *)
+        gum style --foreground 1 "Unknown option: $1" >&2
+        return $AW_EXIT_USAGE
+        ;;
+    esac
+  done
+
+  # Orphaned references: worktrees whose directories are gone
+  local count_before=$(_aw_count_worktrees "$(_aw_get_worktree_list)")
+  _aw_with_lock git worktree prune 2>/dev/null
+  local count_after=$(_aw_count_worktrees "$(_aw_get_worktree_list)")
+  local pruned=$((count_before - count_after))
+  if [[ $pruned -gt 0 ]]; then
+    _aw_is_quiet || gum style --foreground 2 "✓ Pruned $pruned orphaned worktree reference(s)"
+  else
+    _aw_is_quiet || gum style --foreground 8 "No orphaned worktree references"
+  fi
+
+  [[ "$flag_all" == "true" ]] || return 0
+
+  # Worktrees whose branches are merged into the default branch
+  local current_real=$(pwd -P)
+  local -a merged_paths=()
+  local -a merged_branches=()
+  local -a merged_reasons=()
+  local -a skipped=()
+  local locked_list=$(_aw_get_locked_worktrees)
+  local provider=$(_aw_get_issue_provider)
+  _aw_cache_default_branch
+
+  local wt_path
+  while IFS= read -r wt_path; do
+    _aw_validate_worktree_path "$wt_path" || continue
+
+    local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "")
+    [[ -z "$wt_branch" ]] || [[ "$wt_branch" == "HEAD" ]] && continue
+
+    local reason
+    reason=$(_aw_prune_merged_reason "$wt_branch" "$provider") || continue
+
+    local wt_real=$(cd "$wt_path" && pwd -P)
+    if _aw_worktree_is_locked "$wt_path" "$locked_list"; then
+      skipped+=("$(basename "$wt_path") ($wt_branch): locked")
+    elif [[ "$current_real" == "$wt_real" || "$current_real" == "$wt_real"/* ]]; then
+      skipped+=("$(basename "$wt_path") ($wt_branch): current worktree")
+    elif [[ -n "$(git -C "$wt_path" status --porcelain 2>/dev/null)" ]]; then
+      skipped+=("$(basename "$wt_path") ($wt_branch): uncommitted changes")
+    else
+      merged_paths+=("$wt_path")
+      merged_branches+=("$wt_branch")
+      merged_reasons+=("$reason")
+    fi
+  done <<< "$(_aw_get_worktree_list)"
+
+  local entry
+  for entry in "${skipped[@]}"; do
+    gum style --foreground 3 "Skipping $entry" >&2
+  done
+
+  if [[ ${#merged_paths[@]} -eq 0 ]]; then
+    _aw_is_quiet || gum style --foreground 8 "No merged worktrees to remove"
+    return 0
+  fi
+
+  # Arrays are 1-indexed in zsh and 0-indexed in bash; ${array[@]:offset:1}
+  # counts from 0 in both
+  local i=1
+  if ! _aw_is_quiet; then
+    echo ""
+    gum style --foreground 5 "Merged worktrees:"
+    while [[ $i -le ${#merged_paths[@]} ]]; do
+      local m_path="${merged_paths[@]:$((i - 1)):1}"
+      local m_branch="${merged_branches[@]:$((i - 1)):1}"
+      local m_reason="${merged_reasons[@]:$((i - 1)):1}"
+      echo "  • $(basename "$m_path") ($m_branch): $m_reason"
+      i=$((i + 1))
+    done
+    echo ""
+  fi
+
+  # auto-worktree.prune-no-confirm skips this prompt in trusted repositories
+  local no_confirm=$(git config --bool auto-worktree.prune-no-confirm 2>/dev/null)
+  if [[ "$no_confirm" != "true" ]] && ! gum confirm "Remove ${#merged_paths[@]} merged worktree(s) and their branches?"; then
+    gum style --foreground 8 "Prune cancelled" >&2
+    return $AW_EXIT_CANCELLED
+  fi
+
+  i=1
+  while [[ $i -le ${#merged_paths[@]} ]]; do
+    local m_path="${merged_paths[@]:$((i - 1)):1}"
+    local m_branch="${merged_branches[@]:$((i - 1)):1}"
+    local m_reason="${merged_reasons[@]:$((i - 1)):1}"
+    _aw_is_quiet || gum style --foreground 8 "Removing $(basename "$m_path"): $m_reason"
+    _aw_remove_worktree_and_branch "$m_path" "$m_branch" || return 1
+    i=$((i + 1))
+  done
+
+  _aw_is_quiet && return 0
+  echo ""
+  gum style --foreground 2 "✓ Removed ${#merged_paths[@]} merged worktree(s)"
+}
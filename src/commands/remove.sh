@@ -0,0 +1,207 @@
+#!/bin/bash
+
+# ============================================================================
+# Remove a worktree by branch name or path
+# ============================================================================
+_aw_resolve_worktree_target() {
+  # Resolve a branch name or filesystem path to a registered worktree path.
+  # Branch names are tried first; the argument is only treated as a path when
+  # no worktree has a matching branch checked out.
+  # Returns 1 if the target doesn't identify a worktree.
+  local target="$1"
+  [[ -z "$target" ]] && return 1
+
+  local wt_path
+  wt_path=$(_aw_get_worktree_for_branch "$target")
+  if [[ -n "$wt_path" ]]; then
+    echo "$wt_path"
+    return 0
+  fi
+
+  if [[ -d "$target" ]]; then
+    local abs_path
+    abs_path=$(cd "$target" && pwd -P)
+    while IFS= read -r wt_path; do
+      [[ -z "$wt_path" ]] && continue
+      if [[ "$(cd "$wt_path" 2>/dev/null && pwd -P)" == "$abs_path" ]]; then
+        echo "$wt_path"
+        return 0
+      fi
+    done <<< "$(_aw_get_worktree_list)"
+  fi
+
+  return 1
+}
+
+_aw_remove_choices() {
+  # Lines of "path<TAB>display" for every worktree except the main one
+  local wt_path
+  while IFS= read -r wt_path; do
+    [[ -z "$wt_path" ]] && continue
+    _aw_validate_worktree_path "$wt_path" || continue
+
+    local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "unknown")
+    local commit_timestamp=$(_aw_get_worktree_timestamp "$wt_path" "$wt_branch")
+    local display="$(basename "$wt_path") ($wt_branch) $(_aw_format_worktree_age "$commit_timestamp")"
+    if [[ -n "$(git -C "$wt_path" status --porcelain 2>/dev/null)" ]]; then
+      display="$display [dirty]"
+    fi
+    printf '%s\t%s\n' "$wt_path" "$display"
+  done <<< "$(_aw_get_worktree_list)"
+}
+
+_aw_remove() {
+  _aw_ensure_git_repo || return $?
+  _aw_get_repo_info
+
+  local target=""
+  local delete_branch=false
+  local force=false
+  local interactive=false
+
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --keep-branch)
+        delete_branch=false
+        shift
+        ;;
+      --delete-branch)
+        delete_branch=true
+        shift
+        ;;
+      --force|-f)
+        force=true
+        shift
+        ;;
+      --interactive|-i)
+        interactive=true
+        shift
+        ;;
+      -D)
+        # Like `git branch -D`: delete the branch even if it isn't merged
+        delete_branch=true
+        force=true
+        shift
+        ;;
+      -*)
+        gum style --foreground 1 "Unknown option: $1" >&2
+        return $AW_EXIT_USAGE
+        ;;
+      *)
+        target="$1"
+        shift
+        ;;
+    esac
+  done
+
+  if [[ "$interactive" == "true" ]] && [[ -n "$target" ]]; then
+    gum style --foreground 1 "Error: --interactive picks the worktree itself; don't also pass $target" >&2
+    return $AW_EXIT_USAGE
+  fi
+
+  # --interactive: pick the worktree from a list, as the menu does, then
+  # confirm before anything is removed
+  if [[ "$interactive" == "true" ]]; then
+    local choices=$(_aw_remove_choices)
+    if [[ -z "$choices" ]]; then
+      gum style --foreground 8 "No additional worktrees for $_AW_SOURCE_FOLDER"
+      return 0
+    fi
+
+    local selected
+    selected=$(echo "$choices" | cut -f2 | gum filter --placeholder "Select a worktree to remove...")
+    if [[ -z "$selected" ]]; then
+      gum style --foreground 3 "Cancelled" >&2
+      return $AW_EXIT_CANCELLED
+    fi
+
+    target=$(echo "$choices" | awk -F'\t' -v sel="$selected" '$2 == sel { print $1; exit }')
+    if [[ -z "$target" ]]; then
+      gum style --foreground 1 "Error: Could not find selected worktree" >&2
+      return 1
+    fi
+
+    local prompt="Remove worktree $(basename "$target")?"
+    [[ "$delete_branch" == "true" ]] && prompt="Remove worktree $(basename "$target") and delete its branch?"
+    if ! gum confirm "$prompt"; then
+      gum style --foreground 3 "Cancelled" >&2
+      return $AW_EXIT_CANCELLED
+    fi
+  fi
+
+  if [[ -z "$target" ]]; then
+    gum style --foreground 1 "Usage: auto-worktree remove [--keep-branch|--delete-branch [--force]] [--interactive | <branch|path>]" >&2
+    return $AW_EXIT_USAGE
+  fi
+
+  local wt_path
+  wt_path=$(_aw_resolve_worktree_target "$target")
+
+  if [[ -z "$wt_path" ]]; then
+    gum style --foreground 1 "Error: No worktree found for branch or path: $target" >&2
+    return 1
+  fi
+
+  # Compare against the repository's own checkout rather than _AW_GIT_ROOT,
+  # which is the current worktree when run from inside one
+  local main_path=$(_aw_get_worktree_list | head -n 1)
+  if [[ "$(_aw_physical_path "$wt_path")" == "$(_aw_physical_path "$main_path")" ]]; then
+    gum style --foreground 1 "Error: Cannot remove the main worktree: $wt_path" >&2
+    echo "  This is the repository's own checkout, not a worktree added alongside it." >&2
+    return 1
+  fi
+
+  local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "")
+
+  if _aw_worktree_is_locked "$wt_path"; then
+    gum style --foreground 1 "Error: Worktree is locked: $wt_path" >&2
+    local unlock_target="$wt_branch"
+    [[ -z "$unlock_target" ]] || [[ "$unlock_target" == "HEAD" ]] && unlock_target="$wt_path"
+    echo "  Unlock it first: auto-worktree unlock $unlock_target" >&2
+    return 1
+  fi
+
+  # Confirm before throwing away uncommitted work
+  if [[ -n "$(git -C "$wt_path" status --porcelain 2>/dev/null)" ]]; then
+    gum style --foreground 3 "Worktree has uncommitted changes: $wt_path" >&2
+    if ! gum confirm "Remove anyway?"; then
+      gum style --foreground 3 "Cancelled" >&2
+      return $AW_EXIT_CANCELLED
+    fi
+  fi
+
+  # Step out of the worktree before removing it. _AW_GIT_ROOT is the
+  # worktree itself when run from inside it, so go to the main checkout.
+  local current_dir=$(pwd -P)
+  local wt_real=$(cd "$wt_path" && pwd -P)
+  if [[ "$current_dir" == "$wt_real" || "$current_dir" == "$wt_real"/* ]]; then
+    cd "$main_path" || return 1
+  fi
+
+  # The branch is kept unless --delete-branch was given, so only the
+  # checkout goes away by default
+  _aw_remove_worktree_and_branch "$wt_path" || return 1
+
+  if [[ -z "$wt_branch" ]] || [[ "$wt_branch" == "HEAD" ]]; then
+    return 0
+  fi
+
+  # pr-<n> branches fetched for PRs from forks are temporary and go with
+  # their worktree
+  if [[ -n "$(_aw_get_branch_metadata "$wt_branch" "fork-pr")" ]]; then
+    delete_branch=true
+    force=true
+  fi
+
+  if [[ "$delete_branch" != "true" ]]; then
+    _aw_is_quiet || gum style --foreground 8 "Branch kept: $wt_branch"
+    return 0
+  fi
+
+  if ! _aw_delete_branch "$wt_branch" "$force"; then
+    gum style --foreground 1 "Error: Branch '$wt_branch' is not fully merged; it was kept" >&2
+    echo "  Re-run with --force (or -D) to delete it anyway: git branch -D $wt_branch" >&2
+    return 1
+  fi
+  _aw_is_quiet || gum style --foreground 2 "✓ Branch deleted: $wt_branch"
+}
@@ -0,0 +1,128 @@
+#!/bin/bash
+
+# ============================================================================
+# Rename a worktree's branch and move the worktree to match
+# ============================================================================
+
+_aw_rename_worktree() {
+  # Rename the branch, then move the checkout. Runs under the repository lock.
+  # The directory is moved with mv + `git worktree repair` rather than
+  # `git worktree move`, which refuses worktrees containing submodules.
+  # Usage: _aw_rename_worktree old_branch new_branch old_path new_path
+  local old_branch="$1"
+  local new_branch="$2"
+  local old_path="$3"
+  local new_path="$4"
+
+  if ! git branch -m "$old_branch" "$new_branch" 2>/dev/null; then
+    gum style --foreground 1 "Error: Failed to rename branch '$old_branch' to '$new_branch'"
+    return 1
+  fi
+
+  if [[ "$old_path" != "$new_path" ]]; then
+    if ! mv "$old_path" "$new_path"; then
+      git branch -m "$new_branch" "$old_branch" 2>/dev/null
+      gum style --foreground 1 "Error: Failed to move worktree to: $new_path"
+      return 1
+    fi
+    if ! git worktree repair "$new_path" >/dev/null 2>&1; then
+      gum style --foreground 3 "Warning: git worktree repair failed for $new_path"
+    fi
+  fi
+}
+
+_aw_rename() {
+  _aw_ensure_git_repo || return $?
+  _aw_get_repo_info
+
+  local old_branch="${1:-}"
+  local new_branch="${2:-}"
+
+  # `rename <new-branch>` renames the worktree we're standing in
+  if [[ -n "$old_branch" ]] && [[ -z "$new_branch" ]]; then
+    new_branch="$old_branch"
+    old_branch=$(_aw_current_worktree_branch)
+  fi
+
+  if [[ -z "$old_branch" ]] || [[ -z "$new_branch" ]]; then
+    gum style --foreground 1 "Usage: auto-worktree rename [<old-branch>] <new-branch> (old defaults to the current worktree)"
+    return $AW_EXIT_USAGE
+  fi
+
+  local old_path
+  old_path=$(_aw_get_worktree_for_branch "$old_branch")
+  if [[ -z "$old_path" ]]; then
+    gum style --foreground 1 "Error: No worktree found for branch: $old_branch"
+    return 1
+  fi
+
+  # _AW_GIT_ROOT is the current worktree when run from inside one; the main
+  # worktree is always listed first
+  local main_path=$(_aw_get_worktree_list | head -n 1)
+  if [[ "$old_path" == "$main_path" ]] || [[ ! -d "$old_path" ]]; then
+    gum style --foreground 1 "Error: Refusing to rename the main worktree's branch"
+    return 1
+  fi
+
+  if ! git check-ref-format --branch "$new_branch" >/dev/null 2>&1; then
+    gum style --foreground 1 "Error: Invalid branch name: $new_branch"
+    return $AW_EXIT_USAGE
+  fi
+
+  if git show-ref --verify --quiet "refs/heads/${new_branch}"; then
+    gum style --foreground 1 "Error: Branch '$new_branch' already exists"
+    return $AW_EXIT_EXISTS
+  fi
+
+  # Only directories named after their branch (as auto-worktree creates them)
+  # are moved; anything else keeps its path
+  local new_path="$old_path"
+  if [[ "$(basename "$old_path")" == "$(_aw_worktree_dir_name "$old_branch")" ]]; then
+    new_path="$(dirname "$old_path")/$(_aw_worktree_dir_name "$new_branch")"
+  fi
+
+  if [[ "$new_path" != "$old_path" ]]; then
+    if [[ -e "$new_path" ]] || _aw_get_worktree_list | grep -qxF "$new_path"; then
+      gum style --foreground 1 "Error: A worktree already exists at: $new_path"
+      return $AW_EXIT_EXISTS
+    fi
+  fi
+
+  # Step out of the worktree before moving it, and back in afterwards
+  local current_dir=$(pwd -P)
+  local old_real=$(cd "$old_path" && pwd -P)
+  local rel_dir=""
+  local inside=false
+  if [[ "$current_dir" == "$old_real" || "$current_dir" == "$old_real"/* ]]; then
+    inside=true
+    rel_dir="${current_dir#"$old_real"}"
+    cd "$main_path" || return 1
+  fi
+
+  # Read before the move; the tmux session is named after the directory
+  local old_session=$(_aw_tmux_session_name "$old_path")
+
+  if ! _aw_with_lock _aw_rename_worktree "$old_branch" "$new_branch" "$old_path" "$new_path"; then
+    [[ "$inside" == "true" ]] && cd "$old_path$rel_dir" 2>/dev/null
+    return 1
+  fi
+
+  # Branch metadata (branch.<name>.aw-*) moves with the branch section in git
+  # config; the tmux session has to be renamed by hand
+  local new_session=$(_aw_tmux_session_name "$new_path")
+  if [[ "$old_session" != "$new_session" ]] && command -v tmux &>/dev/null \
+    && tmux has-session -t "=$old_session" 2>/dev/null; then
+    tmux rename-session -t "=$old_session" "$new_session" 2>/dev/null
+  fi
+
+  if [[ "$inside" == "true" ]]; then
+    cd "$new_path$rel_dir" 2>/dev/null || cd "$new_path" || return 1
+  fi
+
+  if ! _aw_is_quiet; then
+    gum style --foreground 2 "✓ Renamed branch: $old_branch → $new_branch"
+    [[ "$new_path" != "$old_path" ]] && gum style --foreground 2 "✓ Moved worktree: $new_path"
+  fi
+  _aw_is_quiet && echo "$new_path"
+  return 0
+}
@@ -3,11 +3,109 @@
 # ============================================================================
 # Resume worktree
 # ============================================================================
+_aw_tmux_session_name() {
+  # tmux session name for a worktree: its directory name, with the
+  # characters tmux doesn't allow in target names replaced
+  # Usage: _aw_tmux_session_name worktree_path
+  basename "$1" | tr '.:' '--'
+}
+
+_aw_resume_list() {
+  # Pick from worktrees ordered by when they were last opened through
+  # auto-worktree (falling back to their last commit), then reattach to the
+  # worktree's tmux session or print its path for cd.
+  local worktree_list=$(_aw_get_worktree_list)
+
+  # Lines of "timestamp<TAB>path<TAB>display" for sorting
+  local entries=""
+  local wt_path
+  while IFS= read -r wt_path; do
+    _aw_validate_worktree_path "$wt_path" || continue
+
+    local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "unknown")
+    local accessed=$(_aw_get_branch_metadata "$wt_branch" "last-accessed")
+    local label="accessed"
+    if ! [[ "$accessed" =~ ^[0-9]+$ ]]; then
+      accessed=$(_aw_get_worktree_timestamp "$wt_path" "$wt_branch")
+      label="committed"
+    fi
+    [[ "$accessed" =~ ^[0-9]+$ ]] || accessed=0
+
+    local display="$(basename "$wt_path") ($wt_branch) $label $(_aw_format_worktree_age "$accessed")"
+    entries+="${accessed}"$'\t'"${wt_path}"$'\t'"${display}"$'\n'
+  done <<< "$worktree_list"
+
+  if [[ -z "$entries" ]]; then
+    gum style --foreground 8 "No additional worktrees for $_AW_SOURCE_FOLDER"
+    return 0
+  fi
+
+  local sorted=$(printf '%s' "$entries" | sort -t$'\t' -k1,1 -rn)
+
+  local selected
+  selected=$(echo "$sorted" | cut -f3 | gum filter --placeholder "Select a recent worktree...")
+
+  if [[ -z "$selected" ]]; then
+    gum style --foreground 3 "Cancelled"
+    return $AW_EXIT_CANCELLED
+  fi
+
+  local selected_path=$(echo "$sorted" | awk -F'\t' -v sel="$selected" '$3 == sel { print $2; exit }')
+  if [[ -z "$selected_path" ]]; then
+    gum style --foreground 1 "Error: Could not find selected worktree"
+    return 1
+  fi
+
+  _aw_reattach_worktree "$selected_path"
+}
+
+_aw_reattach_worktree() {
+  # Reattach to a worktree's tmux session, or print its path for cd when it
+  # has none, and record the access
+  # Usage: _aw_reattach_worktree worktree_path
+  local wt_path="$1"
+
+  _aw_touch_last_accessed "$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null)"
+
+  local session=$(_aw_tmux_session_name "$wt_path")
+  if command -v tmux &>/dev/null && tmux has-session -t "=$session" 2>/dev/null; then
+    _aw_is_quiet || gum style --foreground 2 "Attaching to tmux session: $session"
+    if [[ -n "$TMUX" ]]; then
+      tmux switch-client -t "=$session"
+    else
+      tmux attach-session -t "=$session"
+    fi
+    return $?
+  fi
+
+  _aw_is_quiet || gum style --foreground 6 "No tmux session for this worktree. To resume it, run:"
+  echo "cd \"$wt_path\""
+}
+
 _aw_resume() {
-  _aw_ensure_git_repo || return 1
+  _aw_ensure_git_repo || return $?
   _aw_get_repo_info
   _aw_prune_worktrees
 
+  local flag_list=false
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --list)
+        flag_list=true
+        shift
+        ;;
+      *)
+        gum style --foreground 1 "Unknown option: $1"
+        return $AW_EXIT_USAGE
+        ;;
+    esac
+  done
+
+  if [[ "$flag_list" == "true" ]]; then
+    _aw_resume_list
+    return $?
+  fi
+
   local worktree_list=$(_aw_get_worktree_list)
   local worktree_count=$(_aw_count_worktrees "$worktree_list")
 
@@ -29,6 +127,8 @@ _aw_resume() {
     # Build display string
     local age_str=$(_aw_format_worktree_age "$commit_timestamp")
     local display="$(basename "$wt_path") ($wt_branch) $age_str"
+    local sync_str=$(_aw_format_ahead_behind "$wt_path")
+    [[ -n "$sync_str" ]] && display="$display $sync_str"
 
     worktree_paths+=("$wt_path")
     worktree_displays+=("$display")
@@ -80,7 +180,9 @@ _aw_resume() {
 
   # Set terminal title to the branch name
   local branch_name=$(git rev-parse --abbrev-ref HEAD 2>/dev/null || echo "unknown")
+  _aw_touch_last_accessed "$branch_name"
   printf '\033]0;%s\007' "$branch_name"
+  _aw_name_tmux_window "$branch_name"
 
   _resolve_ai_command || return 1
 
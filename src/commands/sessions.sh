@@ -0,0 +1,101 @@
+#!/bin/bash
+
+# ============================================================================
+# Per-worktree session settings
+# ============================================================================
+
+_aw_sessions_keep() {
+  # Mark (or with --off, unmark) a worktree so list/cleanup/status never treat
+  # it as stale, whatever its age
+  local keep="true"
+  local branch=""
+
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --off)
+        keep=""
+        shift
+        ;;
+      -*)
+        gum style --foreground 1 "Unknown option: $1"
+        return $AW_EXIT_USAGE
+        ;;
+      *)
+        if [[ -n "$branch" ]]; then
+          gum style --foreground 1 "Usage: auto-worktree sessions keep [--off] <branch>"
+          return $AW_EXIT_USAGE
+        fi
+        branch="$1"
+        shift
+        ;;
+    esac
+  done
+
+  # Without a branch, mark the worktree we're standing in
+  [[ -z "$branch" ]] && branch=$(_aw_current_worktree_branch)
+
+  if [[ -z "$branch" ]]; then
+    gum style --foreground 1 "Usage: auto-worktree sessions keep [--off] [<branch>] (defaults to the current worktree)"
+    return $AW_EXIT_USAGE
+  fi
+
+  if [[ -z "$(_aw_get_worktree_for_branch "$branch")" ]]; then
+    gum style --foreground 1 "Error: No worktree found for branch: $branch"
+    return 1
+  fi
+
+  _aw_set_branch_metadata "$branch" "keep-alive" "$keep" || return 1
+
+  if [[ -n "$keep" ]]; then
+    gum style --foreground 2 "✓ $branch will be kept alive and never reported as stale"
+  else
+    gum style --foreground 2 "✓ $branch can be reported as stale again"
+  fi
+}
+
+_aw_sessions_list() {
+  # Print every worktree with how often it was opened, whether it is kept
+  # alive, and the issue it was created from
+  local main_path=$(_aw_get_worktree_list | head -n 1)
+  local found=false
+  local wt_path
+  while IFS= read -r wt_path; do
+    [[ -z "$wt_path" ]] && continue
+    [[ "$wt_path" == "$main_path" ]] && continue
+    local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null)
+    [[ -z "$wt_branch" ]] && continue
+
+    local usage="opened $(_aw_get_access_count "$wt_branch")×"
+    local accessed=$(_aw_get_branch_metadata "$wt_branch" "last-accessed")
+    [[ "$accessed" =~ ^[0-9]+$ ]] && usage+=", last $(_aw_format_worktree_age "$accessed")"
+    local line="$wt_branch  $wt_path  $(gum style --foreground 8 "$usage")"
+    _aw_is_kept_alive "$wt_branch" && line+="  $(gum style --foreground 6 "[keep-alive]")"
+    echo "$line"
+
+    local issue_desc=$(_aw_format_issue_metadata "$wt_branch")
+    [[ -n "$issue_desc" ]] && echo "    $(gum style --foreground 8 "↳ $issue_desc")"
+    found=true
+  done <<< "$(_aw_get_worktree_list)"
+
+  if [[ "$found" == "false" ]]; then
+    gum style --foreground 8 "No additional worktrees for $_AW_SOURCE_FOLDER"
+  fi
+}
+
+_aw_sessions() {
+  _aw_ensure_git_repo || return $?
+  _aw_get_repo_info
+
+  local subcommand="${1:-list}"
+  [[ $# -gt 0 ]] && shift
+
+  case "$subcommand" in
+    keep) _aw_sessions_keep "$@" ;;
+    list) _aw_sessions_list ;;
+    *)
+      gum style --foreground 1 "Unknown sessions command: $subcommand"
+      gum style --foreground 8 "Usage: auto-worktree sessions [list | keep [--off] <branch>]"
+      return $AW_EXIT_USAGE
+      ;;
+  esac
+}
@@ -0,0 +1,85 @@
+#!/bin/bash
+
+# ============================================================================
+# One-shot summary of a repository's worktrees
+# ============================================================================
+
+_aw_status() {
+  _aw_ensure_git_repo || return $?
+  _aw_get_repo_info
+
+  if [[ $# -gt 0 ]]; then
+    gum style --foreground 1 "Unknown option: $1"
+    return $AW_EXIT_USAGE
+  fi
+
+  _aw_prune_worktrees
+
+  local now=$(date +%s)
+  local stale_days=$(_aw_get_age_stale_days)
+  local stale_age=$((stale_days * 24 * 60 * 60))
+
+  local total=0
+  local dirty=0
+  local unpushed=0
+  local stale=0
+  local merged=0
+  _aw_cache_default_branch
+
+  local wt_path
+  while IFS= read -r wt_path; do
+    [[ -z "$wt_path" ]] && continue
+    _aw_validate_worktree_path "$wt_path" || continue
+    total=$((total + 1))
+
+    local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "unknown")
+
+    if [[ -n "$(git -C "$wt_path" status --porcelain 2>/dev/null)" ]]; then
+      dirty=$((dirty + 1))
+    fi
+
+    local has_unpushed=false
+    if _aw_has_unpushed_commits "$wt_path"; then
+      has_unpushed=true
+      unpushed=$((unpushed + 1))
+    fi
+
+    local commit_timestamp=$(_aw_get_worktree_timestamp "$wt_path" "$wt_branch")
+    if [[ "$commit_timestamp" =~ ^[0-9]+$ ]] && [[ $commit_timestamp -gt 0 ]] \
+      && [[ $((now - commit_timestamp)) -gt $stale_age ]] \
+      && ! _aw_is_kept_alive "$wt_branch"; then
+      stale=$((stale + 1))
+    fi
+
+    # Same cleanup candidates as `cleanup`: a merged PR/MR, or nothing that
+    # isn't already on the default branch
+    if _aw_check_branch_pr_merged "$wt_branch"; then
+      merged=$((merged + 1))
+    elif [[ "$has_unpushed" == "false" ]] && _aw_check_no_changes_from_default "$wt_path"; then
+      merged=$((merged + 1))
+    fi
+  done <<< "$(_aw_get_worktree_list)"
+
+  # Name the worktree we're standing in, if any
+  local current_wt current_line=""
+  if current_wt=$(_aw_current_worktree); then
+    current_line="  Current:           $(basename "$current_wt") ($(git -C "$current_wt" rev-parse --abbrev-ref HEAD 2>/dev/null))"
+  fi
+
+  local stale_label="Stale (>$stale_days days):"
+  [[ $stale_days -eq 1 ]] && stale_label="Stale (>1 day):"
+
+  gum style --border rounded --padding "0 1" --border-foreground 4 \
+    "Worktree status for $_AW_SOURCE_FOLDER" \
+    ${current_line:+"$current_line"} \
+    "  Worktrees:         $total" \
+    "  Dirty:             $dirty" \
+    "  Unpushed commits:  $unpushed" \
+    "  $(printf '%-19s' "$stale_label")$stale" \
+    "  Merged:            $merged" \
+    "  Location:          $_AW_WORKTREE_BASE"
+
+  if [[ $merged -gt 0 ]] && ! _aw_is_quiet; then
+    gum style --foreground 8 "Run 'auto-worktree cleanup' to remove merged worktrees"
+  fi
+}
@@ -0,0 +1,60 @@
+#!/bin/bash
+
+# ============================================================================
+# Version information
+# ============================================================================
+
+_aw_version_string() {
+  # ci/build.sh stamps _AW_VERSION into dist/aw.sh; when sourced from a
+  # checkout, describe the checkout instead
+  if [[ -n "${_AW_VERSION:-}" ]]; then
+    echo "$_AW_VERSION"
+  elif [[ -n "${_AW_INSTALL_DIR:-}" ]] && git -C "$_AW_INSTALL_DIR" describe --tags --always --dirty 2>/dev/null; then
+    return 0
+  else
+    echo "unknown"
+  fi
+}
+
+_aw_cli_version() {
+  # Echo the version number a CLI reports, e.g. "2.43.0" for
+  # "git version 2.43.0", or nothing if it isn't installed
+  local cli="$1"
+  command -v "$cli" &>/dev/null || return 1
+  "$cli" --version 2>/dev/null | head -n 1 | sed -nE 's/.*version v?([0-9][^ ]*).*/\1/p'
+}
+
+_aw_version_json_value() {
+  # A JSON string for a non-empty value, null otherwise
+  if [[ -n "$1" ]]; then
+    echo "\"$(_aw_json_escape "$1")\""
+  else
+    echo "null"
+  fi
+}
+
+_aw_version() {
+  local flag_json=false
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --json)
+        flag_json=true
+        shift
+        ;;
+      *)
+        gum style --foreground 1 "Unknown option: $1"
+        return $AW_EXIT_USAGE
+        ;;
+    esac
+  done
+
+  local version=$(_aw_version_string)
+  if [[ "$flag_json" != "true" ]]; then
+    echo "auto-worktree $version"
+    return 0
+  fi
+
+  local git_version=$(_aw_cli_version git)
+  local gh_version=$(_aw_cli_version gh)
+  echo "{\"version\": $(_aw_version_json_value "$version"), \"git\": $(_aw_version_json_value "$git_version"), \"gh\": $(_aw_version_json_value "$gh_version")}"
+}
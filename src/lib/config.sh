@@ -4,6 +4,47 @@
 # Project configuration (git config based)
 # ============================================================================
 
+# Every auto-worktree.* key the tool reads, grouped as "category:key key ...".
+# Drives `settings export`/`settings import` and doctor's unknown-key check.
+_AW_SETTING_CATEGORIES=(
+  "provider:issue-provider issue-provider-detected issue-list-labels issue-list-limit github-host github-remote jira-server jira-project jira-jql gitlab-server gitlab-project linear-team"
+  "ai:ai-tool ai-tool-cmd issue-autoselect pr-autoselect"
+  "hooks:run-hooks fail-on-hook-error custom-hooks hook-path"
+  "worktrees:worktree-base worktree-naming branch-prefix default-branch install-deps copy-files editor prune-no-confirm fetch-before-create list-no-status age-warn-days age-stale-days tmux-window-name post-create-message"
+  "templates:issue-templates-dir issue-templates-disabled issue-templates-no-prompt issue-templates-detected"
+)
+
+# Settings that take true or false
+_AW_BOOL_SETTINGS="issue-list-labels issue-autoselect pr-autoselect run-hooks fail-on-hook-error install-deps prune-no-confirm fetch-before-create list-no-status issue-templates-disabled issue-templates-no-prompt"
+
+_aw_setting_keys() {
+  # Echo every known setting key, one per line
+  local category key
+  for category in "${_AW_SETTING_CATEGORIES[@]}"; do
+    for key in $(echo "${category#*:}"); do
+      echo "$key"
+    done
+  done
+}
+
+_aw_is_known_setting() {
+  # Returns 0 if KEY (without the auto-worktree. prefix) is a known setting
+  _aw_setting_keys | grep -qxF -- "$1"
+}
+
+_aw_setting_choices() {
+  # Echo the values KEY accepts, one per line, or nothing for free-form keys
+  case "$1" in
+    issue-provider|issue-provider-detected) printf '%s\n' github gitlab jira linear ;;
+    ai-tool) printf '%s\n' claude codex gemini jules skip ;;
+    *)
+      if [[ " $_AW_BOOL_SETTINGS " == *" $1 "* ]]; then
+        printf '%s\n' true false
+      fi
+      ;;
+  esac
+}
+
 # Generic getter: _aw_get_config KEY
 # Returns the config value or empty string. Never errors.
 _aw_get_config() {
@@ -42,12 +83,45 @@ _aw_unset_config() {
   git config --unset "auto-worktree.$1" 2>/dev/null || true
 }
 
-_aw_get_issue_provider() {
+_aw_get_branch_prefix() {
+  # Get the prefix for generated branch names (work/<name>, work/<id>-<title>)
+  # Invalid ref characters become hyphens and surrounding slashes are dropped,
+  # so "feature/" and "/feature" both yield "feature". Default: work
+  local prefix=$(_aw_get_config "branch-prefix")
+  prefix=$(printf '%s' "$prefix" | tr -c 'A-Za-z0-9._/-' '-' |
+    sed -e 's#//*#/#g' -e 's#\.\.*#.#g' -e 's#^[/.-]*##' -e 's#[/.-]*$##')
+  echo "${prefix:-work}"
+}
+
+_aw_get_worktree_naming() {
+  # Get the template for worktree directory names. Placeholders: {repo},
+  # {branch} and {branch-basename} (the part after the last "/").
+  # Default: {branch}
+  local naming=$(_aw_get_config "worktree-naming")
+  [[ -z "$naming" ]] && naming="{branch}"
+  echo "$naming"
+}
+
+_aw_get_configured_issue_provider() {
   # Get the configured issue provider
+  # The AW_ISSUE_PROVIDER environment variable takes precedence over git config
   # Returns: github, gitlab, jira, linear, or empty string if not configured
+  if [[ -n "${AW_ISSUE_PROVIDER:-}" ]]; then
+    echo "$AW_ISSUE_PROVIDER"
+    return 0
+  fi
   _aw_get_config "issue-provider"
 }
 
+_aw_get_issue_provider() {
+  # Get the issue provider: the configured one, else the one detected from
+  # the remote
+  # Returns: github, gitlab, jira, linear, or empty string if neither is known
+  local provider=$(_aw_get_configured_issue_provider)
+  [[ -z "$provider" ]] && provider=$(_aw_detect_issue_provider)
+  echo "$provider"
+}
+
 _aw_set_issue_provider() {
   # Set the issue provider for this repository
   local provider="$1"
@@ -64,6 +138,38 @@ _aw_set_issue_provider() {
   gum style --foreground 2 "✓ Issue provider set to: $provider"
 }
 
+_aw_get_issue_list_limit() {
+  # Get how many issues, milestones and PRs to fetch for the pickers
+  # Falls back to 100 unless auto-worktree.issue-list-limit is a positive number
+  local limit=$(_aw_get_config "issue-list-limit")
+  if [[ "$limit" =~ ^[0-9]+$ ]] && [[ $((10#$limit)) -gt 0 ]]; then
+    echo "$((10#$limit))"
+  else
+    echo 100
+  fi
+}
+
+_aw_get_age_days() {
+  # Echo KEY as a positive whole number of days, or DEFAULT when unset or invalid
+  # Usage: _aw_get_age_days key default
+  local days=$(_aw_get_config "$1")
+  if [[ "$days" =~ ^[0-9]+$ ]] && [[ $((10#$days)) -gt 0 ]]; then
+    echo "$((10#$days))"
+  else
+    echo "$2"
+  fi
+}
+
+_aw_get_age_warn_days() {
+  # Age in days from which worktrees show in yellow (default: 1)
+  _aw_get_age_days "age-warn-days" 1
+}
+
+_aw_get_age_stale_days() {
+  # Age in days past which worktrees show in red and count as stale (default: 4)
+  _aw_get_age_days "age-stale-days" 4
+}
+
 _aw_get_jira_server() {
   # Get the configured JIRA server URL
   _aw_get_config "jira-server"
@@ -84,6 +190,11 @@ _aw_get_jira_project() {
   _aw_get_config "jira-project"
 }
 
+_aw_get_jira_jql() {
+  # Get the JQL that replaces the default open-issues query, if configured
+  _aw_get_config "jira-jql"
+}
+
 _aw_set_jira_project() {
   # Set the default JIRA project key for this repository
   local project="$1"
@@ -94,6 +205,15 @@ _aw_set_jira_project() {
   gum style --foreground 2 "✓ JIRA project set to: $project"
 }
 
+_aw_get_github_host() {
+  # Get the GitHub hostname; set auto-worktree.github-host for GitHub Enterprise.
+  # Accepts "ghe.example.com" or "https://ghe.example.com/". Default: github.com
+  local host=$(_aw_get_config "github-host")
+  host="${host#*://}"
+  host="${host%%/*}"
+  echo "${host:-github.com}"
+}
+
 _aw_get_gitlab_server() {
   # Get the configured GitLab server URL
   _aw_get_config "gitlab-server"
@@ -495,16 +615,56 @@ _aw_configure_corporate_wrapper() {
   echo ""
 }
 
+_aw_detect_issue_provider() {
+  # Best-effort guess of the issue provider from the origin remote (else the
+  # first remote): github for the GitHub host, gitlab for gitlab.com, the
+  # configured GitLab server or a host with "gitlab" in its name.
+  # JIRA and Linear can't be told from a remote.
+  # Returns 1 if nothing matches
+  local url=$(git config --get remote.origin.url 2>/dev/null)
+  if [[ -z "$url" ]]; then
+    local first_remote=$(git remote 2>/dev/null | head -n 1)
+    [[ -n "$first_remote" ]] && url=$(git config --get "remote.${first_remote}.url" 2>/dev/null)
+  fi
+  [[ -z "$url" ]] && return 1
+
+  # https://host/..., ssh://git@host:22/... and git@host:owner/repo
+  local host="${url#*://}"
+  host="${host#*@}"
+  host="${host%%[:/]*}"
+  host=$(echo "$host" | tr '[:upper:]' '[:lower:]')
+
+  local gitlab_host=$(_aw_get_gitlab_server)
+  gitlab_host="${gitlab_host#*://}"
+  gitlab_host="${gitlab_host%%/*}"
+
+  if [[ "$host" == "github.com" ]] || [[ "$host" == "$(_aw_get_github_host)" ]]; then
+    echo "github"
+  elif [[ "$host" == "gitlab.com" ]] || [[ "$host" == *gitlab* ]] || \
+    { [[ -n "$gitlab_host" ]] && [[ "$host" == "$gitlab_host" ]]; }; then
+    echo "gitlab"
+  else
+    return 1
+  fi
+}
+
 _aw_init_issue_provider() {
-  # Initialize and return the issue provider, prompting if not configured
+  # Initialize and return the issue provider. Without auto-worktree.issue-provider
+  # the provider is detected from the remote (with a hint, once, to save it),
+  # else the user is prompted.
   # Returns: provider name on stdout; exits non-zero on failure
   local provider
-  provider=$(_aw_get_issue_provider)
-  if [[ -z "$provider" ]]; then
+  provider=$(_aw_get_configured_issue_provider)
+  if [[ -z "$provider" ]] && provider=$(_aw_detect_issue_provider); then
+    if [[ "$(_aw_get_config "issue-provider-detected")" != "$provider" ]]; then
+      gum style --foreground 8 "Using $(_aw_provider_display_name "$provider") issues, detected from the remote. To keep it, run: git config auto-worktree.issue-provider $provider" >&2
+      git config auto-worktree.issue-provider-detected "$provider" 2>/dev/null
+    fi
+  elif [[ -z "$provider" ]]; then
     _aw_prompt_issue_provider || return 1
     provider=$(_aw_get_issue_provider)
   fi
-  _aw_check_issue_provider_deps "$provider" || return 1
+  _aw_require_provider "$provider" || return $?
   echo "$provider"
 }
 
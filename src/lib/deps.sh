@@ -88,3 +88,138 @@ _aw_check_issue_provider_deps() {
 
   return 0
 }
+
+_aw_check_provider_auth() {
+  # Check that the provider CLI is authenticated
+  # Returns 1 and prints how to authenticate if it isn't
+  local provider="$1"
+
+  case "$provider" in
+    "github")
+      local host=$(_aw_get_github_host)
+      if ! gh auth status --hostname "$host" &>/dev/null; then
+        gum style --foreground 1 "Error: GitHub CLI (gh) is not authenticated with $host"
+        if [[ "$host" == "github.com" ]]; then
+          echo "Run: gh auth login"
+        else
+          echo "Run: gh auth login --hostname $host"
+        fi
+        return 1
+      fi
+      ;;
+    "gitlab")
+      if ! glab auth status &>/dev/null; then
+        gum style --foreground 1 "Error: GitLab CLI (glab) is not authenticated"
+        echo "Run: glab auth login"
+        return 1
+      fi
+      ;;
+    "jira")
+      if ! jira me &>/dev/null; then
+        gum style --foreground 1 "Error: JIRA CLI is not configured or cannot reach the server"
+        echo "Run: jira init"
+        return 1
+      fi
+      ;;
+    "linear")
+      # Name the missing variable up front instead of relying on the CLI's
+      # error text, which changes between versions. The CLI can also read a
+      # key from its own config file, so an unset variable only fails when
+      # the CLI can't authenticate either.
+      if [[ -z "${LINEAR_API_KEY:-}" ]]; then
+        if ! linear team list &>/dev/null; then
+          gum style --foreground 1 "Error: LINEAR_API_KEY is not set"
+          echo "Create an API key at https://linear.app/settings/account/security, then:"
+          echo "  export LINEAR_API_KEY=your_key_here"
+          return 1
+        fi
+      elif ! linear team list &>/dev/null; then
+        gum style --foreground 1 "Error: Linear rejected the API key in LINEAR_API_KEY"
+        echo "Check that the key is valid or create a new one at:"
+        echo "  https://linear.app/settings/account/security"
+        return 1
+      fi
+      ;;
+  esac
+
+  return 0
+}
+
+# Oldest release of each provider CLI whose output the providers are known to
+# parse. Older ones still run, with a warning.
+_AW_MIN_CLI_VERSIONS="gh:2.0.0 glab:1.22.0 jira:1.1.0 linear:1.0.0"
+
+_aw_provider_cli() {
+  # Echo the CLI a provider uses
+  case "$1" in
+    github) echo "gh" ;;
+    gitlab) echo "glab" ;;
+    jira) echo "jira" ;;
+    linear) echo "linear" ;;
+  esac
+}
+
+_aw_provider_cli_version() {
+  # Echo the version a provider's CLI reports, e.g. "2.43.0", or nothing if
+  # it can't be read. jira-cli prints it from `jira version`, e.g.
+  # (Version="1.4.0", GitCommit=...); the others from --version.
+  local cli=$(_aw_provider_cli "$1")
+  [[ -z "$cli" ]] && return 1
+  local output
+  if [[ "$cli" == "jira" ]]; then
+    output=$(jira version 2>/dev/null)
+  else
+    output=$("$cli" --version 2>/dev/null)
+  fi
+  echo "$output" | head -n 1 | grep -oE '[0-9]+\.[0-9]+(\.[0-9]+)?' | head -n 1
+}
+
+_aw_version_lt() {
+  # Returns 0 if dotted version A is older than B (missing parts count as 0)
+  # Usage: _aw_version_lt a b
+  local a="$1" b="$2"
+  local i part_a part_b
+  for i in 1 2 3; do
+    part_a=$(echo "$a" | cut -d. -f"$i")
+    part_b=$(echo "$b" | cut -d. -f"$i")
+    part_a=$((10#${part_a:-0}))
+    part_b=$((10#${part_b:-0}))
+    [[ $part_a -lt $part_b ]] && return 0
+    [[ $part_a -gt $part_b ]] && return 1
+  done
+  return 1
+}
+
+_aw_check_provider_cli_version() {
+  # Warn if the provider's CLI is older than its entry in _AW_MIN_CLI_VERSIONS,
+  # since its output may not parse. An unreadable version is not reported.
+  # Returns 1 if the CLI is too old
+  local provider="$1"
+  local cli=$(_aw_provider_cli "$provider")
+  local entry minimum=""
+  for entry in $(echo "$_AW_MIN_CLI_VERSIONS"); do
+    [[ "${entry%%:*}" == "$cli" ]] && minimum="${entry#*:}"
+  done
+  [[ -z "$minimum" ]] && return 0
+
+  local version=$(_aw_provider_cli_version "$provider")
+  [[ -z "$version" ]] && return 0
+
+  if _aw_version_lt "$version" "$minimum"; then
+    gum style --foreground 3 "⚠ $cli $version is older than $minimum; if issue or PR lists fail to parse, upgrade $cli first"
+    return 1
+  fi
+  return 0
+}
+
+_aw_require_provider() {
+  # Ensure the provider's CLI is installed and authenticated before a command
+  # talks to it. Guidance goes to stderr so callers capturing stdout still
+  # show it. A CLI older than we support only gets a warning.
+  # Returns AW_EXIT_PROVIDER on failure
+  local provider="$1"
+
+  _aw_check_issue_provider_deps "$provider" >&2 || return $AW_EXIT_PROVIDER
+  _aw_check_provider_cli_version "$provider" >&2
+  _aw_check_provider_auth "$provider" >&2 || return $AW_EXIT_PROVIDER
+}
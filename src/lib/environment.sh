@@ -27,6 +27,39 @@ _aw_setup_environment() {
     return 1
   fi
 
+  if $strict; then
+    _aw_install_dependencies --strict "$worktree_path"
+  else
+    _aw_install_dependencies "$worktree_path"
+  fi
+}
+
+_aw_install_dependencies_enabled() {
+  # Returns 1 when auto-worktree.install-deps is false (default: true)
+  local worktree_path="$1"
+  local install_deps=$(git -C "$worktree_path" config --bool auto-worktree.install-deps 2>/dev/null)
+  [[ "$install_deps" != "false" ]]
+}
+
+_aw_install_dependencies() {
+  # Install dependencies for every project type detected in the worktree.
+  # Accepts the same optional --strict flag as _aw_setup_environment.
+  local strict=false
+  if [[ "${1:-}" == "--strict" ]]; then
+    strict=true
+    shift
+  fi
+  local worktree_path="$1"
+
+  if [[ ! -d "$worktree_path" ]]; then
+    return 0
+  fi
+
+  if ! _aw_install_dependencies_enabled "$worktree_path"; then
+    gum style --foreground 8 "Skipping dependency installation (auto-worktree.install-deps is false)"
+    return 0
+  fi
+
   local setup_ran=false
 
   # Node.js project
@@ -28,6 +28,10 @@ _aw_find_hook_paths() {
   # 3. Standard .git/hooks directory
   # For worktrees, use --git-common-dir to get the shared hooks directory
   local git_common_dir=$(git -C "$worktree_path" rev-parse --git-common-dir 2>/dev/null)
+  # The main worktree reports a path relative to itself (".git")
+  if [[ -n "$git_common_dir" && "$git_common_dir" != /* ]]; then
+    git_common_dir="$worktree_path/$git_common_dir"
+  fi
   if [[ -n "$git_common_dir" && -d "$git_common_dir/hooks" ]]; then
     hook_paths+=("$git_common_dir/hooks")
   fi
@@ -38,6 +42,47 @@ _aw_find_hook_paths() {
   done
 }
 
+_aw_discover_hooks() {
+  # Find the hooks that will run: for each name, the first executable file
+  # across the hook directories, as _aw_run_git_hooks picks them
+  # Usage: _aw_discover_hooks "dir<newline>dir..." hook_name...
+  # Prints "name<TAB>path" per hook found, in order
+  local hook_dirs="$1"
+  shift
+
+  local hook_name hook_dir
+  for hook_name in "$@"; do
+    while IFS= read -r hook_dir; do
+      [[ -z "$hook_dir" ]] && continue
+      if [[ -f "$hook_dir/$hook_name" ]] && [[ -x "$hook_dir/$hook_name" ]]; then
+        printf '%s\t%s\n' "$hook_name" "$hook_dir/$hook_name"
+        break
+      fi
+    done <<< "$hook_dirs"
+  done
+}
+
+_aw_hook_extra_path() {
+  # Directories appended to PATH for hooks: auto-worktree.hook-path if set,
+  # otherwise the usual tool locations for this OS
+  local worktree_path="$1"
+  local configured=$(git -C "$worktree_path" config auto-worktree.hook-path 2>/dev/null)
+  if [[ -n "$configured" ]]; then
+    echo "$configured"
+    return 0
+  fi
+
+  case "$(uname -s 2>/dev/null)" in
+    Darwin)
+      # Homebrew lives in /opt/homebrew on Apple Silicon, /usr/local on Intel
+      echo "/opt/homebrew/bin:/opt/homebrew/sbin:/usr/local/bin:/usr/bin:/bin:/usr/sbin:/sbin"
+      ;;
+    *)
+      echo "/usr/local/bin:/usr/bin:/bin:/usr/sbin:/sbin"
+      ;;
+  esac
+}
+
 _aw_execute_hook() {
   # Execute a single git hook if it exists and is executable
   # Returns 0 on success, 1 on failure, 2 if hook doesn't exist
@@ -67,12 +112,10 @@ _aw_execute_hook() {
   # Set up PATH for hook execution
   # Git hooks run with minimal environment, so we need to ensure they have access to:
   # 1. User's current PATH (includes user-installed tools like gum, homebrew packages, etc.)
-  # 2. Standard system directories (fallback for basic commands)
-  # 3. Common package manager directories (Homebrew on macOS, etc.)
+  # 2. auto-worktree.hook-path, or standard system and package manager
+  #    directories for this OS (fallback for node, python, etc.)
   local hook_path_env="$PATH"
-
-  # Add common directories if not already in PATH (for robustness)
-  local additional_paths="/opt/homebrew/bin:/opt/homebrew/sbin:/usr/local/bin:/usr/bin:/bin:/usr/sbin:/sbin"
+  local additional_paths=$(_aw_hook_extra_path "$worktree_path")
   hook_path_env="$hook_path_env:$additional_paths"
 
   # Run hook with output displayed directly to user
@@ -89,8 +132,12 @@ _aw_run_git_hooks() {
   # Executes hooks in order: post-checkout, post-clone, post-worktree, custom hooks
   local worktree_path="$1"
 
-  # Check if hook execution is enabled (default: true)
-  local run_hooks=$(git -C "$worktree_path" config --bool auto-worktree.run-hooks 2>/dev/null)
+  # Check if hook execution is enabled (default: true). A --hooks/--no-hooks
+  # flag on new/issue overrides the config for that invocation.
+  local run_hooks="${_AW_HOOKS_OVERRIDE:-}"
+  if [[ -z "$run_hooks" ]]; then
+    run_hooks=$(git -C "$worktree_path" config --bool auto-worktree.run-hooks 2>/dev/null)
+  fi
   if [[ "$run_hooks" == "false" ]]; then
     return 0
   fi
@@ -109,8 +156,10 @@ _aw_run_git_hooks() {
 
   if [[ ${#hook_paths[@]} -eq 0 ]]; then
     # No hook directories found, skip silently
+    _aw_log debug "no hook directories in $worktree_path"
     return 0
   fi
+  _aw_log debug "hook directories: ${hook_paths[*]}"
 
   # Define hooks to run in order
   # Note: post-checkout is already run by git automatically during worktree creation
@@ -124,6 +173,18 @@ _aw_run_git_hooks() {
     hooks_to_run+=("${custom_array[@]}")
   fi
 
+  # List the hooks before running any, so a slow install in post-worktree
+  # doesn't come as a surprise
+  local discovered=$(_aw_discover_hooks "$(printf '%s\n' "${hook_paths[@]}")" "${hooks_to_run[@]}")
+  if [[ -n "$discovered" ]]; then
+    echo ""
+    gum style --foreground 6 "Hooks to run:"
+    local found_name found_path
+    while IFS=$'\t' read -r found_name found_path; do
+      echo "  $found_name  $found_path"
+    done <<< "$discovered"
+  fi
+
   local any_hook_ran=false
   local any_hook_failed=false
   local failed_hooks=()
@@ -136,8 +197,14 @@ _aw_run_git_hooks() {
     for hook_dir in "${hook_paths[@]}"; do
       local hook_path="$hook_dir/$hook_name"
 
+      # Hook durations show up under --verbose, to explain slow creation
+      local hook_start=""
+      [[ "${_AW_VERBOSE:-false}" == "true" ]] && hook_start=$(_aw_now_ms)
       _aw_execute_hook "$hook_path" "$worktree_path"
       local result=$?
+      if [[ $result -ne 2 ]] && [[ -n "$hook_start" ]]; then
+        _aw_log debug "hook $hook_name ($hook_path) → exit $result in $(($(_aw_now_ms) - hook_start))ms"
+      fi
 
       if [[ $result -eq 0 ]]; then
         # Hook succeeded
@@ -0,0 +1,83 @@
+#!/bin/bash
+
+# ============================================================================
+# Per-branch worktree metadata
+# ============================================================================
+#
+# Metadata is stored in the repository's git config under the branch section
+# (branch.<name>.aw-<key>), so it travels with the branch and is removed
+# automatically when the branch is deleted.
+
+_aw_get_branch_metadata() {
+  # Usage: _aw_get_branch_metadata <branch> <key>
+  local branch="$1"
+  local key="$2"
+  [[ -z "$branch" || -z "$key" ]] && return 1
+  git config --get "branch.${branch}.aw-${key}" 2>/dev/null
+}
+
+_aw_set_branch_metadata() {
+  # Usage: _aw_set_branch_metadata <branch> <key> <value>
+  # An empty value removes the key.
+  local branch="$1"
+  local key="$2"
+  local value="$3"
+  [[ -z "$branch" || -z "$key" ]] && return 1
+
+  if [[ -z "$value" ]]; then
+    git config --unset "branch.${branch}.aw-${key}" 2>/dev/null || true
+    return 0
+  fi
+  git config "branch.${branch}.aw-${key}" "$value"
+}
+
+_aw_record_issue_metadata() {
+  # Record the issue a worktree's branch was created from
+  # Usage: _aw_record_issue_metadata <branch> <provider> <issue_id> <title> [url]
+  local branch="$1"
+  local provider="$2"
+  local issue_id="$3"
+  local title="$4"
+  local url="${5:-}"
+
+  _aw_set_branch_metadata "$branch" "provider" "$provider" || return 1
+  _aw_set_branch_metadata "$branch" "issue-id" "$issue_id"
+  _aw_set_branch_metadata "$branch" "issue-title" "$title"
+  _aw_set_branch_metadata "$branch" "issue-url" "$url"
+}
+
+_aw_touch_last_accessed() {
+  # Record that a branch's worktree was just opened, and count the opening
+  # Usage: _aw_touch_last_accessed <branch>
+  _aw_set_branch_metadata "$1" "last-accessed" "$(date +%s)" 2>/dev/null || true
+  local count=$(_aw_get_access_count "$1")
+  _aw_set_branch_metadata "$1" "access-count" "$((count + 1))" 2>/dev/null || true
+}
+
+_aw_get_access_count() {
+  # Echo how many times a branch's worktree was opened (0 if never recorded)
+  # Usage: _aw_get_access_count <branch>
+  local count=$(_aw_get_branch_metadata "$1" "access-count")
+  [[ "$count" =~ ^[0-9]+$ ]] || count=0
+  echo "$count"
+}
+
+_aw_format_issue_metadata() {
+  # Echo a one-line description of the branch's source issue, e.g.
+  # "Linear ENG-12: Fix login bug", or nothing if no issue was recorded
+  local branch="$1"
+  local issue_id
+  issue_id=$(_aw_get_branch_metadata "$branch" "issue-id")
+  [[ -z "$issue_id" ]] && return 0
+
+  local provider=$(_aw_get_branch_metadata "$branch" "provider")
+  local title=$(_aw_get_branch_metadata "$branch" "issue-title")
+  local line="$(_aw_provider_display_name "$provider") $(_aw_format_issue_ref "$issue_id" "$provider")"
+  [[ -n "$title" ]] && line="${line}: ${title}"
+  echo "$line"
+}
+
+_aw_is_kept_alive() {
+  # True if `sessions keep` marked the branch's worktree to never count as stale
+  [[ "$(_aw_get_branch_metadata "$1" "keep-alive")" == "true" ]]
+}
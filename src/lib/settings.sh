@@ -299,6 +299,106 @@ _aw_settings_autoselect() {
   done
 }
 
+_aw_settings_edit() {
+  # Browse every setting by category and set or unset it in the local or
+  # global git config
+  while true; do
+    local categories=()
+    local category
+    for category in "${_AW_SETTING_CATEGORIES[@]}"; do
+      categories+=("${category%%:*}")
+    done
+
+    local choice=$(gum choose --header "Edit settings: pick a category" "${categories[@]}" "Back")
+    case "$choice" in
+      "") return $AW_EXIT_CANCELLED ;;
+      "Back") return 0 ;;
+    esac
+
+    _aw_settings_edit_category "$choice"
+  done
+}
+
+_aw_settings_edit_category() {
+  # Pick a key of one category, showing its effective value
+  # Usage: _aw_settings_edit_category category
+  local name="$1"
+  local category keys=""
+  for category in "${_AW_SETTING_CATEGORIES[@]}"; do
+    [[ "${category%%:*}" == "$name" ]] && keys="${category#*:}"
+  done
+
+  while true; do
+    local entries=()
+    local key
+    for key in $(echo "$keys"); do
+      entries+=("$key = $(git config --get "auto-worktree.$key" 2>/dev/null || echo "(unset)")")
+    done
+
+    local choice=$(gum choose --header "Edit $name settings" "${entries[@]}" "Back")
+    case "$choice" in
+      "") return $AW_EXIT_CANCELLED ;;
+      "Back") return 0 ;;
+    esac
+
+    _aw_settings_edit_key "${choice%% = *}"
+  done
+}
+
+_aw_settings_edit_key() {
+  # Set or unset one key, locally or globally. Keys with a fixed set of values
+  # (see _aw_setting_choices) get a picker, others a text input.
+  # Usage: _aw_settings_edit_key key
+  local key="$1"
+  local local_value=$(git config --local --get "auto-worktree.$key" 2>/dev/null)
+  local global_value=$(git config --global --get "auto-worktree.$key" 2>/dev/null)
+
+  local action=$(gum choose \
+    --header "auto-worktree.$key (local: ${local_value:-unset}, global: ${global_value:-unset})" \
+    "Set for this repository" \
+    "Set globally" \
+    "Unset for this repository" \
+    "Unset globally" \
+    "Back")
+
+  local scope
+  case "$action" in
+    "Set for this repository"|"Unset for this repository") scope="--local" ;;
+    "Set globally"|"Unset globally") scope="--global" ;;
+    "") return $AW_EXIT_CANCELLED ;;
+    *) return 0 ;;
+  esac
+
+  if [[ "$action" == Unset* ]]; then
+    git config "$scope" --unset "auto-worktree.$key" 2>/dev/null
+    gum style --foreground 2 "✓ auto-worktree.$key unset in ${scope#--} git config"
+    return 0
+  fi
+
+  local current_value="$local_value"
+  [[ "$scope" == "--global" ]] && current_value="$global_value"
+
+  local value
+  local choices=$(_aw_setting_choices "$key")
+  if [[ -n "$choices" ]]; then
+    value=$(echo "$choices" | gum choose --header "auto-worktree.$key (current: ${current_value:-unset})")
+  else
+    gum style --foreground 6 "auto-worktree.$key:"
+    value=$(gum input --placeholder "value" --value "$current_value")
+  fi
+
+  if [[ -z "$value" ]]; then
+    gum style --foreground 3 "Cancelled"
+    return 0
+  fi
+
+  if ! git config "$scope" "auto-worktree.$key" "$value"; then
+    gum style --foreground 1 "Error: Failed to save setting '$key'"
+    return 1
+  fi
+  gum style --foreground 2 "✓ auto-worktree.$key set to '$value' in ${scope#--} git config"
+}
+
 _aw_settings_reset() {
   if ! gum confirm "Reset all auto-worktree settings for this repository?"; then
     gum style --foreground 3 "Cancelled"
@@ -314,7 +414,7 @@ _aw_settings_reset() {
 }
 
 _aw_settings_menu() {
-  _aw_ensure_git_repo || return 1
+  _aw_ensure_git_repo || return $?
   _aw_get_repo_info
 
   while true; do
@@ -326,6 +426,7 @@ _aw_settings_menu() {
       "Issue provider settings" \
       "AI tool preference" \
       "Auto-select settings" \
+      "Edit any setting" \
       "Reset settings" \
       "Back")
 
@@ -333,6 +434,7 @@ _aw_settings_menu() {
       "Issue provider settings") _aw_settings_issue_provider ;;
       "AI tool preference") _aw_settings_ai_tool ;;
       "Auto-select settings") _aw_settings_autoselect ;;
+      "Edit any setting") _aw_settings_edit ;;
       "Reset settings") _aw_settings_reset ;;
       *)
         [[ -z "$choice" ]] && return $AW_EXIT_CANCELLED
@@ -342,6 +444,146 @@ _aw_settings_menu() {
   done
 }
 
+_aw_settings_export() {
+  # Print auto-worktree settings as a JSON object grouped by the categories in
+  # _AW_SETTING_CATEGORIES. Only keys that are set appear. Values are the
+  # effective ones (local over global) unless --local or --global picks a scope.
+  local scope_args=()
+
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --local|--global)
+        scope_args=("$1")
+        shift
+        ;;
+      *)
+        gum style --foreground 1 "Unknown option: $1" >&2
+        return $AW_EXIT_USAGE
+        ;;
+    esac
+  done
+
+  if [[ "${scope_args[*]}" != "--global" ]]; then
+    _aw_ensure_git_repo || return $?
+  fi
+
+  local json="{}"
+  local category key value
+  for category in "${_AW_SETTING_CATEGORIES[@]}"; do
+    local name="${category%%:*}"
+    json=$(jq --arg c "$name" '.[$c] = {}' <<< "$json") || return 1
+    for key in $(echo "${category#*:}"); do
+      value=$(git config "${scope_args[@]}" --get "auto-worktree.$key" 2>/dev/null) || continue
+      json=$(jq --arg c "$name" --arg k "$key" --arg v "$value" '.[$c][$k] = $v' <<< "$json") || return 1
+    done
+  done
+
+  echo "$json"
+}
+
+_aw_settings_import() {
+  # Write settings from a JSON file in the `settings export` format to git
+  # config (--local, the default, or --global). Unknown keys are refused and
+  # nothing is written unless the whole file is valid.
+  local file=""
+  local scope="--local"
+
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --local|--global)
+        scope="$1"
+        shift
+        ;;
+      -*)
+        gum style --foreground 1 "Unknown option: $1"
+        return $AW_EXIT_USAGE
+        ;;
+      *)
+        file="$1"
+        shift
+        ;;
+    esac
+  done
+
+  if [[ -z "$file" ]]; then
+    gum style --foreground 1 "Usage: auto-worktree settings import <file.json> [--local|--global]"
+    return $AW_EXIT_USAGE
+  fi
+
+  if [[ ! -r "$file" ]]; then
+    gum style --foreground 1 "Error: Cannot read settings file: $file"
+    return 1
+  fi
+
+  if [[ "$scope" == "--local" ]]; then
+    _aw_ensure_git_repo || return $?
+  fi
+
+  # One "category<TAB>key" line per setting; values are read separately so
+  # they don't need unescaping
+  local entries
+  if ! entries=$(jq -r '
+      if type != "object" then error("expected a JSON object") else . end
+      | to_entries[]
+      | .key as $category
+      | if (.value | type) != "object" then error("category \($category) is not an object") else . end
+      | .value | to_entries[]
+      | if (.value | type) == "object" or (.value | type) == "array" or .value == null
+        then error("\($category).\(.key) is not a string, number or boolean") else . end
+      | [$category, .key] | @tsv' "$file" 2>&1); then
+    gum style --foreground 1 "Error: Invalid settings file $file: ${entries##*: }"
+    return 1
+  fi
+
+  local category key
+  local unknown=()
+  while IFS=$'\t' read -r category key; do
+    [[ -z "$key" ]] && continue
+    _aw_is_known_setting "$key" || unknown+=("$key")
+  done <<< "$entries"
+
+  if [[ ${#unknown[@]} -gt 0 ]]; then
+    gum style --foreground 1 "Error: Unknown setting(s) in $file: ${unknown[*]}"
+    echo "Nothing was imported. Known settings are listed by: auto-worktree settings export"
+    return 1
+  fi
+
+  local count=0
+  while IFS=$'\t' read -r category key; do
+    [[ -z "$key" ]] && continue
+    local value
+    value=$(jq -r --arg c "$category" --arg k "$key" '.[$c][$k] | tostring' "$file")
+    if ! git config "$scope" "auto-worktree.$key" "$value"; then
+      gum style --foreground 1 "Error: Failed to save setting '$key'"
+      return 1
+    fi
+    count=$((count + 1))
+  done <<< "$entries"
+
+  _aw_is_quiet || gum style --foreground 2 "✓ Imported $count setting(s) into ${scope#--} git config"
+}
+
+_aw_settings() {
+  # Entry point for `auto-worktree settings [subcommand]`
+  case "${1:-}" in
+    "")
+      _aw_settings_menu
+      ;;
+    export|--json)
+      shift
+      _aw_settings_export "$@"
+      ;;
+    import)
+      shift
+      _aw_settings_import "$@"
+      ;;
+    *)
+      gum style --foreground 1 "Usage: auto-worktree settings [export|--json|import <file>] [--local|--global]"
+      return $AW_EXIT_USAGE
+      ;;
+  esac
+}
+
 _aw_prompt_issue_provider() {
   # Prompt user to choose issue provider if not configured
   echo ""
@@ -4,6 +4,14 @@
 # Helper functions
 # ============================================================================
 
+# Exit codes, so wrapper scripts can tell error categories apart.
+# Anything not covered below returns 1.
+readonly AW_EXIT_USAGE=2          # Unknown command/option or malformed argument
+readonly AW_EXIT_NOT_GIT_REPO=3   # Not inside a git repository
+readonly AW_EXIT_PROVIDER=4       # Issue provider missing, unauthenticated or failing
+readonly AW_EXIT_EXISTS=5         # Branch/worktree already exists
+readonly AW_EXIT_LOCKED=6         # Another auto-worktree instance holds the repository lock
+
 # Exit code for user cancellation (e.g. Ctrl+C or gum prompt dismissed)
 readonly AW_EXIT_CANCELLED=130
 
@@ -13,10 +21,149 @@ AI_CMD=()
 AI_CMD_NAME=""
 AI_RESUME_CMD=()
 
+_aw_is_quiet() {
+  # Returns 0 when --quiet was passed: decorative output (boxes, progress and
+  # success messages) should be skipped, leaving only machine-useful output
+  [[ "${_AW_QUIET:-false}" == "true" ]]
+}
+
+_aw_quietable() {
+  # Run a command, discarding its stdout in quiet mode
+  if _aw_is_quiet; then
+    "$@" >/dev/null
+  else
+    "$@"
+  fi
+}
+
+_aw_events_enabled() {
+  # Returns 0 when --events was passed: worktree creation reports each phase
+  # as an NDJSON line on stdout (implies --quiet)
+  [[ "${_AW_EVENTS:-false}" == "true" ]]
+}
+
+# ----------------------------------------------------------------------------
+# Verbose logging (--verbose / -V)
+# ----------------------------------------------------------------------------
+
+# Commands traced while --verbose is on. gum is only logged for `gum spin`,
+# which runs git worktree add and friends.
+_AW_TRACED_TOOLS="git gh glab jira linear gum"
+
+_aw_log_level_rank() {
+  case "$1" in
+    debug) echo 0 ;;
+    info)  echo 1 ;;
+    warn)  echo 2 ;;
+    error) echo 3 ;;
+    *)     echo 0 ;;
+  esac
+}
+
+_aw_log() {
+  # Log a message to stderr when --verbose was passed and level is at least
+  # AW_LOG_LEVEL (debug, info, warn or error; default debug). Goes to the
+  # stderr auto-worktree started with, so callers' 2>/dev/null don't hide it.
+  # Usage: _aw_log level message
+  [[ "${_AW_VERBOSE:-false}" == "true" ]] || return 0
+  local level="$1"
+  shift
+  [[ $(_aw_log_level_rank "$level") -ge $(_aw_log_level_rank "${AW_LOG_LEVEL:-debug}") ]] || return 0
+
+  local line
+  line="[aw $(date +%H:%M:%S)] $(echo "$level" | tr '[:lower:]' '[:upper:]') $*"
+  if [[ -n "${_AW_LOG_FD:-}" ]]; then
+    echo "$line" >&"$_AW_LOG_FD"
+  else
+    echo "$line" >&2
+  fi
+}
+
+_aw_traced() {
+  # Stand-in for a traced tool: run it and log its arguments, duration and
+  # exit status. Outside a verbose run (say, left behind by Ctrl+C) it
+  # removes itself and runs the tool untouched.
+  # Usage: _aw_traced tool [args...]
+  local tool="$1"
+  shift
+  if [[ "${_AW_VERBOSE:-false}" != "true" ]]; then
+    unset -f "$tool"
+    command "$tool" "$@"
+    return $?
+  fi
+  if [[ "$tool" == "gum" ]] && [[ "${1:-}" != "spin" ]]; then
+    command gum "$@"
+    return $?
+  fi
+
+  local start=$(_aw_now_ms)
+  local exit_code=0
+  command "$tool" "$@" || exit_code=$?
+  local elapsed=$(($(_aw_now_ms) - start))
+
+  local level=debug
+  [[ $exit_code -ne 0 ]] && level=warn
+  _aw_log "$level" "$tool $(printf '%q ' "$@")→ exit $exit_code in ${elapsed}ms"
+  return $exit_code
+}
+
+_aw_trace_start() {
+  # Route the traced tools through _aw_traced for this run, keeping a copy of
+  # stderr in _AW_LOG_FD. Tools that are already shell functions are left
+  # alone. Sets _AW_TRACE_INSTALLED.
+  exec {_AW_LOG_FD}>&2
+  _AW_TRACE_INSTALLED=""
+  local tool
+  for tool in $(echo "$_AW_TRACED_TOOLS"); do
+    typeset -f "$tool" >/dev/null 2>&1 && continue
+    eval "$tool() { _aw_traced $tool \"\$@\"; }"
+    _AW_TRACE_INSTALLED+="$tool "
+  done
+}
+
+_aw_trace_stop() {
+  # Undo _aw_trace_start
+  local tool
+  for tool in $(echo "${_AW_TRACE_INSTALLED:-}"); do
+    unset -f "$tool"
+  done
+  _AW_TRACE_INSTALLED=""
+  if [[ -n "${_AW_LOG_FD:-}" ]]; then
+    exec {_AW_LOG_FD}>&-
+    _AW_LOG_FD=""
+  fi
+}
+
+_aw_json_escape() {
+  # Escape a string for use inside a JSON string literal
+  printf '%s' "$1" | sed -e 's/\\/\\\\/g' -e 's/"/\\"/g' |
+    awk 'NR > 1 { printf "\\n" } { gsub(/\t/, "\\t"); gsub(/\r/, "\\r"); printf "%s", $0 }'
+}
+
+_aw_emit_event() {
+  # Print a progress event when --events is active, e.g.
+  #   {"phase":"creating-worktree","status":"done"}
+  # Usage: _aw_emit_event phase status [error] [path]
+  _aw_events_enabled || return 0
+  local phase="$1"
+  local event_status="$2"
+  local error="${3:-}"
+  local event_path="${4:-}"
+
+  local line="{\"phase\":\"$phase\",\"status\":\"$event_status\""
+  if [[ -n "$error" ]]; then
+    line="$line,\"error\":\"$(_aw_json_escape "$error")\""
+  fi
+  if [[ -n "$event_path" ]]; then
+    line="$line,\"path\":\"$(_aw_json_escape "$event_path")\""
+  fi
+  echo "$line}"
+}
+
 _aw_ensure_git_repo() {
   if ! git rev-parse --git-dir > /dev/null 2>&1; then
     gum style --foreground 1 "Error: Not in a git repository"
-    return 1
+    return $AW_EXIT_NOT_GIT_REPO
   fi
   return 0
 }
@@ -24,15 +171,218 @@ _aw_ensure_git_repo() {
 _aw_get_repo_info() {
   _AW_GIT_ROOT=$(git rev-parse --show-toplevel)
   _AW_SOURCE_FOLDER=$(basename "$_AW_GIT_ROOT")
-  _AW_WORKTREE_BASE="$HOME/worktrees/$_AW_SOURCE_FOLDER"
+  # Each command looks the default branch up afresh (_aw_cache_default_branch)
+  _AW_DEFAULT_BRANCH_CACHE=""
+
+  # Directory holding each repository's worktrees. Precedence:
+  # AW_WORKTREE_BASE env var > auto-worktree.worktree-base (local, then
+  # global git config) > ~/worktrees
+  local base_root="${AW_WORKTREE_BASE:-$(git config --get auto-worktree.worktree-base 2>/dev/null)}"
+  base_root="${base_root/#\~/$HOME}"
+  [[ -z "$base_root" ]] && base_root="$HOME/worktrees"
+  _AW_WORKTREE_BASE="${base_root%/}/$_AW_SOURCE_FOLDER"
+
+  _aw_warn_worktree_base_overlap
+}
+
+_aw_physical_path() {
+  # Resolve symlinks in a path that may not exist yet, through its nearest
+  # existing ancestor
+  local target="$1"
+  local suffix=""
+  while [[ ! -d "$target" ]] && [[ "$target" == */* ]]; do
+    suffix="/$(basename "$target")$suffix"
+    target=$(dirname "$target")
+  done
+  local resolved=$(cd "$target" 2>/dev/null && pwd -P)
+  echo "${resolved%/}$suffix"
+}
+
+_aw_worktree_base_overlap() {
+  # Echo "inside" if the worktree base is the main checkout or inside it,
+  # "contains" if the main checkout is inside the worktree base, or nothing
+  # Usage: _aw_worktree_base_overlap main_root worktree_base
+  local main_root=$(_aw_physical_path "$1")
+  local base=$(_aw_physical_path "$2")
+  [[ -z "$main_root" || -z "$base" ]] && return 0
+
+  if [[ "$base" == "$main_root" || "$base" == "$main_root"/* ]]; then
+    echo "inside"
+  elif [[ "$main_root" == "$base"/* ]]; then
+    echo "contains"
+  fi
+}
+
+_aw_main_worktree_root() {
+  # Path of the repository's main checkout, or nothing for bare repositories
+  local common_dir
+  common_dir=$(_aw_git_common_dir) || return 1
+  [[ "$(basename "$common_dir")" == ".git" ]] || return 1
+  dirname "$common_dir"
+}
+
+_aw_warn_worktree_base_overlap() {
+  # A worktree base inside the checkout nests every worktree in it, so
+  # searches and size scans of the checkout would also walk all worktrees
+  local main_root
+  main_root=$(_aw_main_worktree_root) || return 0
+
+  case "$(_aw_worktree_base_overlap "$main_root" "$_AW_WORKTREE_BASE")" in
+    inside)
+      gum style --foreground 3 "Warning: worktree base $_AW_WORKTREE_BASE is inside the repository $main_root" >&2
+      echo "  Worktrees would be nested in the checkout. Point auto-worktree.worktree-base (or AW_WORKTREE_BASE) outside it." >&2
+      ;;
+    contains)
+      gum style --foreground 3 "Warning: the repository $main_root is inside its worktree base $_AW_WORKTREE_BASE" >&2
+      echo "  Point auto-worktree.worktree-base (or AW_WORKTREE_BASE) somewhere that doesn't contain the repository." >&2
+      ;;
+  esac
+  return 0
+}
+
+# ============================================================================
+# Repository registry
+# ============================================================================
+#
+# Every repository auto-worktree runs in is remembered (by its main worktree
+# path) in a file under the user's config dir, so `list --all-repos` can show
+# worktrees across all of them.
+
+_aw_registry_file() {
+  echo "${XDG_CONFIG_HOME:-$HOME/.config}/auto-worktree/repos"
+}
+
+_aw_register_repo() {
+  # Add the current repository to the registry if it isn't there yet.
+  # Failures are ignored; the registry is only a convenience.
+  local common_dir
+  common_dir=$(_aw_git_common_dir) || return 0
+  # Bare repositories have no main worktree to list
+  [[ "$(basename "$common_dir")" == ".git" ]] || return 0
+  local repo_root=$(dirname "$common_dir")
+
+  local registry=$(_aw_registry_file)
+  if [[ -f "$registry" ]] && grep -qxF "$repo_root" "$registry"; then
+    return 0
+  fi
+  mkdir -p "$(dirname "$registry")" 2>/dev/null && echo "$repo_root" >> "$registry" 2>/dev/null
+  return 0
+}
+
+_aw_registered_repos() {
+  # Echo the registered repository roots that still exist, one per line
+  local registry=$(_aw_registry_file)
+  [[ -f "$registry" ]] || return 0
+  local repo_root
+  while IFS= read -r repo_root; do
+    [[ -n "$repo_root" ]] && [[ -d "$repo_root/.git" ]] && echo "$repo_root"
+  done < "$registry"
+}
+
+# ============================================================================
+# Repository lock
+# ============================================================================
+#
+# An advisory lock (a directory, since mkdir is atomic and flock isn't
+# available on macOS) in the repository's common git dir, so every worktree
+# of a repository shares it. Held around worktree create/remove/prune to keep
+# parallel auto-worktree runs from racing on git's metadata.
+
+# Lock nesting depth, so helpers that lock can call each other
+_AW_LOCK_DEPTH=0
+_AW_LOCK_DIR=""
+
+_aw_git_common_dir() {
+  # Absolute path of the git dir shared by every worktree of the repository
+  local common_dir
+  common_dir=$(git rev-parse --git-common-dir 2>/dev/null) || return 1
+  (cd "$common_dir" && pwd -P)
+}
+
+_aw_lock_path() {
+  local common_dir
+  common_dir=$(_aw_git_common_dir) || return 1
+  echo "$common_dir/auto-worktree.lock"
+}
+
+_aw_acquire_lock() {
+  # Take the repository lock, waiting up to timeout_secs for another instance
+  # to release it (default: AW_LOCK_TIMEOUT or 30). Locks left behind by a
+  # process that no longer exists are reclaimed.
+  # Usage: _aw_acquire_lock [timeout_secs]
+  # Returns AW_EXIT_LOCKED if the lock couldn't be taken in time
+  local timeout_secs="${1:-${AW_LOCK_TIMEOUT:-30}}"
+
+  if [[ $_AW_LOCK_DEPTH -gt 0 ]]; then
+    _AW_LOCK_DEPTH=$((_AW_LOCK_DEPTH + 1))
+    return 0
+  fi
+
+  local lock_dir
+  lock_dir=$(_aw_lock_path) || return 1
+
+  # $$ is shared by subshells and background jobs, so record the PID of the
+  # process actually taking the lock
+  local owner_pid="$$"
+  if [[ -n "$ZSH_VERSION" ]]; then
+    zmodload -F zsh/system p:sysparams 2>/dev/null && owner_pid="${sysparams[pid]:-$$}"
+  else
+    owner_pid="${BASHPID:-$$}"
+  fi
+
+  local waited=0
+  while ! mkdir "$lock_dir" 2>/dev/null; do
+    local holder=$(cat "$lock_dir/pid" 2>/dev/null)
+    # Left behind by a process that no longer exists
+    if [[ -n "$holder" ]] && ! kill -0 "$holder" 2>/dev/null; then
+      rm -rf "$lock_dir"
+      continue
+    fi
+
+    if [[ $waited -ge $timeout_secs ]]; then
+      gum style --foreground 1 "Error: Another auto-worktree process${holder:+ (PID $holder)} is modifying this repository" >&2
+      echo "If no other instance is running, remove the stale lock: rm -rf \"$lock_dir\"" >&2
+      return $AW_EXIT_LOCKED
+    fi
+
+    sleep 1
+    waited=$((waited + 1))
+  done
+
+  echo "$owner_pid" > "$lock_dir/pid"
+  _AW_LOCK_DIR="$lock_dir"
+  _AW_LOCK_DEPTH=1
+}
+
+_aw_release_lock() {
+  [[ $_AW_LOCK_DEPTH -gt 0 ]] || return 0
+  _AW_LOCK_DEPTH=$((_AW_LOCK_DEPTH - 1))
+  if [[ $_AW_LOCK_DEPTH -eq 0 ]] && [[ -n "$_AW_LOCK_DIR" ]]; then
+    rm -rf "$_AW_LOCK_DIR"
+    _AW_LOCK_DIR=""
+  fi
+}
+
+_aw_with_lock() {
+  # Run a command while holding the repository lock
+  # Usage: _aw_with_lock command [args...]
+  _aw_acquire_lock || return $?
+  "$@"
+  local cmd_status=$?
+  _aw_release_lock
+  return $cmd_status
 }
 
 _aw_prune_worktrees() {
   local count_before=$(git worktree list --porcelain 2>/dev/null | grep -c "^worktree " || echo 0)
-  git worktree prune 2>/dev/null
+  # Pruning is housekeeping; skip it rather than wait if another instance is busy
+  if _aw_acquire_lock 0 2>/dev/null; then
+    git worktree prune 2>/dev/null
+    _aw_release_lock
+  fi
   local count_after=$(git worktree list --porcelain 2>/dev/null | grep -c "^worktree " || echo 0)
   local pruned=$((count_before - count_after))
-  if [[ $pruned -gt 0 ]]; then
+  if [[ $pruned -gt 0 ]] && ! _aw_is_quiet; then
     gum style --foreground 3 "Pruned $pruned orphaned worktree(s)"
     echo ""
   fi
@@ -54,6 +404,169 @@ _aw_sanitize_branch_name() {
   echo "$1" | tr '[:upper:]' '[:lower:]' | sed 's/[^a-z0-9]/-/g' | sed -E 's/-+/-/g' | sed 's/^-//;s/-$//'
 }
 
+_aw_get_dir_size_kb() {
+  # Echo the disk usage of a directory in kilobytes without following
+  # symlinks. Gives up after timeout_secs (when timeout/gtimeout is available).
+  # A directory nested inside it (the worktree base, when it lives in the
+  # checkout) can be left out of the total.
+  # Usage: _aw_get_dir_size_kb dir [timeout_secs] [exclude_dir]
+  # Returns 1 if the size could not be computed in time
+  local dir="$1"
+  local timeout_secs="${2:-30}"
+  local exclude_dir="${3:-}"
+
+  local timeout_cmd=()
+  if command -v timeout &>/dev/null; then
+    timeout_cmd=(timeout "$timeout_secs")
+  elif command -v gtimeout &>/dev/null; then
+    timeout_cmd=(gtimeout "$timeout_secs")
+  fi
+
+  local size_kb
+  size_kb=$("${timeout_cmd[@]}" du -skP "$dir" 2>/dev/null | awk '{print $1}')
+  [[ "$size_kb" =~ ^[0-9]+$ ]] || return 1
+
+  if [[ -n "$exclude_dir" ]] && [[ -d "$exclude_dir" ]] \
+    && [[ "$(_aw_worktree_base_overlap "$dir" "$exclude_dir")" == "inside" ]]; then
+    local excluded_kb
+    excluded_kb=$("${timeout_cmd[@]}" du -skP "$exclude_dir" 2>/dev/null | awk '{print $1}')
+    [[ "$excluded_kb" =~ ^[0-9]+$ ]] && size_kb=$((size_kb - excluded_kb))
+  fi
+  echo "$size_kb"
+}
+
+_aw_format_size() {
+  # Format a size in kilobytes as a human-readable string (e.g. 512K, 1.2G)
+  awk -v kb="$1" 'BEGIN {
+    if (kb >= 1048576) printf "%.1fG\n", kb / 1048576
+    else if (kb >= 1024) printf "%.1fM\n", kb / 1024
+    else printf "%dK\n", kb
+  }'
+}
+
+_aw_parse_duration() {
+  # Echo a duration such as 30m, 24h, 7d, 2w or 1h30m in seconds
+  # Returns 1 for anything else (a unit is required)
+  local rest="$1"
+  local total=0
+  [[ -z "$rest" ]] && return 1
+  # Parsed without capture groups: zsh only fills BASH_REMATCH with
+  # setopt bash_rematch
+  while [[ -n "$rest" ]]; do
+    [[ "$rest" =~ ^[0-9]+[smhdw] ]] || return 1
+    local amount="${rest%%[!0-9]*}"
+    rest="${rest#"$amount"}"
+    local unit="${rest:0:1}"
+    rest="${rest:1}"
+    case "$unit" in
+      s) total=$((total + 10#$amount)) ;;
+      m) total=$((total + 10#$amount * 60)) ;;
+      h) total=$((total + 10#$amount * 3600)) ;;
+      d) total=$((total + 10#$amount * 86400)) ;;
+      w) total=$((total + 10#$amount * 604800)) ;;
+    esac
+  done
+  echo "$total"
+}
+
+_aw_now_ms() {
+  # Echo the current time in milliseconds: $EPOCHREALTIME (bash 5, zsh with
+  # zsh/datetime), then GNU date, then whole seconds
+  # Split without capture groups: zsh only fills BASH_REMATCH with
+  # setopt bash_rematch
+  local now="${EPOCHREALTIME:-}"
+  if [[ "$now" =~ ^[0-9]+[.,][0-9]{3} ]]; then
+    local secs="${now%%[.,]*}"
+    local frac="${now#*[.,]}"
+    echo "$((10#$secs * 1000 + 10#${frac:0:3}))"
+    return 0
+  fi
+  now=$(date +%s%3N 2>/dev/null)
+  if [[ "$now" =~ ^[0-9]+$ ]]; then
+    echo "$now"
+  else
+    echo "$(($(date +%s) * 1000))"
+  fi
+}
+
+_aw_stdout_is_tty() {
+  [[ -t 1 ]]
+}
+
+_aw_stdin_is_tty() {
+  [[ -t 0 ]]
+}
+
+_aw_terminal_width() {
+  # Echo the terminal's width in columns: $COLUMNS, then tput, then stty
+  local cols="${COLUMNS:-}"
+  [[ "$cols" =~ ^[0-9]+$ ]] || cols=$(tput cols 2>/dev/null)
+  [[ "$cols" =~ ^[0-9]+$ ]] || cols=$(stty size 2>/dev/null < /dev/tty | awk '{print $2}')
+  [[ "$cols" =~ ^[0-9]+$ ]] && [[ $cols -gt 0 ]] || return 1
+  echo "$cols"
+}
+
+_aw_visible_length() {
+  # Length of a string as displayed, ignoring ANSI color codes
+  local plain=$(_aw_strip_ansi "$1")
+  echo "${#plain}"
+}
+
+_aw_strip_ansi() {
+  # Print a string with its ANSI color codes removed
+  printf '%s' "$1" | sed $'s/\x1b\\[[0-9;]*m//g'
+}
+
+_aw_color_enabled() {
+  # Return 0 if colored output should be drawn: NO_COLOR is unset, the
+  # terminal isn't dumb, and stderr (where gum draws) is a terminal
+  [[ -z "${NO_COLOR:-}" ]] && [[ "${TERM:-}" != "dumb" ]] && [[ -t 2 ]]
+}
+
+_aw_label_chip_sgr() {
+  # SGR parameters for a label chip on the given hex background, with black
+  # or white text by luminance. Truecolor when COLORTERM advertises it,
+  # otherwise the nearest color of the 256-color cube
+  # Returns 1 if the color isn't a six-digit hex value
+  local hex="${1#\#}"
+  [[ "$hex" =~ ^[0-9a-fA-F]{6}$ ]] || return 1
+  local r=$((16#${hex:0:2})) g=$((16#${hex:2:2})) b=$((16#${hex:4:2}))
+  local fg=97
+  if (( (299 * r + 587 * g + 114 * b) / 1000 > 140 )); then
+    fg=30
+  fi
+  case "${COLORTERM:-}" in
+    truecolor|24bit)
+      echo "48;2;$r;$g;$b;$fg"
+      ;;
+    *)
+      local cube=$((16 + 36 * ((r * 5 + 127) / 255) + 6 * ((g * 5 + 127) / 255) + (b * 5 + 127) / 255))
+      echo "48;5;$cube;$fg"
+      ;;
+  esac
+}
+
+_aw_truncate() {
+  # Shorten text to at most max_width characters, marking the cut with "…"
+  # at the end (default) or at the start, which keeps the tail of a path
+  # Usage: _aw_truncate text max_width [end|start]
+  local text="$1"
+  local max_width="$2"
+  local side="${3:-end}"
+
+  if [[ ${#text} -le $max_width ]] || [[ $max_width -lt 2 ]]; then
+    echo "$text"
+    return 0
+  fi
+
+  local kept=$((max_width - 1))
+  if [[ "$side" == "start" ]]; then
+    echo "…${text:$((${#text} - kept))}"
+  else
+    echo "${text:0:$kept}…"
+  fi
+}
+
 _aw_get_file_mtime() {
   # Get file modification time in Unix timestamp format
   # Works on both macOS/BSD and Linux
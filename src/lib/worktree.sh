@@ -46,72 +46,577 @@ _aw_has_unpushed_commits() {
   return 1
 }
 
-_aw_create_worktree() {
+_aw_get_ahead_behind() {
+  # Echo "<ahead> <behind>": commits on HEAD missing from the upstream, and
+  # upstream commits missing from HEAD
+  # Returns 1 if the worktree's branch has no upstream
+  local wt_path="$1"
+  local counts
+  counts=$(git -C "$wt_path" rev-list --left-right --count '@{upstream}...HEAD' 2>/dev/null) || return 1
+
+  local behind ahead
+  read -r behind ahead <<< "$counts"
+  [[ "$behind" =~ ^[0-9]+$ ]] && [[ "$ahead" =~ ^[0-9]+$ ]] || return 1
+  echo "$ahead $behind"
+}
+
+_aw_format_ahead_behind() {
+  # Echo "↑N ↓M" for a worktree relative to its upstream, leaving out zero
+  # counts. Nothing is echoed without an upstream or when in sync.
+  local counts
+  counts=$(_aw_get_ahead_behind "$1") || return 0
+
+  local ahead behind
+  read -r ahead behind <<< "$counts"
+  local parts=()
+  [[ $ahead -gt 0 ]] && parts+=("↑$ahead")
+  [[ $behind -gt 0 ]] && parts+=("↓$behind")
+  [[ ${#parts[@]} -gt 0 ]] && echo "${parts[*]}"
+  return 0
+}
+
+_aw_copy_untracked_files() {
+  # Copy untracked files matching the auto-worktree.copy-files globs (space or
+  # comma separated, e.g. ".env **/.env.local") from the repo root into a new
+  # worktree. Tracked files are never copied; they are already checked out.
+  # Usage: _aw_copy_untracked_files repo_root worktree_path
+  local repo_root="$1"
+  local worktree_path="$2"
+  local patterns=$(git -C "$repo_root" config auto-worktree.copy-files 2>/dev/null)
+
+  if [[ -z "$patterns" ]]; then
+    return 0
+  fi
+
+  local copied=0
+  local pattern file
+  while IFS= read -r pattern; do
+    [[ -z "$pattern" ]] && continue
+    # ls-files --others only lists untracked (including ignored) files
+    while IFS= read -r -d '' file; do
+      [[ -e "$worktree_path/$file" ]] && continue
+      if mkdir -p "$worktree_path/$(dirname "$file")" && cp -p "$repo_root/$file" "$worktree_path/$file"; then
+        copied=$((copied + 1))
+      fi
+    done < <(git -C "$repo_root" ls-files -z --others -- ":(glob)$pattern" 2>/dev/null)
+  done < <(echo "$patterns" | tr ', ' '\n\n')
+
+  if [[ $copied -gt 0 ]]; then
+    _aw_is_quiet || gum style --foreground 2 "✓ Copied $copied untracked file(s) from $repo_root"
+  fi
+  return 0
+}
+
+_aw_worktree_dir_name() {
+  # Directory name for a branch's worktree, from auto-worktree.worktree-naming.
+  # The expanded template is sanitized like a branch name so the result is a
+  # single filesystem-safe path component.
+  # Usage: _aw_worktree_dir_name branch_name
   local branch_name="$1"
-  local initial_context="${2:-}"
-  local worktree_name=$(_aw_sanitize_branch_name "$branch_name")
+  local name=$(_aw_get_worktree_naming)
+
+  name="${name//"{repo}"/$_AW_SOURCE_FOLDER}"
+  name="${name//"{branch-basename}"/${branch_name##*/}}"
+  name="${name//"{branch}"/$branch_name}"
+  name=$(_aw_sanitize_branch_name "$name")
+
+  # A template that expands to nothing usable falls back to the default
+  if [[ -z "$name" ]]; then
+    name=$(_aw_sanitize_branch_name "$branch_name")
+  fi
+  echo "$name"
+}
+
+_aw_tmux_window_name() {
+  # tmux window name for a branch, from auto-worktree.tmux-window-name
+  # (default {branch-basename}). {issue} is the issue the worktree was created
+  # from (#12, ENG-4), or nothing if none was recorded.
+  # Usage: _aw_tmux_window_name branch_name
+  local branch_name="$1"
+  local name=$(_aw_get_config "tmux-window-name")
+  [[ -z "$name" ]] && name="{branch-basename}"
+
+  local issue=""
+  local issue_id=$(_aw_get_branch_metadata "$branch_name" "issue-id" 2>/dev/null)
+  [[ -n "$issue_id" ]] && issue=$(_aw_format_issue_ref "$issue_id" "$(_aw_get_branch_metadata "$branch_name" "provider")")
+
+  name="${name//"{repo}"/"$_AW_SOURCE_FOLDER"}"
+  name="${name//"{issue}"/"$issue"}"
+  name="${name//"{branch-basename}"/"${branch_name##*/}"}"
+  name="${name//"{branch}"/"$branch_name"}"
+
+  # Drop separators left around an empty {issue}, e.g. "{issue} {branch-basename}"
+  name=$(echo "$name" | sed -e 's/^[[:space:]:-]*//' -e 's/[[:space:]:-]*$//')
+  echo "${name:-${branch_name##*/}}"
+}
+
+_aw_name_tmux_window() {
+  # When running inside tmux, name the window after the branch being worked
+  # on, so tmux ls and the status bar say which worktree is which
+  # Usage: _aw_name_tmux_window branch_name
+  [[ -n "${TMUX:-}" ]] || return 0
+  command -v tmux &>/dev/null || return 0
+
+  local -a target=()
+  [[ -n "${TMUX_PANE:-}" ]] && target=(-t "$TMUX_PANE")
+  tmux rename-window "${target[@]}" "$(_aw_tmux_window_name "$1")" 2>/dev/null || true
+}
+
+_aw_find_remote_branch() {
+  # Echo "<remote>/<branch>" for a branch that isn't local but exists on a
+  # remote. Only remote-tracking refs are checked (origin before the others)
+  # unless --query-remote is given: then origin (or the only remote) is asked
+  # with ls-remote and the branch is fetched into its remote-tracking ref,
+  # unless --no-fetch is given too.
+  # Returns 1 if no remote has the branch.
+  # Usage: _aw_find_remote_branch branch_name [--query-remote] [--no-fetch]
+  local branch_name="$1"
+  shift
+  local query_remote=false
+  local fetch=true
+  while [[ $# -gt 0 ]]; do
+    case "$1" in
+      --query-remote) query_remote=true ;;
+      --no-fetch) fetch=false ;;
+    esac
+    shift
+  done
+
+  local remotes=$(git remote 2>/dev/null)
+  [[ -z "$remotes" ]] && return 1
+
+  local remote
+  while IFS= read -r remote; do
+    [[ -z "$remote" ]] && continue
+    if git show-ref --verify --quiet "refs/remotes/${remote}/${branch_name}"; then
+      echo "${remote}/${branch_name}"
+      return 0
+    fi
+  done <<< "$(echo "$remotes" | grep -x origin; echo "$remotes" | grep -vx origin)"
+
+  # Asking the remote is a network round-trip (or an SSH prompt), so only
+  # callers that expect the branch to be there do it
+  [[ "$query_remote" == "true" ]] || return 1
+
+  remote="origin"
+  echo "$remotes" | grep -qx origin || remote=$(echo "$remotes" | head -n 1)
+  git ls-remote --exit-code --heads "$remote" "refs/heads/${branch_name}" >/dev/null 2>&1 || return 1
+
+  if [[ "$fetch" == "true" ]]; then
+    git fetch --quiet "$remote" "+refs/heads/${branch_name}:refs/remotes/${remote}/${branch_name}" >/dev/null 2>&1 || return 1
+  fi
+  echo "${remote}/${branch_name}"
+}
+
+_aw_worktree_is_registered() {
+  # Returns 0 if git has a worktree registered at the given path, whether or
+  # not its directory still exists
+  # Usage: _aw_worktree_is_registered path
+  git worktree list --porcelain 2>/dev/null | grep -qxF "worktree $1"
+}
+
+_aw_recover_missing_worktree() {
+  # A worktree whose directory was deleted outside git (rm -rf) stays
+  # registered, so git refuses to check its branch out again or reuse its
+  # path. Explain that and offer to prune the stale registration.
+  # Returns 0 once pruned (or, in dry-run, when it would be), non-zero to abort.
+  # Usage: _aw_recover_missing_worktree path [branch_name]
+  local missing_path="$1"
+  local branch_name="${2:-}"
+  local reason="Worktree $missing_path is registered with git but its directory no longer exists"
+
+  gum style --foreground 3 "⚠ The worktree directory is missing, but git still has it registered:" >&2
+  echo "  $missing_path" >&2
+  [[ -n "$branch_name" ]] && echo "  Branch '${branch_name}' stays checked out there until the registration is pruned" >&2
+
+  if [[ "${_AW_DRY_RUN:-}" == "true" ]]; then
+    gum style --foreground 8 "  Would run 'git worktree prune' before creating the worktree" >&2
+    return 0
+  fi
+
+  # Scripts get an error they can act on instead of a prompt
+  if _aw_is_quiet || ! gum confirm "Run 'git worktree prune' and recreate the worktree?"; then
+    _aw_emit_event detecting-repo failed "$reason"
+    gum style --foreground 8 "  Run 'git worktree prune' to clear it, then try again" >&2
+    return $AW_EXIT_EXISTS
+  fi
+
+  if ! _aw_with_lock git worktree prune || _aw_worktree_is_registered "$missing_path"; then
+    _aw_emit_event detecting-repo failed "$reason"
+    gum style --foreground 1 "Error: Could not prune the registration for $missing_path" >&2
+    return 1
+  fi
+  _aw_is_quiet || gum style --foreground 2 "✓ Pruned the stale worktree registration"
+}
+
+_aw_add_worktree() {
+  # Create a worktree for a branch and set up its environment, without
+  # switching to it or launching the AI tool.
+  # Sets _AW_CREATED_WORKTREE_PATH to the new worktree's path.
+  # With _AW_DRY_RUN=true (new --dry-run) only the checks run and the plan
+  # is printed.
+  # Usage: _aw_add_worktree branch_name
+  local branch_name="$1"
+  local worktree_name=$(_aw_worktree_dir_name "$branch_name")
   local worktree_path="$_AW_WORKTREE_BASE/$worktree_name"
+  _AW_CREATED_WORKTREE_PATH=""
 
-  mkdir -p "$_AW_WORKTREE_BASE"
+  _aw_emit_event detecting-repo started
+
+  # Start from origin's latest base when auto-worktree.fetch-before-create is
+  # on. A --fetch/--no-fetch flag on new/issue overrides it for that invocation.
+  local fetch_base="${_AW_FETCH_OVERRIDE:-}"
+  if [[ -z "$fetch_base" ]]; then
+    fetch_base=$(git config --bool auto-worktree.fetch-before-create 2>/dev/null || echo "")
+  fi
+
+  # Remote-tracking refs are always checked for the branch. The remote itself
+  # is only asked for a name the user typed (_AW_EXPLICIT_BRANCH) or when
+  # fetching is on, and never with --no-fetch.
+  local -a remote_lookup=()
+  if [[ "${_AW_FETCH_OVERRIDE:-}" != "false" ]]; then
+    if [[ "$fetch_base" == "true" ]] || [[ "${_AW_EXPLICIT_BRANCH:-}" == "true" ]]; then
+      remote_lookup+=(--query-remote)
+    fi
+  fi
+  [[ "${_AW_DRY_RUN:-}" == "true" ]] && remote_lookup+=(--no-fetch)
 
-  # Check if branch already exists
+  # Check if branch already exists, locally or only on a remote
   local branch_exists=false
+  local remote_branch=""
+  local existing_worktree=""
   if git show-ref --verify --quiet "refs/heads/${branch_name}"; then
     branch_exists=true
-    local existing_worktree=$(git worktree list --porcelain | grep -A2 "^worktree " | grep -B1 "branch refs/heads/${branch_name}$" | head -1 | sed 's/^worktree //')
-    if [[ -n "$existing_worktree" ]]; then
-      gum style --foreground 1 "Error: Branch '${branch_name}' already has a worktree at:"
-      echo "  $existing_worktree"
-      return 1
+    existing_worktree=$(_aw_get_worktree_for_branch "$branch_name")
+    if [[ -n "$existing_worktree" ]] && [[ ! -d "$existing_worktree" ]]; then
+      _aw_recover_missing_worktree "$existing_worktree" "$branch_name" || return $?
+    elif [[ -n "$existing_worktree" ]]; then
+      _aw_emit_event detecting-repo failed "Branch '${branch_name}' already has a worktree at $existing_worktree"
+      gum style --foreground 1 "Error: Branch '${branch_name}' already has a worktree at:" >&2
+      echo "  $existing_worktree" >&2
+      return $AW_EXIT_EXISTS
     fi
-    gum style --foreground 3 "Branch '${branch_name}' exists, creating worktree for it..."
+    _aw_is_quiet || gum style --foreground 3 "Branch '${branch_name}' exists, creating worktree for it..."
+  elif remote_branch=$(_aw_find_remote_branch "$branch_name" "${remote_lookup[@]}"); then
+    # Check out the remote's work rather than starting an unrelated branch
+    branch_exists=true
+    _aw_is_quiet || gum style --foreground 3 "Branch '${branch_name}' exists on ${remote_branch%%/*}, creating a tracking branch for it..."
   fi
 
-  local base_branch=$(git symbolic-ref --short HEAD 2>/dev/null || echo "main")
+  # Templates such as {branch-basename} can map different branches to the
+  # same directory; never reuse one
+  if [[ -e "$worktree_path" ]]; then
+    _aw_emit_event detecting-repo failed "Worktree path already exists: $worktree_path"
+    gum style --foreground 1 "Error: Worktree path already exists (the directory is on disk):" >&2
+    echo "  $worktree_path" >&2
+    echo "  Pick another branch name or adjust auto-worktree.worktree-naming" >&2
+    return $AW_EXIT_EXISTS
+  elif [[ "$existing_worktree" != "$worktree_path" ]] && _aw_worktree_is_registered "$worktree_path"; then
+    # Only the git registration is left behind at this path
+    _aw_recover_missing_worktree "$worktree_path" || return $?
+  fi
 
-  echo ""
-  gum style --border rounded --padding "0 1" --border-foreground 4 \
-    "Creating worktree" \
-    "  Path:   $worktree_path" \
-    "  Branch: $branch_name" \
-    $([[ "$branch_exists" == "false" ]] && echo "  Base:   $base_branch")
+  if [[ "$branch_exists" == "false" ]] && ! git check-ref-format --branch "$branch_name" >/dev/null 2>&1; then
+    _aw_emit_event detecting-repo failed "Invalid branch name: $branch_name"
+    gum style --foreground 1 "Error: Invalid branch name: $branch_name" >&2
+    return $AW_EXIT_USAGE
+  fi
 
-  local worktree_cmd_success=false
-  if [[ "$branch_exists" == "true" ]]; then
-    if gum spin --spinner dot --title "Creating worktree..." -- git worktree add "$worktree_path" "$branch_name"; then
-      worktree_cmd_success=true
+  local base_branch=$(git symbolic-ref --short HEAD 2>/dev/null)
+  if [[ -z "$base_branch" ]]; then
+    _aw_cache_default_branch
+    base_branch=$(_aw_get_default_branch)
+  fi
+  local base_ref="$base_branch"
+  _aw_emit_event detecting-repo done
+
+  if [[ "${_AW_DRY_RUN:-}" == "true" ]]; then
+    _aw_print_worktree_plan "$worktree_path" "$branch_name" "$branch_exists" "$base_branch" "$remote_branch"
+    return $?
+  fi
+
+  # `new --depth N`: start from origin's tip without deepening a shallow clone
+  if [[ -n "${_AW_WORKTREE_DEPTH:-}" ]] && [[ "$branch_exists" == "false" ]]; then
+    local shallow_sha
+    if shallow_sha=$(_aw_fetch_shallow_base "$base_branch" "$_AW_WORKTREE_DEPTH"); then
+      base_ref="$shallow_sha"
+    fi
+  fi
+
+  if [[ "$fetch_base" == "true" ]] && [[ "$branch_exists" == "false" ]] && [[ "$base_ref" == "$base_branch" ]]; then
+    local fetched_sha
+    if fetched_sha=$(_aw_fetch_base "$base_branch"); then
+      base_ref="$fetched_sha"
     fi
+  fi
+
+  if ! _aw_is_quiet; then
+    echo ""
+    gum style --border rounded --padding "0 1" --border-foreground 4 \
+      "Creating worktree" \
+      "  Path:   $worktree_path" \
+      "  Branch: $branch_name" \
+      $([[ "$branch_exists" == "false" ]] && echo "  Base:   $base_branch")
+  fi
+
+  if [[ -n "$remote_branch" ]]; then
+    _aw_emit_event creating-branch started
+    if ! _aw_with_lock git branch --track "$branch_name" "$remote_branch" >/dev/null 2>&1; then
+      _aw_emit_event creating-branch failed "Could not create branch '$branch_name' tracking '$remote_branch'"
+      gum style --foreground 1 "Failed to create branch '$branch_name' tracking '$remote_branch'" >&2
+      return 1
+    fi
+    _aw_emit_event creating-branch done
+  elif [[ "$branch_exists" == "true" ]]; then
+    _aw_emit_event creating-branch skipped
   else
-    if gum spin --spinner dot --title "Creating worktree..." -- git worktree add -b "$branch_name" "$worktree_path" "$base_branch"; then
-      worktree_cmd_success=true
+    _aw_emit_event creating-branch started
+    if ! _aw_with_lock git branch "$branch_name" "$base_ref" >/dev/null 2>&1; then
+      _aw_emit_event creating-branch failed "Could not create branch '$branch_name' from '$base_branch'"
+      gum style --foreground 1 "Failed to create branch '$branch_name'" >&2
+      return 1
     fi
+    _aw_emit_event creating-branch done
   fi
 
-  if [[ "$worktree_cmd_success" == "true" ]]; then
-    # Set up the development environment
-    _aw_setup_environment "$worktree_path"
+  _aw_emit_event creating-worktree started
+  mkdir -p "$_AW_WORKTREE_BASE"
+  if ! _aw_with_lock gum spin --spinner dot --title "Creating worktree..." -- git worktree add "$worktree_path" "$branch_name"; then
+    # Don't leave behind a branch that only existed for this worktree
+    if [[ "$branch_exists" == "false" ]] || [[ -n "$remote_branch" ]]; then
+      git branch -D "$branch_name" >/dev/null 2>&1
+    fi
+    _aw_emit_event creating-worktree failed "git worktree add failed for $worktree_path"
+    gum style --foreground 1 "Failed to create worktree" >&2
+    return 1
+  fi
+  _aw_emit_event creating-worktree done
 
-    cd "$worktree_path" || return 1
+  _aw_finish_worktree_setup "$worktree_path" "$branch_name"
+}
+
+_aw_post_create_message() {
+  # Next-step hint shown after a worktree is created, from
+  # auto-worktree.post-create-message (default "To start working: cd {path}").
+  # Usage: _aw_post_create_message worktree_path branch_name
+  local worktree_path="$1"
+  local branch_name="$2"
+  local message=$(_aw_get_config "post-create-message")
+  [[ -z "$message" ]] && message="To start working: cd {path}"
 
-    # Set terminal title to branch name
-    printf '\033]0;%s\007' "$branch_name"
+  message="${message//"{path}"/"$worktree_path"}"
+  message="${message//"{branch}"/"$branch_name"}"
+  echo "$message"
+}
 
-    _resolve_ai_command || return 1
+_aw_write_branch_file() {
+  # new/issue --write-branch <file>: save the created branch for later CI
+  # steps. Under GitHub Actions a branch=<name> line is appended, as
+  # $GITHUB_OUTPUT expects; elsewhere the file holds just the name.
+  # Does nothing unless _AW_WRITE_BRANCH_FILE is set.
+  # Usage: _aw_write_branch_file branch_name
+  local file="${_AW_WRITE_BRANCH_FILE:-}"
+  [[ -z "$file" ]] && return 0
+
+  local written
+  if [[ "${GITHUB_ACTIONS:-}" == "true" ]]; then
+    { echo "branch=$1" >> "$file"; } 2>/dev/null
+    written=$?
+  else
+    { echo "$1" > "$file"; } 2>/dev/null
+    written=$?
+  fi
 
-    if [[ "${AI_CMD[1]}" != "skip" ]]; then
-      gum style --foreground 2 "Starting $AI_CMD_NAME..."
-      if [[ -n "$initial_context" ]]; then
-        "${AI_CMD[@]}" "$initial_context"
-      else
-        "${AI_CMD[@]}"
-      fi
+  if [[ $written -ne 0 ]]; then
+    gum style --foreground 1 "Error: Could not write the branch name to $file" >&2
+    return 1
+  fi
+}
+
+_aw_finish_worktree_setup() {
+  # Set up a freshly added worktree: copy local files, run git hooks and
+  # install dependencies, then report the path and the next-step hint.
+  # Sets _AW_CREATED_WORKTREE_PATH.
+  # Usage: _aw_finish_worktree_setup worktree_path branch_name
+  local worktree_path="$1"
+  local branch_name="$2"
+
+  # Bring over local files (.env etc.) before dependency installation needs them
+  _aw_copy_untracked_files "$_AW_GIT_ROOT" "$worktree_path"
+
+  # Set up the development environment: git hooks, then dependencies.
+  # A failing hook (with fail-on-hook-error) skips the install but keeps the worktree.
+  _aw_emit_event running-hooks started
+  if _aw_quietable _aw_run_git_hooks "$worktree_path"; then
+    _aw_emit_event running-hooks done
+    if _aw_install_dependencies_enabled "$worktree_path"; then
+      _aw_emit_event installing-deps started
+      _aw_quietable _aw_install_dependencies "$worktree_path"
+      _aw_emit_event installing-deps done
     else
-      gum style --foreground 3 "Skipping AI tool - worktree is ready for manual work"
+      _aw_quietable _aw_install_dependencies "$worktree_path"
+      _aw_emit_event installing-deps skipped
     fi
   else
-    gum style --foreground 1 "Failed to create worktree"
+    _aw_emit_event running-hooks failed "A git hook failed in $worktree_path"
+    _aw_emit_event installing-deps skipped
+  fi
+
+  if _aw_events_enabled; then
+    _aw_emit_event done done "" "$worktree_path"
+  elif _aw_is_quiet; then
+    # The created path is the only output scripts need
+    echo "$worktree_path"
+  else
+    gum style --foreground 2 "$(_aw_post_create_message "$worktree_path" "$branch_name")"
+  fi
+
+  _AW_CREATED_WORKTREE_PATH="$worktree_path"
+  return 0
+}
+
+_aw_add_detached_worktree() {
+  # Create a worktree checked out at a commit with a detached HEAD, for
+  # bisecting or reproducing a bug; no branch is created.
+  # Sets _AW_CREATED_WORKTREE_PATH and _AW_CREATED_WORKTREE_NAME (detached-<short sha>).
+  # Usage: _aw_add_detached_worktree commit
+  local commit="$1"
+  _AW_CREATED_WORKTREE_PATH=""
+  _AW_CREATED_WORKTREE_NAME=""
+
+  _aw_emit_event detecting-repo started
+  local sha
+  if ! sha=$(git rev-parse --verify --quiet "${commit}^{commit}"); then
+    _aw_emit_event detecting-repo failed "Not a commit: $commit"
+    gum style --foreground 1 "Error: '$commit' doesn't resolve to a commit" >&2
+    return $AW_EXIT_USAGE
+  fi
+
+  local short_sha=$(git rev-parse --short "$sha")
+  local worktree_name="detached-$short_sha"
+  local worktree_path="$_AW_WORKTREE_BASE/$worktree_name"
+  local subject=$(git log -1 --format=%s "$sha")
+
+  if [[ -e "$worktree_path" ]]; then
+    _aw_emit_event detecting-repo failed "Worktree path already exists: $worktree_path"
+    gum style --foreground 1 "Error: Worktree path already exists (the directory is on disk):" >&2
+    echo "  $worktree_path" >&2
+    return $AW_EXIT_EXISTS
+  elif _aw_worktree_is_registered "$worktree_path"; then
+    _aw_recover_missing_worktree "$worktree_path" || return $?
+  fi
+  _aw_emit_event detecting-repo done
+
+  if [[ "${_AW_DRY_RUN:-}" == "true" ]]; then
+    if _aw_is_quiet; then
+      echo "$worktree_path"
+      return 0
+    fi
+    echo ""
+    gum style --border rounded --padding "0 1" --border-foreground 8 \
+      "Dry run: would create worktree" \
+      "  Path:   $worktree_path" \
+      "  Commit: $short_sha $subject (detached)"
+    gum style --foreground 8 "Nothing was created"
+    return 0
+  fi
+
+  if ! _aw_is_quiet; then
+    echo ""
+    gum style --border rounded --padding "0 1" --border-foreground 4 \
+      "Creating worktree" \
+      "  Path:   $worktree_path" \
+      "  Commit: $short_sha $subject (detached)"
+  fi
+
+  _aw_emit_event creating-branch skipped
+  _aw_emit_event creating-worktree started
+  mkdir -p "$_AW_WORKTREE_BASE"
+  if ! _aw_with_lock gum spin --spinner dot --title "Creating worktree..." -- git worktree add --detach "$worktree_path" "$sha"; then
+    _aw_emit_event creating-worktree failed "git worktree add failed for $worktree_path"
+    gum style --foreground 1 "Failed to create worktree" >&2
     return 1
   fi
+  _aw_emit_event creating-worktree done
+
+  _AW_CREATED_WORKTREE_NAME="$worktree_name"
+  _aw_finish_worktree_setup "$worktree_path" "$worktree_name"
+}
+
+_aw_launch_worktree() {
+  # Switch to a worktree and start the configured AI tool in it
+  # Usage: _aw_launch_worktree worktree_path branch_name [initial_context]
+  local worktree_path="$1"
+  local branch_name="$2"
+  local initial_context="${3:-}"
+
+  cd "$worktree_path" || return 1
+  # Detached worktrees have no branch to record the visit on
+  git show-ref --verify --quiet "refs/heads/$branch_name" && _aw_touch_last_accessed "$branch_name"
+
+  # Set terminal title (and tmux window name) to branch name
+  _aw_is_quiet || printf '\033]0;%s\007' "$branch_name"
+  _aw_name_tmux_window "$branch_name"
+
+  _resolve_ai_command || return 1
+
+  if [[ "${AI_CMD[1]}" != "skip" ]]; then
+    _aw_is_quiet || gum style --foreground 2 "Starting $AI_CMD_NAME..."
+    if [[ -n "$initial_context" ]]; then
+      "${AI_CMD[@]}" "$initial_context"
+    else
+      "${AI_CMD[@]}"
+    fi
+  else
+    _aw_is_quiet || gum style --foreground 3 "Skipping AI tool - worktree is ready for manual work"
+  fi
+}
+
+_aw_print_worktree_plan() {
+  # Print what _aw_add_worktree would create, for new --dry-run.
+  # Returns 1 if a new branch's base can't be resolved.
+  # Usage: _aw_print_worktree_plan worktree_path branch_name branch_exists base_branch [remote_branch]
+  local worktree_path="$1"
+  local branch_name="$2"
+  local branch_exists="$3"
+  local base_branch="$4"
+  local remote_branch="${5:-}"
+
+  local branch_line="  Branch: $branch_name (existing)"
+  [[ -n "$remote_branch" ]] && branch_line="  Branch: $branch_name (tracking $remote_branch)"
+  if [[ "$branch_exists" == "false" ]]; then
+    if [[ -z "$base_branch" ]] || ! git rev-parse --verify --quiet "${base_branch}^{commit}" >/dev/null; then
+      gum style --foreground 1 "Error: Could not resolve base branch '${base_branch}' for '$branch_name'" >&2
+      return 1
+    fi
+    branch_line="  Branch: $branch_name (new, from $base_branch)"
+  fi
+
+  if _aw_is_quiet; then
+    echo "$worktree_path"
+    return 0
+  fi
+
+  echo ""
+  gum style --border rounded --padding "0 1" --border-foreground 8 \
+    "Dry run: would create worktree" \
+    "  Path:   $worktree_path" \
+    "$branch_line"
+  gum style --foreground 8 "Nothing was created"
+}
+
+_aw_create_worktree() {
+  # Create a worktree for a branch, switch to it and launch the AI tool
+  # Usage: _aw_create_worktree branch_name [initial_context]
+  local branch_name="$1"
+  local initial_context="${2:-}"
+
+  _aw_add_worktree "$branch_name" || return $?
+  [[ "${_AW_DRY_RUN:-}" == "true" ]] && return 0
+  _aw_write_branch_file "$branch_name" || return $?
+  # With --events the caller drives what happens next from the "done" event
+  _aw_events_enabled && return 0
+  _aw_launch_worktree "$_AW_CREATED_WORKTREE_PATH" "$branch_name" "$initial_context"
 }
 
 # ============================================================================
@@ -123,6 +628,78 @@ _aw_get_worktree_list() {
   git worktree list --porcelain 2>/dev/null | grep "^worktree " | sed 's/^worktree //'
 }
 
+_aw_get_locked_worktrees() {
+  # Echo the paths of worktrees locked with `git worktree lock`, one per line
+  git worktree list --porcelain 2>/dev/null \
+    | awk '/^worktree /{ path = substr($0, 10) } /^locked( |$)/{ print path }'
+}
+
+_aw_worktree_is_locked() {
+  # Return 0 if the worktree at the given path (as git lists it) is locked
+  # Usage: _aw_worktree_is_locked worktree_path [locked_list]
+  # Pass the output of _aw_get_locked_worktrees as locked_list to check
+  # several worktrees without asking git each time
+  local locked="${2-$(_aw_get_locked_worktrees)}"
+  [[ -n "$locked" ]] && grep -qxF -- "$1" <<< "$locked"
+}
+
+_aw_current_worktree() {
+  # Echo the path of the linked worktree the current directory is in (from
+  # any subdirectory), so commands can treat "here" as their target.
+  # Returns 1 in the main checkout or outside any worktree.
+  local here=$(pwd -P)
+  local main_path=$(_aw_get_worktree_list | head -n 1)
+  local best=""
+  local best_real=""
+
+  local wt_path
+  while IFS= read -r wt_path; do
+    [[ -z "$wt_path" ]] || [[ ! -d "$wt_path" ]] && continue
+    local wt_real=$(cd "$wt_path" && pwd -P)
+    # Worktrees can be nested in each other; the deepest match wins
+    if [[ "$here" == "$wt_real" || "$here" == "$wt_real"/* ]] && [[ ${#wt_real} -gt ${#best_real} ]]; then
+      best="$wt_path"
+      best_real="$wt_real"
+    fi
+  done <<< "$(_aw_get_worktree_list)"
+
+  [[ -z "$best" ]] || [[ "$best" == "$main_path" ]] && return 1
+  echo "$best"
+}
+
+_aw_current_worktree_branch() {
+  # Echo the branch checked out in the current linked worktree
+  # Returns 1 outside one, or when it has a detached HEAD
+  local wt_path
+  wt_path=$(_aw_current_worktree) || return 1
+  local branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null)
+  [[ -z "$branch" ]] || [[ "$branch" == "HEAD" ]] && return 1
+  echo "$branch"
+}
+
+_aw_get_worktree_for_branch() {
+  # Echo the path of the worktree that has the given branch checked out.
+  # Returns 1 if no worktree uses the branch.
+  local branch_name="$1"
+  [[ -z "$branch_name" ]] && return 1
+
+  local wt_path=""
+  local line
+  while IFS= read -r line; do
+    case "$line" in
+      "worktree "*)
+        wt_path="${line#worktree }"
+        ;;
+      "branch refs/heads/${branch_name}")
+        echo "$wt_path"
+        return 0
+        ;;
+    esac
+  done < <(git worktree list --porcelain 2>/dev/null)
+
+  return 1
+}
+
 _aw_get_worktree_timestamp() {
   # Echo a unix timestamp integer for the given worktree path.
   # Fallback chain: git log → git reflog → file mtime
@@ -138,7 +715,10 @@ _aw_get_worktree_timestamp() {
   fi
 
   if [[ -z "$commit_timestamp" ]] || ! [[ "$commit_timestamp" =~ ^[0-9]+$ ]]; then
-    commit_timestamp=$(find "$wt_path" -maxdepth 3 -type f -not -path '*/.git/*' -print0 2>/dev/null | while IFS= read -r -d '' file; do _aw_get_file_mtime "$file"; done | sort -rn | head -1)
+    # Skip the worktree base in case it's nested in this checkout
+    local skip_base=()
+    [[ -n "${_AW_WORKTREE_BASE:-}" ]] && skip_base=(-not -path "${_AW_WORKTREE_BASE%/}/*")
+    commit_timestamp=$(find "$wt_path" -maxdepth 3 -type f -not -path '*/.git/*' "${skip_base[@]}" -print0 2>/dev/null | while IFS= read -r -d '' file; do _aw_get_file_mtime "$file"; done | sort -rn | head -1)
   fi
 
   echo "$commit_timestamp"
@@ -168,6 +748,62 @@ _aw_format_worktree_age() {
   fi
 }
 
+_aw_worktree_issue_id() {
+  # Echo the issue ID a branch belongs to: the issue recorded when the
+  # worktree was created, falling back to the ID encoded in the branch name
+  # Usage: _aw_worktree_issue_id branch provider
+  local branch="$1"
+  local provider="$2"
+
+  local recorded_id=$(_aw_get_branch_metadata "$branch" "issue-id" 2>/dev/null)
+  local recorded_provider=$(_aw_get_branch_metadata "$branch" "provider" 2>/dev/null)
+  if [[ -n "$recorded_id" ]] && [[ -z "$recorded_provider" || "$recorded_provider" == "$provider" ]]; then
+    echo "$recorded_id"
+    return 0
+  fi
+
+  _aw_extract_issue_id_from_branch "$branch" "$provider"
+}
+
+_aw_get_active_issue_ids() {
+  # Echo the IDs of issues that have a linked worktree, one per line
+  # Usage: _aw_get_active_issue_ids provider
+  local provider="$1"
+
+  local wt_path
+  while IFS= read -r wt_path; do
+    _aw_validate_worktree_path "$wt_path" || continue
+    local wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "")
+    [[ -z "$wt_branch" ]] && continue
+    local wt_issue=$(_aw_worktree_issue_id "$wt_branch" "$provider")
+    [[ -n "$wt_issue" ]] && echo "$wt_issue"
+  done <<< "$(_aw_get_worktree_list)"
+}
+
+_aw_mark_active_issues() {
+  # Prefix issues that already have a worktree with "● " and move them to
+  # the top of the list so they're easy to jump back into
+  # Usage: _aw_mark_active_issues issues provider
+  local issues="$1"
+  local provider="$2"
+  local active=$'\n'"$(_aw_get_active_issue_ids "$provider")"$'\n'
+
+  local marked=""
+  local unmarked=""
+  local issue_line
+  while IFS= read -r issue_line; do
+    [[ -z "$issue_line" ]] && continue
+    local line_issue=$(_aw_extract_id_from_selection "$issue_line")
+    if [[ "$active" == *$'\n'"$line_issue"$'\n'* ]]; then
+      marked+="● $issue_line"$'\n'
+    else
+      unmarked+="$issue_line"$'\n'
+    fi
+  done <<< "$issues"
+
+  printf '%s' "${marked}${unmarked}"
+}
+
 _aw_find_worktree_for_issue() {
   # Search all worktrees for one matching the given issue ID and provider.
   # Echoes the matching worktree path, or returns 1 if not found.
@@ -187,14 +823,7 @@ _aw_find_worktree_for_issue() {
       local wt_branch
       wt_branch=$(git -C "$wt_path" rev-parse --abbrev-ref HEAD 2>/dev/null || echo "")
       if [[ -n "$wt_branch" ]]; then
-        local wt_issue=""
-        if [[ "$provider" == "jira" ]]; then
-          wt_issue=$(_aw_extract_jira_key "$wt_branch")
-        elif [[ "$provider" == "linear" ]]; then
-          wt_issue=$(_aw_extract_linear_key "$wt_branch")
-        else
-          wt_issue=$(_aw_extract_issue_number "$wt_branch")
-        fi
+        local wt_issue=$(_aw_worktree_issue_id "$wt_branch" "$provider")
         if [[ "$wt_issue" == "$issue_id" ]]; then
           echo "$wt_path"
           return 0
@@ -206,6 +835,74 @@ _aw_find_worktree_for_issue() {
   return 1
 }
 
+_aw_git_supports_shallow() {
+  # `git rev-parse --is-shallow-repository` arrived in git 2.15; older
+  # versions echo the flag back instead of answering
+  local answer
+  answer=$(git rev-parse --is-shallow-repository 2>/dev/null)
+  [[ "$answer" == "true" || "$answer" == "false" ]]
+}
+
+_aw_fetch_shallow_base() {
+  # Fetch the last <depth> commits of base_branch from origin and echo the
+  # fetched tip. Only shallow clones are fetched into: a full clone already
+  # has the history, and worktrees share it rather than copying it.
+  # Returns 1 (after a warning) when the caller should use the local branch.
+  # Usage: _aw_fetch_shallow_base base_branch depth
+  local base_branch="$1"
+  local depth="$2"
+
+  if ! _aw_git_supports_shallow; then
+    gum style --foreground 3 "Warning: $(git --version 2>/dev/null || echo "This git") can't create shallow worktrees; creating a normal one" >&2
+    return 1
+  fi
+
+  if [[ "$(git rev-parse --is-shallow-repository 2>/dev/null)" != "true" ]]; then
+    _aw_is_quiet || gum style --foreground 8 "Repository has full history, which worktrees share; ignoring --depth" >&2
+    return 1
+  fi
+
+  if ! gum spin --spinner dot --title "Fetching last $depth commit(s) of $base_branch..." -- \
+    git fetch --quiet --depth "$depth" origin "$base_branch" >/dev/null 2>&1; then
+    gum style --foreground 3 "Warning: Shallow fetch of origin/$base_branch failed; creating a normal worktree" >&2
+    return 1
+  fi
+
+  git rev-parse --verify --quiet FETCH_HEAD
+}
+
+_aw_fetch_base() {
+  # Fetch base_branch from origin and echo its tip when the local branch is
+  # behind it (or doesn't exist), so a new branch starts from the latest
+  # commit. A local branch with commits origin doesn't have is left to win.
+  # Failing to fetch, e.g. offline, is only a warning.
+  # Returns 1 when the caller should use the local branch.
+  # Usage: _aw_fetch_base base_branch
+  local base_branch="$1"
+
+  if ! git remote 2>/dev/null | grep -qx origin; then
+    _aw_is_quiet || gum style --foreground 8 "No origin remote; branching from the local $base_branch" >&2
+    return 1
+  fi
+
+  if ! gum spin --spinner dot --title "Fetching origin/$base_branch..." -- \
+    git fetch --quiet origin "+refs/heads/${base_branch}:refs/remotes/origin/${base_branch}" >/dev/null 2>&1; then
+    gum style --foreground 3 "Warning: Could not fetch origin/$base_branch (offline?); branching from the local $base_branch" >&2
+    return 1
+  fi
+
+  local remote_sha
+  remote_sha=$(git rev-parse --verify --quiet "refs/remotes/origin/${base_branch}^{commit}") || return 1
+
+  if git show-ref --verify --quiet "refs/heads/${base_branch}" \
+    && ! git merge-base --is-ancestor "refs/heads/${base_branch}" "$remote_sha" 2>/dev/null; then
+    _aw_is_quiet || gum style --foreground 8 "Local $base_branch has commits that aren't on origin; branching from it" >&2
+    return 1
+  fi
+
+  echo "$remote_sha"
+}
+
 _aw_remove_worktree_and_branch() {
   # Remove a worktree and optionally delete its branch.
   # Usage: _aw_remove_worktree_and_branch worktree_path branch_name
@@ -213,22 +910,39 @@ _aw_remove_worktree_and_branch() {
   local worktree_path="$1"
   local branch_name="${2:-}"
 
-  echo ""
+  _aw_is_quiet || echo ""
+  _aw_acquire_lock || return $?
   git worktree remove --force "$worktree_path"
   local remove_exit=$?
   if [[ $remove_exit -ne 0 ]]; then
-    gum style --foreground 1 "Error: Failed to remove worktree: $worktree_path"
+    _aw_release_lock
+    gum style --foreground 1 "Error: Failed to remove worktree: $worktree_path" >&2
     return 1
   fi
 
-  gum style --foreground 2 "✓ Worktree removed: $(basename "$worktree_path")"
+  _aw_is_quiet || gum style --foreground 2 "✓ Worktree removed: $(basename "$worktree_path")"
 
   if [[ -n "$branch_name" ]] && git show-ref --verify --quiet "refs/heads/${branch_name}"; then
-    if ! git branch -d "$branch_name" 2>/dev/null; then
-      # Branch has unmerged changes; force-delete
-      git branch -D "$branch_name" 2>/dev/null
-    fi
-    gum style --foreground 2 "✓ Branch deleted: $branch_name"
+    # Branch may have unmerged changes; force-delete
+    _aw_delete_branch "$branch_name" true
+    _aw_is_quiet || gum style --foreground 2 "✓ Branch deleted: $branch_name"
+  fi
+  _aw_release_lock
+}
+
+_aw_delete_branch() {
+  # Delete a local branch with `git branch -d`, or `-D` when force is "true".
+  # Returns 1 if the branch isn't fully merged (and force is off) or can't
+  # be deleted.
+  # Usage: _aw_delete_branch branch_name [force]
+  local branch_name="$1"
+  local force="${2:-false}"
+  [[ -z "$branch_name" ]] && return 1
+
+  if [[ "$force" == "true" ]]; then
+    git branch -D "$branch_name" >/dev/null 2>&1
+  else
+    git branch -d "$branch_name" >/dev/null 2>&1
   fi
 }
 
@@ -5,17 +5,55 @@
 # Usage:
 #   auto-worktree                    # Interactive menu
 #   auto-worktree new                # Create new worktree
+#   auto-worktree new --detach <sha> # Worktree at a commit with a detached HEAD (no branch)
+#   auto-worktree new --batch < branches.txt  # One worktree per branch name on stdin, with a summary
+#   auto-worktree new --write-branch <file>  # Save the created branch name for later CI steps (also issue)
 #   auto-worktree resume             # Resume existing worktree
+#   auto-worktree resume --list      # Pick a recent worktree (tmux attach or cd path)
 #   auto-worktree issue [id]         # Work on an issue (GitHub #123, GitLab #456, or JIRA PROJ-123)
+#   auto-worktree issue --preview    # Preview issue descriptions while picking
+#   auto-worktree issue --milestone <name> --all  # Worktrees for a whole milestone
+#   auto-worktree issue --jql '<query>'  # Pick from a one-off JIRA query
+#   auto-worktree issue --include-closed  # Also list closed issues (GitHub, GitLab)
+#   auto-worktree issue <id> --resume  # Reattach to the issue's worktree (tmux session or cd path)
 #   auto-worktree milestone          # Work on a Milestone/Epic
 #   auto-worktree pr [num]           # Review a GitHub PR or GitLab MR
+#   auto-worktree pr [num] --stat    # ...and show its diff stat
+#   auto-worktree pr --not-author @me  # Pick from PRs opened by someone else (--author <login> for one person's)
+#   auto-worktree pr --create --draft  # Open a draft PR/MR for the current branch
 #   auto-worktree list               # List existing worktrees
+#   auto-worktree list --since 24h   # Only worktrees committed to or opened in the last day
+#   auto-worktree list --all-repos   # List worktrees across every repository used with auto-worktree
+#   auto-worktree list --format '{branch}\t{path}'  # One templated line per worktree, for scripts
+#   auto-worktree list --count-only  # Just the number of worktrees, for shell prompts
+#   auto-worktree list --no-status   # Only name, branch and age; skip merge and sync checks
+#   auto-worktree status             # Summarize dirty, unpushed, stale and merged worktrees
+#   auto-worktree remove <branch>    # Remove a worktree by branch name or path
+#   auto-worktree remove -D <branch> # Remove a worktree and force-delete its branch
+#   auto-worktree remove --interactive  # Pick a worktree to remove from a list
+#   auto-worktree prune [--all]      # Prune orphaned worktrees (--all: also merged ones)
+#   auto-worktree grep <pattern>     # Search every worktree, prefixed by branch
+#   auto-worktree edit [<branch>]    # Open a worktree (default: the current one) in your editor
+#   auto-worktree rename [<old>] <new>  # Rename a worktree's branch and move it to match
+#   auto-worktree lock [<branch>]    # Protect a worktree from cleanup, prune and list (unlock to undo)
+#   auto-worktree sessions keep [<branch>]  # Never report a worktree as stale (--off to undo)
 #   auto-worktree settings           # Configure per-repository settings
+#   auto-worktree settings export    # Print settings as JSON grouped by category
+#   auto-worktree settings import <file>  # Apply settings from an exported JSON file
+#   auto-worktree doctor             # Validate configuration and repository state
+#   auto-worktree doctor --repair    # Fix worktree registrations after directories moved
+#   auto-worktree doctor --self-test # Time git and the provider CLI to diagnose slow or broken setups
+#   auto-worktree version [--json]   # Print the version (JSON includes git and gh versions)
 #
 # Configuration (per-repository via git config):
 #   git config auto-worktree.issue-provider github|gitlab|jira  # Set issue provider
 #   git config auto-worktree.jira-server <URL>                  # Set JIRA server URL
 #   git config auto-worktree.jira-project <KEY>                 # Set default JIRA project
+#   git config auto-worktree.jira-jql '<query>'                 # JQL for the JIRA issue list (project filter still applied)
+#   git config auto-worktree.issue-list-labels <bool>           # false to list GitHub issues without labels (smaller, faster)
+#   git config auto-worktree.issue-list-limit <n>               # How many issues, milestones and PRs to fetch (default: 100)
+#   git config auto-worktree.github-host <HOST>                 # Set GitHub Enterprise hostname (default: github.com)
+#   git config auto-worktree.github-remote <remote>             # Remote whose GitHub repo has the issues (default: origin)
 #   git config auto-worktree.gitlab-server <URL>                # Set GitLab server URL (for self-hosted)
 #   git config auto-worktree.gitlab-project <GROUP/PROJECT>     # Set default GitLab project path
 #   git config auto-worktree.linear-team <TEAM>                 # Set default Linear team
@@ -26,6 +64,20 @@
 #   git config auto-worktree.run-hooks <bool>                   # true/false to enable/disable git hooks (default: true)
 #   git config auto-worktree.fail-on-hook-error <bool>          # true/false to fail on hook errors (default: false)
 #   git config auto-worktree.custom-hooks "<hook1> <hook2>"     # Space or comma-separated list of custom hooks to run
+#   git config auto-worktree.hook-path "<dir>:<dir>"            # Appended to PATH for hooks (default: OS-specific tool dirs)
+#   git config auto-worktree.install-deps <bool>                # true/false to install project dependencies (default: true)
+#   git config auto-worktree.copy-files "<glob> <glob>"         # Untracked files to copy into new worktrees, e.g. ".env"
+#   git config auto-worktree.branch-prefix <prefix>             # Prefix for generated branch names (default: work)
+#   git config auto-worktree.default-branch <branch>            # Override default branch detection (origin/HEAD, gh, main, master)
+#   git config auto-worktree.editor <command>                   # Editor for 'edit' (default: $VISUAL, then $EDITOR)
+#   git config auto-worktree.prune-no-confirm <bool>            # true to skip the 'prune --all' confirmation (default: false)
+#   git config auto-worktree.fetch-before-create <bool>         # true to branch new worktrees from origin's latest base (default: false)
+#   git config auto-worktree.list-no-status <bool>              # true to make 'list' skip merge and sync checks (default: false)
+#   git config auto-worktree.age-warn-days <n>                  # Days before a worktree's age shows in yellow (default: 1)
+#   git config auto-worktree.age-stale-days <n>                 # Days before it shows in red and counts as stale (default: 4)
+#   git config auto-worktree.worktree-naming "{repo}-{branch}"  # Worktree directory name (default: {branch})
+#   git config auto-worktree.tmux-window-name "{issue} {branch-basename}"  # tmux window name (default: {branch-basename})
+#   git config auto-worktree.post-create-message "<template>"   # Hint after creating a worktree; {path}, {branch} (default: To start working: cd {path})
 
 # Determine the directory where this script is located
 _AW_SRC_DIR="${BASH_SOURCE[0]:-${(%):-%x}}"
@@ -40,6 +92,8 @@ source "$_AW_SRC_DIR/lib/deps.sh"
 source "$_AW_SRC_DIR/lib/utils.sh"
 # shellcheck source=lib/config.sh
 source "$_AW_SRC_DIR/lib/config.sh"
+# shellcheck source=lib/metadata.sh
+source "$_AW_SRC_DIR/lib/metadata.sh"
 # shellcheck source=lib/hooks.sh
 source "$_AW_SRC_DIR/lib/hooks.sh"
 # shellcheck source=lib/environment.sh
@@ -62,6 +116,8 @@ source "$_AW_SRC_DIR/providers/linear.sh"
 source "$_AW_SRC_DIR/lib/worktree.sh"
 # shellcheck source=commands/list.sh
 source "$_AW_SRC_DIR/commands/list.sh"
+# shellcheck source=commands/status.sh
+source "$_AW_SRC_DIR/commands/status.sh"
 # shellcheck source=commands/new.sh
 source "$_AW_SRC_DIR/commands/new.sh"
 # shellcheck source=commands/issue.sh
@@ -74,6 +130,24 @@ source "$_AW_SRC_DIR/commands/pr.sh"
 source "$_AW_SRC_DIR/commands/resume.sh"
 # shellcheck source=commands/cleanup.sh
 source "$_AW_SRC_DIR/commands/cleanup.sh"
+# shellcheck source=commands/remove.sh
+source "$_AW_SRC_DIR/commands/remove.sh"
+# shellcheck source=commands/prune.sh
+source "$_AW_SRC_DIR/commands/prune.sh"
+# shellcheck source=commands/grep.sh
+source "$_AW_SRC_DIR/commands/grep.sh"
+# shellcheck source=commands/edit.sh
+source "$_AW_SRC_DIR/commands/edit.sh"
+# shellcheck source=commands/rename.sh
+source "$_AW_SRC_DIR/commands/rename.sh"
+# shellcheck source=commands/lock.sh
+source "$_AW_SRC_DIR/commands/lock.sh"
+# shellcheck source=commands/sessions.sh
+source "$_AW_SRC_DIR/commands/sessions.sh"
+# shellcheck source=commands/version.sh
+source "$_AW_SRC_DIR/commands/version.sh"
+# shellcheck source=commands/doctor.sh
+source "$_AW_SRC_DIR/commands/doctor.sh"
 # shellcheck source=commands/milestone.sh
 source "$_AW_SRC_DIR/commands/milestone.sh"
 # shellcheck source=commands/menu.sh
@@ -86,43 +160,163 @@ source "$_AW_SRC_DIR/commands/menu.sh"
 auto-worktree() {
   _aw_check_deps || return 1
 
+  # Global flags may appear anywhere on the command line
+  local _AW_QUIET=false
+  local _AW_EVENTS=false
+  local _AW_VERBOSE=false
+  local _AW_LOG_FD=""
+  local args=()
+  local arg
+  for arg in "$@"; do
+    case "$arg" in
+      --quiet)  _AW_QUIET=true ;;
+      --events) _AW_EVENTS=true; _AW_QUIET=true ;;
+      --verbose|-V) _AW_VERBOSE=true ;;
+      *)        args+=("$arg") ;;
+    esac
+  done
+  set -- "${args[@]}"
+
+  # --verbose logs every git and provider CLI call to stderr
+  if [[ "$_AW_VERBOSE" == "true" ]]; then
+    _aw_trace_start
+    _aw_log debug "auto-worktree $*"
+  fi
+
+  # Remember this repository for `list --all-repos`
+  git rev-parse --git-dir >/dev/null 2>&1 && _aw_register_repo
+
+  local dispatch_status=0
+  _aw_dispatch "$@" || dispatch_status=$?
+
+  if [[ "$_AW_VERBOSE" == "true" ]]; then
+    _aw_log debug "exit $dispatch_status"
+    _aw_trace_stop
+  fi
+  return $dispatch_status
+}
+
+_aw_dispatch() {
+  # Run the command named by the first argument
   case "${1:-}" in
     new)     shift; _aw_new "$@" ;;
     issue)      shift; _aw_issue "$@" ;;
     milestone)  shift; _aw_milestone "$@" ;;
     create)     shift; _aw_create_issue "$@" ;;
     pr)      shift; _aw_pr "$@" ;;
-    resume)  shift; _aw_resume ;;
-    list)    shift; _aw_list ;;
-    cleanup) shift; _aw_cleanup_interactive ;;
-    settings) shift; _aw_settings_menu ;;
+    resume)  shift; _aw_resume "$@" ;;
+    list)    shift; _aw_list "$@" ;;
+    status)  shift; _aw_status "$@" ;;
+    cleanup) shift; _aw_cleanup_interactive "$@" ;;
+    remove)  shift; _aw_remove "$@" ;;
+    prune)   shift; _aw_prune "$@" ;;
+    edit)    shift; _aw_edit "$@" ;;
+    rename)  shift; _aw_rename "$@" ;;
+    lock)    shift; _aw_lock_worktree "$@" ;;
+    unlock)  shift; _aw_unlock_worktree "$@" ;;
+    sessions) shift; _aw_sessions "$@" ;;
+    doctor)  shift; _aw_doctor "$@" ;;
+    version|--version) shift; _aw_version "$@" ;;
+    grep)    shift; _aw_grep "$@" ;;
+    settings) shift; _aw_settings "$@" ;;
     help|--help|-h)
       echo "Usage: auto-worktree [command] [args]"
       echo ""
       echo "Commands:"
-      echo "  new             Create a new worktree"
-      echo "  resume          Resume an existing worktree"
+      echo "  new             Create a new worktree (--no-hooks/--hooks: override auto-worktree.run-hooks,"
+      echo "                  --fetch/--no-fetch: override auto-worktree.fetch-before-create,"
+      echo "                  --depth N: shallow-fetch the base in shallow clones,"
+      echo "                  --dry-run: print the path and branch without creating anything,"
+      echo "                  --detach <commit>: check out a commit without creating a branch,"
+      echo "                  --batch: one worktree per branch name read from stdin,"
+      echo "                  --write-branch <file>: save the branch name, branch=<name> under"
+      echo "                  GitHub Actions; issue accepts it too)"
+      echo "  resume          Resume an existing worktree (--list: pick by most recently used)"
       echo "  issue [id]      Work on an issue (GitHub #123, GitLab #456, JIRA PROJ-123, or Linear TEAM-123)"
+      echo "                  (--jql '<query>': list JIRA issues from a custom query;"
+      echo "                  --include-closed: also list closed GitHub/GitLab issues;"
+      echo "                  <id> --resume: reattach to the issue's existing worktree)"
       echo "  milestone       Work on a Milestone/Epic (filter issues by milestone)"
       echo "  create          Create a new issue with optional template"
-      echo "  pr [num]        Review a GitHub PR or GitLab MR"
-      echo "  list            List existing worktrees"
-      echo "  cleanup         Interactively clean up worktrees"
-      echo "  settings        Configure per-repository settings"
+      echo "  pr [num]        Review a GitHub PR or GitLab MR (--stat: show the diff stat;"
+      echo "                  --create [--draft] to open one; --author/--not-author <login|@me>"
+      echo "                  narrow the list)"
+      echo "  list            List existing worktrees (--size: show disk usage;"
+      echo "                  --since <24h|7d>: only recently active worktrees;"
+      echo "                  --all-repos: every repository auto-worktree has run in;"
+      echo "                  --format <template>: one line per worktree, e.g. '{branch} {path}';"
+      echo "                  --count-only: just the number of worktrees;"
+      echo "                  --no-status: skip merge and sync checks for a faster list)"
+      echo "  status          Summarize worktrees: dirty, unpushed, stale, merged"
+      echo "  cleanup         Interactively clean up worktrees (--force: include dirty ones,"
+      echo "                  --include-detached: include detached-HEAD ones by age)"
+      echo "  remove <target> Remove a worktree by branch name or path (keeps the branch;"
+      echo "                  --delete-branch to delete it, --force/-D if unmerged;"
+      echo "                  --interactive/-i: pick the worktree from a list)"
+      echo "  prune           Prune orphaned worktree references (--all: also remove merged worktrees)"
+      echo "  edit [<target>] Open a worktree in \$VISUAL/\$EDITOR (or auto-worktree.editor)"
+      echo "  rename [<old>] <new> Rename a worktree's branch and move its directory to match"
+      echo "  lock [<target>] Lock a worktree so cleanup, prune and list never remove it"
+      echo "                  (--reason <text>); unlock [<target>] to undo"
+      echo "  sessions        List worktrees with use counts and source issues"
+      echo "                  (keep [--off] [<branch>]: never report a worktree as stale,"
+      echo "                  whatever its age)"
+      echo "                  edit, rename, lock, unlock and sessions keep default to the"
+      echo "                  current worktree"
+      echo "  grep <pattern>  Search all worktrees (--branch NAME, -i, --)"
+      echo "  settings        Configure per-repository settings (export/--json: print as JSON;"
+      echo "                  import <file>: apply an exported file)"
+      echo "  doctor          Run repository diagnostics (--check-config; --repair: fix"
+      echo "                  worktree registrations after moving directories; --self-test:"
+      echo "                  time git and provider CLI calls)"
+      echo "  version         Print the version (--json: also git and gh versions, for tooling)"
       echo ""
       echo "Run without arguments for interactive menu."
       echo ""
+      echo "Exit Codes:"
+      echo "  0 success, 1 general error, 2 usage error, 3 not a git repository,"
+      echo "  4 issue provider/auth failure, 5 branch or worktree already exists,"
+      echo "  6 repository locked by another auto-worktree process, 130 cancelled"
+      echo ""
+      echo "Global Flags:"
+      echo "  --quiet            Suppress decorative output; print only essential results"
+      echo "                     (e.g. the created worktree path). Errors go to stderr."
+      echo "  --events           Report worktree creation as NDJSON on stdout, one line per"
+      echo "                     phase (implies --quiet; the AI tool is not started)"
+      echo "  --verbose, -V      Log each git and provider CLI call, its duration and exit"
+      echo "                     status to stderr (AW_LOG_LEVEL=info|warn|error to narrow)"
+      echo ""
+      echo "Issue Flags:"
+      echo "  --preview          Show the issue description before creating the worktree"
+      echo "  --milestone <name> Pick from the issues in a milestone/epic (name or ID)"
+      echo "  --all              With --milestone, create worktrees for every open issue"
+      echo ""
+      echo "PR Flags:"
+      echo "  --create           Open a PR/MR for the current branch (pushes it if needed)"
+      echo "  --draft            With --create, open it as a draft"
+      echo "  --suggest-reviewers  With --create, request reviews from CODEOWNERS (GitHub)"
+      echo ""
       echo "Create Issue Flags:"
       echo "  --title TEXT       Issue title (required for non-interactive mode)"
-      echo "  --body TEXT        Issue description/body"
-      echo "  --template PATH    Path to template file to use"
+      echo "  --body TEXT        Issue description/body (read from stdin when piped)"
+      echo "  --template NAME    Template name (e.g. bug_report) or path to a template file"
       echo "  --no-template      Skip template selection"
       echo "  --no-worktree      Don't offer to create worktree after issue creation"
+      echo "  --label NAME       Apply a label (GitHub; repeatable, offers to create missing ones)"
+      echo "  --assignee USER    Assign the issue (repeatable; @me for yourself)"
       echo ""
       echo "Configuration:"
       echo "  First time using issues? Run 'auto-worktree issue' to configure"
       echo "  your issue provider (GitHub, GitLab, JIRA, or Linear) for this repository."
       echo ""
+      echo "Environment:"
+      echo "  AW_ISSUE_PROVIDER  Override auto-worktree.issue-provider"
+      echo "  AW_WORKTREE_BASE   Override auto-worktree.worktree-base (default ~/worktrees)"
+      echo "  AW_NO_CACHE=1      Don't reuse the cached GitHub owner/repo lookup"
+      echo "  AW_CLI_RETRIES     Attempts for JIRA/Linear calls hitting network errors (default 3)"
+      echo "  AW_CLI_RETRY_DELAY Seconds before the first retry, doubling each time (default 1)"
+      echo "  Precedence: environment > git config --local > git config --global > defaults"
+      echo ""
       gum style --foreground 3 --bold "⚠️  SAFETY WARNING"
       echo ""
       echo "Worktrees are safe, but git is NOT designed for concurrent operations."
@@ -141,7 +335,7 @@ auto-worktree() {
     *)
       gum style --foreground 1 "Unknown command: $1"
       echo "Run 'auto-worktree help' for usage"
-      return 1
+      return $AW_EXIT_USAGE
       ;;
   esac
 }
@@ -171,6 +365,9 @@ elif [[ -n "$BASH_VERSION" ]]; then
   fi
 fi
 
+# Remembered for `version` in source checkouts
+_AW_INSTALL_DIR="$_AW_SCRIPT_DIR"
+
 # Clean up temporary variables
 unset _AW_SCRIPT_DIR
 unset _AW_SRC_DIR
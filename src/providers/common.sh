@@ -16,6 +16,118 @@ _aw_format_labels() {
   echo  # trailing newline
 }
 
+# Labels kept on a compacted issue row; the rest become "+N more"
+_AW_ISSUE_ROW_MAX_LABELS=3
+
+_aw_compact_issue_line() {
+  # Fit one issue list row into a width by capping its labels and shortening
+  # its title. The ID is never cut, so a selection still resolves.
+  # Rows that already fit are left alone.
+  # Usage: _aw_compact_issue_line line width
+  local line="$1"
+  local width="$2"
+  if [[ ${#line} -le $width ]] || [[ "$line" != *" | "* ]]; then
+    echo "$line"
+    return 0
+  fi
+
+  local id="${line%% | *}"
+  local title="${line#* | }"
+  local labels=""
+  if [[ "$title" == *" | ["*"]" ]]; then
+    labels="${title##* | }"
+    title="${title% | *}"
+  fi
+
+  local suffix=""
+  if [[ -n "$labels" ]]; then
+    local shown=""
+    local count=0
+    local label
+    while IFS= read -r label; do
+      [[ -z "$label" ]] && continue
+      count=$((count + 1))
+      [[ $count -le $_AW_ISSUE_ROW_MAX_LABELS ]] && shown+="${shown:+ }$label"
+    done <<< "$(printf '%s' "$labels" | grep -o '\[[^]]*\]')"
+    [[ $count -gt $_AW_ISSUE_ROW_MAX_LABELS ]] && shown+=" +$((count - _AW_ISSUE_ROW_MAX_LABELS)) more"
+    suffix=" | $shown"
+  fi
+
+  local title_width=$((width - ${#id} - 3 - ${#suffix}))
+  [[ $title_width -lt 10 ]] && title_width=10
+  echo "$id | $(_aw_truncate "$title" "$title_width")$suffix"
+}
+
+_aw_label_colors_file() {
+  # Temp file for a provider's issue list call to save label colors to, or
+  # nothing when labels won't be colored. GitHub and Linear report colors
+  # with the issues; callers keep the path in _AW_LABEL_COLORS_FILE.
+  # Usage: _aw_label_colors_file provider
+  [[ "$1" == "github" || "$1" == "linear" ]] || return 0
+  _aw_color_enabled || return 0
+  [[ "$(git config --get --bool auto-worktree.issue-list-labels 2>/dev/null)" != "false" ]] || return 0
+  mktemp "${TMPDIR:-/tmp}/aw-labels.XXXXXX" 2>/dev/null
+  return 0
+}
+
+_aw_take_label_colors() {
+  # Print the label colors saved to _AW_LABEL_COLORS_FILE and remove the file
+  [[ -n "${_AW_LABEL_COLORS_FILE:-}" ]] || return 0
+  cat "$_AW_LABEL_COLORS_FILE" 2>/dev/null
+  rm -f "$_AW_LABEL_COLORS_FILE"
+}
+
+_aw_colorize_issue_labels() {
+  # Draw the trailing [label] chips of each issue row in the label's color
+  # Labels without a known color, and titles, are left as they are
+  # Usage: _aw_colorize_issue_labels issues label_colors
+  # label_colors is "name<TAB>hex color" per line
+  local issues="$1"
+  local label_colors="$2"
+  local sgr_map="" name color sgr
+
+  while IFS=$'\t' read -r name color; do
+    [[ -z "$name" ]] && continue
+    sgr=$(_aw_label_chip_sgr "$color") || continue
+    sgr_map+="${name}"$'\t'"${sgr}"$'\n'
+  done <<< "$label_colors"
+
+  if [[ -z "$sgr_map" ]]; then
+    printf '%s' "$issues"
+    return 0
+  fi
+
+  printf '%s' "$issues" | awk -F'\t' -v esc=$'\033' '
+    NR == FNR { sgr[$1] = $2; next }
+    {
+      line = $0
+      # Labels are the last " | " field, made only of [label] chips
+      last = 0; rest = line
+      while ((i = index(rest, " | ")) > 0) { last += i + 2; rest = substr(rest, i + 3) }
+      if (last == 0 || rest !~ /^( ?\[[^]]*\])+( \+[0-9]+ more)?$/) { print line; next }
+      out = ""
+      while (match(rest, /\[[^]]*\]/)) {
+        chip = substr(rest, RSTART, RLENGTH)
+        name = substr(chip, 2, RLENGTH - 2)
+        if (name in sgr) chip = esc "[" sgr[name] "m" chip esc "[0m"
+        out = out substr(rest, 1, RSTART - 1) chip
+        rest = substr(rest, RSTART + RLENGTH)
+      }
+      print substr(line, 1, last) out rest
+    }
+  ' <(printf '%s' "$sgr_map") -
+}
+
+_aw_compact_issue_list() {
+  # Apply _aw_compact_issue_line to every row of an issue list
+  # Usage: _aw_compact_issue_list issues width
+  local issue_line
+  while IFS= read -r issue_line; do
+    [[ -z "$issue_line" ]] && continue
+    _aw_compact_issue_line "$issue_line" "$2"
+  done <<< "$1"
+}
+
 _aw_extract_issue_number() {
   # Extract issue number from branch name patterns like:
   # work/123-description, issue-123, 123-fix-something
@@ -156,23 +268,294 @@ _aw_check_branch_pr_merged() {
     gitlab)  _aw_gitlab_check_mr_merged "$branch_name" ;;
     jira|linear)
       # JIRA/Linear don't host PRs — check if the branch is merged into default via git
-      local default_branch
-      default_branch=$(_aw_get_default_branch)
-      if [[ -z "$default_branch" ]]; then
-        return 1
+      _aw_branch_merged_into_default "$branch_name"
+      ;;
+    *)       return 1 ;;
+  esac
+}
+
+_aw_issue_finished_state() {
+  # Echo how an issue was finished, in the provider's words ("merged" or
+  # "closed" for GitHub, "closed" for GitLab, "resolved" for JIRA,
+  # "completed" for Linear), or nothing while it's still open
+  local issue_id="$1"
+  local provider="${2:-$(_aw_get_issue_provider)}"
+
+  [[ -z "$issue_id" ]] && return 0
+
+  case "$provider" in
+    github)
+      if _aw_check_issue_merged "$issue_id" "$provider"; then
+        echo "merged"
+      elif _aw_check_issue_closed "$issue_id" "$provider"; then
+        echo "closed"
       fi
-      # Returns 0 if branch_name is an ancestor of (i.e. merged into) default_branch
-      git merge-base --is-ancestor "$branch_name" "$default_branch" 2>/dev/null
       ;;
+    gitlab)  _aw_gitlab_check_closed "$issue_id" && echo "closed" ;;
+    jira)    _aw_jira_check_resolved "$issue_id" && echo "resolved" ;;
+    linear)  _aw_linear_check_completed "$issue_id" && echo "completed" ;;
+  esac
+  return 0
+}
+
+_aw_branch_merged_into_default() {
+  # Returns 0 if branch_name is an ancestor of (i.e. merged into) the default
+  # branch, according to local git history only
+  local branch_name="$1"
+  [[ -z "$branch_name" ]] && return 1
+
+  local default_branch
+  default_branch=$(_aw_get_default_branch)
+  if [[ -z "$default_branch" ]]; then
+    return 1
+  fi
+  git merge-base --is-ancestor "$branch_name" "$default_branch" 2>/dev/null
+}
+
+_aw_list_issues() {
+  # List open issues for the given provider in the canonical issue format
+  # Dispatches to the provider-specific implementation.
+  local provider="${1:-$(_aw_get_issue_provider)}"
+
+  case "$provider" in
+    github)  _aw_github_list_issues ;;
+    gitlab)  _aw_gitlab_list_issues ;;
+    jira)    _aw_jira_list_issues ;;
+    linear)  _aw_linear_list_issues ;;
+    *)       return 1 ;;
+  esac
+}
+
+_aw_get_issue_details() {
+  # Fetch an issue's title and body for the given provider
+  # Sets variables: title, body (description), url (may be empty)
+  # Dispatches to the provider-specific implementation.
+  local issue_id="$1"
+  local provider="${2:-$(_aw_get_issue_provider)}"
+
+  if [[ -z "$issue_id" ]]; then
+    return 1
+  fi
+
+  case "$provider" in
+    github)  _aw_github_get_issue_details "$issue_id" ;;
+    gitlab)  _aw_gitlab_get_issue_details "$issue_id" ;;
+    jira)    _aw_jira_get_issue_details "$issue_id" ;;
+    linear)  _aw_linear_get_issue_details "$issue_id" ;;
+    *)       return 1 ;;
+  esac
+}
+
+_aw_detect_issue_type() {
+  # Classify a user-supplied issue ID for the configured provider
+  # Numeric IDs belong to GitHub/GitLab, KEY-123 style IDs to JIRA/Linear.
+  # Whichever family the configured provider is in wins; otherwise the
+  # format decides (KEY-123 falls back to JIRA for backwards compatibility).
+  # Returns 1 if the ID matches neither format.
+  local issue_id="$1"
+  local provider="$2"
+
+  if [[ "$issue_id" =~ ^[A-Z][A-Z0-9]*-[0-9]+$ ]]; then
+    case "$provider" in
+      jira|linear) echo "$provider" ;;
+      *)           echo "jira" ;;
+    esac
+  elif [[ "$issue_id" =~ ^#?[0-9]+$ ]]; then
+    case "$provider" in
+      github|gitlab) echo "$provider" ;;
+      *)             echo "github" ;;
+    esac
+  else
+    return 1
+  fi
+}
+
+_aw_provider_display_name() {
+  # Human-readable provider name for messages
+  local provider="$1"
+
+  case "$provider" in
+    gitlab) echo "GitLab" ;;
+    jira)   echo "JIRA" ;;
+    linear) echo "Linear" ;;
+    *)      echo "GitHub" ;;
+  esac
+}
+
+_aw_format_issue_ref() {
+  # Format an issue ID the way the provider displays it
+  # GitHub/GitLab = #123, JIRA/Linear = KEY-123
+  local issue_id="$1"
+  local provider="$2"
+
+  case "$provider" in
+    jira|linear) echo "$issue_id" ;;
+    *)           echo "#${issue_id#\#}" ;;
+  esac
+}
+
+_aw_issue_branch_suffix() {
+  # Return the issue-identifying part used in branch names (work/<suffix>-title)
+  # GitHub/GitLab use the bare number, JIRA/Linear the issue key.
+  local issue_id="$1"
+  local provider="$2"
+
+  case "$provider" in
+    jira|linear) echo "$issue_id" ;;
+    *)           echo "${issue_id#\#}" ;;
+  esac
+}
+
+_aw_provider_supports_prs() {
+  # Returns 0 if the provider hosts pull/merge requests
+  # JIRA and Linear are issue trackers only.
+  local provider="$1"
+
+  case "$provider" in
+    github|gitlab) return 0 ;;
+    *)             return 1 ;;
+  esac
+}
+
+_aw_get_pr_provider() {
+  # Return the code-hosting provider used for PRs/MRs
+  # JIRA and Linear only track issues, so their repositories (and
+  # unconfigured ones) are assumed to be hosted on GitHub.
+  local provider="${1:-$(_aw_get_issue_provider)}"
+
+  if _aw_provider_supports_prs "$provider"; then
+    echo "$provider"
+  else
+    echo "github"
+  fi
+}
+
+_aw_pr_term() {
+  # Provider-specific name for a pull request: PR (GitHub) or MR (GitLab)
+  local provider="$1"
+
+  case "$provider" in
+    gitlab) echo "MR" ;;
+    *)      echo "PR" ;;
+  esac
+}
+
+_aw_format_pr_ref() {
+  # Format a PR/MR number the way the provider displays it: #123 or !123
+  local pr_num="$1"
+  local provider="$2"
+
+  case "$provider" in
+    gitlab) echo "!${pr_num}" ;;
+    *)      echo "#${pr_num}" ;;
+  esac
+}
+
+_aw_list_prs() {
+  # List open PRs/MRs for the given provider
+  # Output format: #NUMBER | CHECKS | Title | ... | head-branch
+  # Dispatches to the provider-specific implementation.
+  # Args: $1 = provider, $2 = author filter, $3 = excluded author (GitHub only)
+  local provider="$1"
+
+  case "$provider" in
+    github)  _aw_github_list_prs "${2:-}" "${3:-}" ;;
+    gitlab)  _aw_gitlab_list_mrs "${2:-}" ;;
+    *)       return 1 ;;
+  esac
+}
+
+_aw_get_pr_details() {
+  # Fetch a PR/MR's details for the given provider
+  # Sets variables: title, body, head_ref, base_ref, author (and is_fork for GitHub)
+  # Dispatches to the provider-specific implementation.
+  local pr_num="$1"
+  local provider="$2"
+
+  if [[ -z "$pr_num" ]]; then
+    return 1
+  fi
+
+  case "$provider" in
+    github)  _aw_github_get_pr_details "$pr_num" ;;
+    gitlab)  _aw_gitlab_get_mr_details "$pr_num" ;;
     *)       return 1 ;;
   esac
 }
 
+_aw_get_pr_checks() {
+  # Summarize a PR/MR's CI checks as "<passed> <failed> <pending>"
+  # Only GitHub is supported; other providers return 1.
+  local pr_num="$1"
+  local provider="$2"
+
+  case "$provider" in
+    github)  _aw_github_get_pr_checks "$pr_num" ;;
+    *)       return 1 ;;
+  esac
+}
+
+_aw_create_pr() {
+  # Open a PR/MR for the current branch
+  # Args: $1 = provider, $2 = "true" to open it as a draft,
+  #       $3... = reviewers to request (GitHub only)
+  # Outputs the PR/MR URL. Dispatches to the provider-specific implementation.
+  local provider="$1"
+  local draft="${2:-false}"
+  shift $(( $# > 2 ? 2 : $# ))
+
+  case "$provider" in
+    github)  _aw_github_create_pr "$draft" "$@" ;;
+    gitlab)  _aw_gitlab_create_mr "$draft" ;;
+    *)       return 1 ;;
+  esac
+}
+
+# Default branch looked up by _aw_cache_default_branch, valid while
+# _AW_GIT_ROOT is still _AW_DEFAULT_BRANCH_CACHE_ROOT
+_AW_DEFAULT_BRANCH_CACHE=""
+_AW_DEFAULT_BRANCH_CACHE_ROOT=""
+
+_aw_cache_default_branch() {
+  # Look the default branch up once, so the per-worktree checks of batch
+  # commands (list, prune --all, cleanup...) don't each repeat the detection.
+  # Does nothing if this repository's branch is already cached; nothing is
+  # cached when no default branch is found.
+  if [[ -n "$_AW_DEFAULT_BRANCH_CACHE" ]] && [[ "$_AW_DEFAULT_BRANCH_CACHE_ROOT" == "${_AW_GIT_ROOT:-}" ]]; then
+    return 0
+  fi
+  _AW_DEFAULT_BRANCH_CACHE=$(_aw_get_default_branch || echo "")
+  _AW_DEFAULT_BRANCH_CACHE_ROOT="${_AW_GIT_ROOT:-}"
+}
+
+_aw_origin_is_github() {
+  # Returns 0 if origin is a repository on the GitHub host and gh is
+  # authenticated there
+  command -v gh >/dev/null 2>&1 || return 1
+  local url=$(git config --get remote.origin.url 2>/dev/null)
+  [[ -z "$url" ]] && return 1
+  _aw_github_parse_remote "$url" >/dev/null || return 1
+  gh auth status --hostname "$(_aw_get_github_host)" &>/dev/null
+}
+
 _aw_get_default_branch() {
-  # Detect the default branch (main or master)
+  # Detect the default branch: auto-worktree.default-branch, then origin/HEAD,
+  # then GitHub's answer via gh (GitHub origins only), then main/master, then
+  # the checked-out branch
   # Returns the branch name or empty string if not found
+  if [[ -n "$_AW_DEFAULT_BRANCH_CACHE" ]] && [[ "$_AW_DEFAULT_BRANCH_CACHE_ROOT" == "${_AW_GIT_ROOT:-}" ]]; then
+    echo "$_AW_DEFAULT_BRANCH_CACHE"
+    return 0
+  fi
+
+  # An explicit auto-worktree.default-branch wins over detection
+  local configured=$(_aw_get_config "default-branch")
+  if [[ -n "$configured" ]]; then
+    echo "$configured"
+    return 0
+  fi
 
-  # First try to get from remote
+  # Then try to get from remote
   local default_branch=$(git symbolic-ref refs/remotes/origin/HEAD 2>/dev/null | sed 's@^refs/remotes/origin/@@')
 
   if [[ -n "$default_branch" ]]; then
@@ -180,6 +563,17 @@ _aw_get_default_branch() {
     return 0
   fi
 
+  # origin/HEAD is missing when origin was added to an existing repository
+  # rather than cloned. Ask GitHub, but only when origin is hosted there and
+  # gh is logged in, so other remotes never wait on the network.
+  if _aw_origin_is_github; then
+    default_branch=$(gh repo view --json defaultBranchRef --jq '.defaultBranchRef.name' 2>/dev/null)
+    if [[ -n "$default_branch" ]]; then
+      echo "$default_branch"
+      return 0
+    fi
+  fi
+
   # Fallback: check if main or master exists locally
   if git show-ref --verify --quiet refs/heads/main 2>/dev/null; then
     echo "main"
@@ -198,6 +592,13 @@ _aw_get_default_branch() {
     return 0
   fi
 
+  # Local-only repository with an unusual default: use the checked-out branch
+  default_branch=$(git symbolic-ref --short HEAD 2>/dev/null)
+  if [[ -n "$default_branch" ]]; then
+    echo "$default_branch"
+    return 0
+  fi
+
   return 1
 }
 
@@ -255,3 +656,47 @@ _aw_check_no_changes_from_default() {
 
   return 1
 }
+
+# ============================================================================
+# Retrying provider CLI calls
+# ============================================================================
+
+# Error output worth retrying: network blips and overloaded servers, not
+# authentication or "issue not found" errors
+_AW_TRANSIENT_ERROR_PATTERN='timed out|timeout|connection (reset|refused)|temporary failure|could not resolve|network is unreachable|no such host|tls handshake|unexpected eof|bad gateway|service unavailable|gateway timeout|too many requests|rate limit|returned error: (429|5[0-9][0-9])'
+
+_aw_retry_cli() {
+  # Run a provider CLI, retrying transient failures with exponential backoff
+  # (like the repository lock, it waits with sleep rather than giving up).
+  # Stdout is printed once the command settles; stderr of the last attempt is
+  # passed through.
+  # AW_CLI_RETRIES: total attempts (default 3)
+  # AW_CLI_RETRY_DELAY: whole seconds before the first retry, doubled each time (default 1)
+  # Values that aren't whole numbers fall back to the defaults.
+  # Usage: _aw_retry_cli cmd [args...]
+  local attempts="${AW_CLI_RETRIES:-3}"
+  local delay="${AW_CLI_RETRY_DELAY:-1}"
+  [[ "$attempts" =~ ^[0-9]+$ ]] && attempts=$((10#$attempts)) || attempts=3
+  [[ "$delay" =~ ^[0-9]+$ ]] && delay=$((10#$delay)) || delay=1
+  local err_file
+  err_file=$(mktemp "${TMPDIR:-/tmp}/aw-cli.XXXXXX") || { "$@"; return $?; }
+
+  local attempt=1
+  local output exit_code
+  while true; do
+    output=$("$@" 2>"$err_file")
+    exit_code=$?
+    if [[ $exit_code -eq 0 ]] || [[ $attempt -ge $attempts ]] || \
+      ! grep -qiE "$_AW_TRANSIENT_ERROR_PATTERN" "$err_file"; then
+      break
+    fi
+    sleep "$delay"
+    delay=$((delay * 2))
+    attempt=$((attempt + 1))
+  done
+
+  [[ -n "$output" ]] && printf '%s\n' "$output"
+  cat "$err_file" >&2
+  rm -f "$err_file"
+  return $exit_code
+}
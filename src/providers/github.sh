@@ -4,19 +4,142 @@
 # GitHub integration
 # ============================================================================
 
-_aw_github_list_milestones() {
-  # List open GitHub milestones
-  # Output format: ID | Title | [N open] [N closed] [due: DATE]
-  local owner repo
-  owner=$(gh repo view --json owner --jq '.owner.login' 2>/dev/null)
-  repo=$(gh repo view --json name --jq '.name' 2>/dev/null)
+_aw_gh() {
+  # Run gh with its stdout and stderr kept apart, so warnings never end up in
+  # output that callers parse. On success stdout is echoed; on failure only
+  # gh's error message is echoed, to stderr.
+  # Returns gh's exit status
+  local err_file
+  err_file=$(mktemp "${TMPDIR:-/tmp}/aw-gh.XXXXXX") || return 1
+
+  local out
+  local gh_status=0
+  out=$(gh "$@" 2>"$err_file") || gh_status=$?
+  local err=$(sed '/^[[:space:]]*$/d' "$err_file")
+  rm -f "$err_file"
+
+  if [[ $gh_status -ne 0 ]]; then
+    if [[ -n "$err" ]]; then
+      echo "$err" >&2
+    else
+      echo "gh $1 failed (exit status $gh_status)" >&2
+    fi
+    return $gh_status
+  fi
+
+  [[ -n "$out" ]] && echo "$out"
+  return 0
+}
+
+_aw_github_parse_remote() {
+  # Parse a remote URL on the configured GitHub host (see _aw_get_github_host):
+  # https://<host>/owner/repo, git@<host>:owner/repo or ssh://git@<host>/owner/repo,
+  # each with or without .git
+  # Outputs "<host> <owner>/<repo>"; returns 1 for other hosts or formats
+  local url="$1"
+  local host=$(_aw_get_github_host)
+  local repo_path=""
+
+  case "$url" in
+    https://"$host"/*|http://"$host"/*) repo_path="${url#*://"$host"/}" ;;
+    git@"$host":*)                      repo_path="${url#git@"$host":}" ;;
+    ssh://git@"$host"/*)                repo_path="${url#ssh://git@"$host"/}" ;;
+    *)                                  return 1 ;;
+  esac
+
+  repo_path="${repo_path%/}"
+  repo_path="${repo_path%.git}"
+  if [[ ! "$repo_path" =~ ^[^/]+/[^/]+$ ]]; then
+    return 1
+  fi
+
+  echo "$host $repo_path"
+}
+
+_aw_github_remote_url() {
+  # Echo the URL of the remote that names the GitHub repository: the one in
+  # auto-worktree.github-remote (default origin), else origin, else the first
+  # remote. Fork workflows set github-remote to upstream.
+  # Returns 1 if the repository has no remotes
+  local preferred=$(_aw_get_config "github-remote")
+  local remote url
+  for remote in "${preferred:-origin}" origin "$(git remote 2>/dev/null | head -n 1)"; do
+    [[ -z "$remote" ]] && continue
+    if url=$(git config --get "remote.${remote}.url" 2>/dev/null) && [[ -n "$url" ]]; then
+      echo "$url"
+      return 0
+    fi
+  done
+  return 1
+}
+
+_aw_github_repo_flag() {
+  # Echo "--repo <host>/<owner>/<repo>" for gh issue commands when
+  # auto-worktree.github-remote is set, so issues come from that remote's
+  # repository rather than whichever one gh picks. Echoes nothing otherwise.
+  [[ -n "$(_aw_get_config "github-remote")" ]] || return 0
+  local parsed
+  parsed=$(_aw_github_parse_remote "$(_aw_github_remote_url)") || return 0
+  echo "--repo ${parsed%% *}/${parsed#* }"
+}
+
+# Seconds a detected owner/repo is reused before asking gh again
+_AW_GITHUB_REPO_CACHE_TTL=300
+
+_aw_github_repo_slug() {
+  # Echo "owner/repo" for the current repository.
+  # gh repo view is a network round trip, so the result is cached in the
+  # common git dir (shared by all worktrees) for _AW_GITHUB_REPO_CACHE_TTL
+  # seconds and discarded when the remote's URL changes (see
+  # _aw_github_remote_url for which remote is used).
+  # Set AW_NO_CACHE=1 to bypass the cache.
+  local remote_url=$(_aw_github_remote_url)
+
+  # A remote on the GitHub host already names the repository
+  local parsed
+  if parsed=$(_aw_github_parse_remote "$remote_url"); then
+    echo "${parsed#* }"
+    return 0
+  fi
 
-  if [[ -z "$owner" ]] || [[ -z "$repo" ]]; then
+  local common_dir=$(_aw_git_common_dir 2>/dev/null)
+  local cache_file=""
+  [[ -n "$common_dir" ]] && cache_file="$common_dir/auto-worktree-github-repo"
+  local now=$(date +%s)
+
+  if [[ "${AW_NO_CACHE:-}" != "1" ]] && [[ -n "$cache_file" ]] && [[ -f "$cache_file" ]]; then
+    local cached_url cached_at cached_slug
+    IFS=$'\t' read -r cached_url cached_at cached_slug < "$cache_file"
+    if [[ "$cached_url" == "$remote_url" ]] && [[ "$cached_at" =~ ^[0-9]+$ ]] && \
+      [[ $((now - cached_at)) -lt $_AW_GITHUB_REPO_CACHE_TTL ]] && [[ -n "$cached_slug" ]]; then
+      echo "$cached_slug"
+      return 0
+    fi
+  fi
+
+  local slug
+  slug=$(gh repo view --json owner,name --jq '.owner.login + "/" + .name' 2>/dev/null)
+  if [[ "$slug" != */* ]]; then
     return 1
   fi
 
-  gh api "repos/$owner/$repo/milestones" --jq '.[] | select(.state == "open")' 2>/dev/null | \
-    jq -r '[.number, .title, .open_issues, .closed_issues, .due_on // ""] | @tsv' | \
+  if [[ -n "$cache_file" ]]; then
+    printf '%s\t%s\t%s\n' "$remote_url" "$now" "$slug" > "$cache_file" 2>/dev/null
+  fi
+  echo "$slug"
+}
+
+_aw_github_list_milestones() {
+  # List open GitHub milestones
+  # Output format: ID | Title | [N open] [N closed] [due: DATE]
+  local slug
+  slug=$(_aw_github_repo_slug) || return 1
+  local limit=$(_aw_get_issue_list_limit)
+
+  # The API leaves closed milestones out, and gh's --jq reduces each one to
+  # the fields shown, so there's no separate jq pass over the full response
+  gh api --hostname "$(_aw_get_github_host)" "repos/$slug/milestones?state=open&per_page=$limit" \
+    --jq ".[:$limit][] | select(.state == \"open\") | [.number, .title, .open_issues, .closed_issues, .due_on // \"\"] | @tsv" 2>/dev/null | \
     while IFS=$'\t' read -r number title open_count closed_count due_on; do
       local labels=""
       if [[ "$open_count" -gt 0 ]] || [[ "$closed_count" -gt 0 ]]; then
@@ -29,18 +152,73 @@ _aw_github_list_milestones() {
     done
 }
 
+_aw_github_list_labels() {
+  # List the repository's issue labels, one name per line
+  # Returns 1 if gh can't list them
+  local labels
+  labels=$(gh label list $(_aw_github_repo_flag) --limit 500 --json name --jq '.[].name' 2>/dev/null) || return 1
+  [[ -n "$labels" ]] && echo "$labels"
+  return 0
+}
+
+_aw_github_create_label() {
+  # Create an issue label with gh's default color
+  # Args: $1 = label name
+  _aw_gh label create $(_aw_github_repo_flag) "$1" >/dev/null
+}
+
+_aw_github_issue_list_fields() {
+  # JSON fields requested when listing issues. Labels are a list of objects
+  # (id, name, description, color) per issue and make up most of the payload,
+  # so auto-worktree.issue-list-labels=false leaves them out.
+  if [[ "$(git config --get --bool auto-worktree.issue-list-labels 2>/dev/null)" == "false" ]]; then
+    echo "number,title"
+  else
+    echo "number,title,labels"
+  fi
+}
+
 _aw_github_list_issues() {
-  # List open GitHub issues
-  # Output format: #NUMBER | Title | [label1][label2]
+  # List open GitHub issues, or open and closed ones (marked "(closed)") when
+  # _AW_ISSUE_INCLUDE_CLOSED is true (issue --include-closed)
+  # Output format: #NUMBER | Title | [label1][label2] (labels only when requested)
+  # No output with status 0 means there are no open issues; if gh fails, its
+  # error goes to stderr and its exit status is returned.
   local project="${1:-}"
+  local state="open"
+  local fields=$(_aw_github_issue_list_fields)
+  local closed_mark=""
+  if [[ "${_AW_ISSUE_INCLUDE_CLOSED:-false}" == "true" ]]; then
+    state="all"
+    fields+=",state"
+    closed_mark='{{if eq .state "CLOSED"}} (closed){{end}}'
+  fi
+
+  # Label colors come with the labels, so read them from the same JSON
+  if [[ -n "${_AW_LABEL_COLORS_FILE:-}" ]] && [[ "$fields" == *labels* ]]; then
+    local issues_json
+    issues_json=$(_aw_gh issue list $(_aw_github_repo_flag) --limit "$(_aw_get_issue_list_limit)" --state "$state" --json "$fields") || return
+    _aw_github_format_issue_list "$issues_json"
+    return
+  fi
 
-  gh issue list --limit 100 --state open --json number,title,labels \
-    --template '{{range .}}#{{.number}} | {{.title}}{{if .labels}} |{{range .labels}} [{{.name}}]{{end}}{{end}}{{"\n"}}{{end}}' 2>/dev/null || true
+  _aw_gh issue list $(_aw_github_repo_flag) --limit "$(_aw_get_issue_list_limit)" --state "$state" --json "$fields" \
+    --template '{{range .}}#{{.number}} | {{.title}}'"$closed_mark"'{{if .labels}} |{{range .labels}} [{{.name}}]{{end}}{{end}}{{"\n"}}{{end}}'
+}
+
+_aw_github_format_issue_list() {
+  # Format `gh issue list --json` output like the --template rows, saving each
+  # label's "name<TAB>color" to _AW_LABEL_COLORS_FILE
+  # Usage: _aw_github_format_issue_list issues_json
+  printf '%s' "$1" | jq -r '[.[].labels[]?] | unique_by(.name)[] | "\(.name)\t\(.color)"' >> "$_AW_LABEL_COLORS_FILE"
+  printf '%s' "$1" | jq -r '.[] | "#\(.number) | \(.title)"
+    + (if .state == "CLOSED" then " (closed)" else "" end)
+    + (if (.labels // []) | length > 0 then " |" + ([.labels[] | " [\(.name)]"] | join("")) else "" end)'
 }
 
 _aw_github_get_issue_details() {
   # Get GitHub issue details
-  # Sets variables: title, body (description)
+  # Sets variables: title, body (description), url
   local issue_id="$1"
 
   if [[ -z "$issue_id" ]]; then
@@ -52,7 +230,7 @@ _aw_github_get_issue_details() {
 
   # Get issue details in JSON format
   local issue_json
-  issue_json=$(gh issue view "$number" --json number,title,body,state,labels 2>/dev/null)
+  issue_json=$(gh issue view $(_aw_github_repo_flag) "$number" --json number,title,body,state,labels,url 2>/dev/null)
 
   if [[ -z "$issue_json" ]]; then
     return 1
@@ -61,6 +239,7 @@ _aw_github_get_issue_details() {
   # Extract title and body using jq
   title=$(echo "$issue_json" | jq -r '.title // ""')
   body=$(echo "$issue_json" | jq -r '.body // ""')
+  url=$(echo "$issue_json" | jq -r '.url // ""')
 
   return 0
 }
@@ -79,7 +258,7 @@ _aw_github_check_closed() {
   local number="${issue_num#\#}"
 
   local issue_state
-  issue_state=$(gh issue view "$number" --json state --jq '.state' 2>/dev/null)
+  issue_state=$(gh issue view $(_aw_github_repo_flag) "$number" --json state --jq '.state' 2>/dev/null)
 
   if [[ "$issue_state" != "CLOSED" ]]; then
     return 1
@@ -87,7 +266,7 @@ _aw_github_check_closed() {
 
   # Check if there's an open PR that references this issue
   local open_prs
-  open_prs=$(gh pr list --state open --search "closes #$number OR fixes #$number OR resolves #$number" --json number --jq 'length' 2>/dev/null)
+  open_prs=$(gh pr list $(_aw_github_repo_flag) --state open --search "closes #$number OR fixes #$number OR resolves #$number" --json number --jq 'length' 2>/dev/null)
 
   if [[ "$open_prs" -gt 0 ]] 2>/dev/null; then
     _AW_ISSUE_HAS_PR=true
@@ -112,7 +291,7 @@ _aw_github_check_issue_merged() {
 
   # First check if issue is closed
   local issue_state
-  issue_state=$(gh issue view "$number" --json state --jq '.state' 2>/dev/null)
+  issue_state=$(gh issue view $(_aw_github_repo_flag) "$number" --json state --jq '.state' 2>/dev/null)
 
   if [[ "$issue_state" != "CLOSED" ]]; then
     return 1
@@ -121,7 +300,7 @@ _aw_github_check_issue_merged() {
   # Check if there's a linked PR that was merged
   # GitHub's stateReason can tell us if it was completed (often means PR merged)
   local state_reason
-  state_reason=$(gh issue view "$number" --json stateReason --jq '.stateReason' 2>/dev/null)
+  state_reason=$(gh issue view $(_aw_github_repo_flag) "$number" --json stateReason --jq '.stateReason' 2>/dev/null)
 
   if [[ "$state_reason" == "COMPLETED" ]]; then
     return 0
@@ -129,7 +308,7 @@ _aw_github_check_issue_merged() {
 
   # Also check for PRs that reference this issue and are merged
   local merged_prs
-  merged_prs=$(gh pr list --state merged --search "closes #$number OR fixes #$number OR resolves #$number" --json number --jq 'length' 2>/dev/null)
+  merged_prs=$(gh pr list $(_aw_github_repo_flag) --state merged --search "closes #$number OR fixes #$number OR resolves #$number" --json number --jq 'length' 2>/dev/null)
 
   if [[ "$merged_prs" -gt 0 ]] 2>/dev/null; then
     return 0
@@ -167,7 +346,213 @@ _aw_github_list_issues_by_milestone() {
     return 1
   fi
 
-  gh issue list --milestone "$milestone_title" --limit 100 --state open --json number,title,labels \
+  local fields=$(_aw_github_issue_list_fields)
+  if [[ -n "${_AW_LABEL_COLORS_FILE:-}" ]] && [[ "$fields" == *labels* ]]; then
+    local issues_json
+    issues_json=$(gh issue list $(_aw_github_repo_flag) --milestone "$milestone_title" --limit "$(_aw_get_issue_list_limit)" --state open --json "$fields" 2>/dev/null) || return
+    _aw_github_format_issue_list "$issues_json"
+    return
+  fi
+
+  gh issue list $(_aw_github_repo_flag) --milestone "$milestone_title" --limit "$(_aw_get_issue_list_limit)" --state open --json "$fields" \
     --template '{{range .}}#{{.number}} | {{.title}}{{if .labels}} |{{range .labels}} [{{.name}}]{{end}}{{end}}{{"\n"}}{{end}}' 2>/dev/null
 }
 
+_aw_github_list_prs() {
+  # List open GitHub PRs with the details used for display and AI selection
+  # Output format: #NUMBER | CHECKS | Title | @author [ | [labels]] | +ADD/-DEL | reviews:N [ | requested:[..]] | headRefName
+  # The trailing headRefName is stripped before display.
+  # Args: $1 = only PRs by this login (optional), $2 = leave out PRs by this
+  # login (optional); both accept @me
+  local author="${1:-}"
+  local not_author="${2:-}"
+  local filter_args=()
+  [[ -n "$author" ]] && filter_args+=(--author "$author")
+  [[ -n "$not_author" ]] && filter_args+=(--search "-author:$not_author")
+
+  gh pr list --limit "$(_aw_get_issue_list_limit)" --state open "${filter_args[@]}" --json number,title,author,headRefName,baseRefName,labels,statusCheckRollup,reviews,additions,deletions,reviewRequests 2>/dev/null | \
+    jq -r '.[] | "#\(.number) | \(
+      if (.statusCheckRollup | length == 0) then "○"
+      elif (.statusCheckRollup | all(.state == "SUCCESS")) then "✓"
+      elif (.statusCheckRollup | any(.state == "FAILURE" or .state == "ERROR")) then "✗"
+      else "○"
+      end
+    ) | \(.title) | @\(.author.login)\(
+      if (.labels | length > 0) then " |" + ([.labels[].name] | map(" [\(.)]") | join(""))
+      else ""
+      end
+    ) | +\(.additions)/-\(.deletions) | \(
+      if (.reviews | length) > 0 then "reviews:\(.reviews | length)"
+      else "reviews:0"
+      end
+    )\(
+      if (.reviewRequests | length) > 0 then " | requested:[" + ([.reviewRequests[].login] | join(",")) + "]"
+      else ""
+      end
+    ) | \(.headRefName)"'
+}
+
+_aw_github_get_pr_details() {
+  # Get GitHub PR details
+  # Sets variables: title, body, head_ref, base_ref, author, is_fork
+  local pr_num="${1#\#}"
+
+  if [[ -z "$pr_num" ]]; then
+    return 1
+  fi
+
+  local pr_json
+  pr_json=$(gh pr view "$pr_num" --json number,title,body,headRefName,baseRefName,author,isCrossRepository 2>/dev/null)
+
+  if [[ -z "$pr_json" ]]; then
+    return 1
+  fi
+
+  title=$(echo "$pr_json" | jq -r '.title // ""')
+  body=$(echo "$pr_json" | jq -r '.body // ""')
+  head_ref=$(echo "$pr_json" | jq -r '.headRefName // ""')
+  base_ref=$(echo "$pr_json" | jq -r '.baseRefName // ""')
+  author=$(echo "$pr_json" | jq -r '.author.login // ""')
+  is_fork=$(echo "$pr_json" | jq -r 'if .isCrossRepository then "true" else "false" end')
+
+  return 0
+}
+
+_aw_github_get_pr_checks() {
+  # Summarize a PR's CI checks
+  # Outputs "<passed> <failed> <pending>"; returns 1 if checks can't be fetched.
+  # gh pr checks exits non-zero while checks fail or are pending, so only the
+  # output is trusted.
+  local pr_num="${1#\#}"
+
+  if [[ -z "$pr_num" ]]; then
+    return 1
+  fi
+
+  local checks_json
+  checks_json=$(gh pr checks "$pr_num" --json name,state,bucket 2>/dev/null)
+
+  if [[ -z "$checks_json" ]]; then
+    return 1
+  fi
+
+  echo "$checks_json" | jq -r '[.[].bucket] |
+    "\(map(select(. == "pass" or . == "skipping")) | length) \(map(select(. == "fail" or . == "cancel")) | length) \(map(select(. == "pending")) | length)"'
+}
+
+_aw_github_create_pr() {
+  # Open a PR for the current branch, filling title/body from its commits
+  # Args: $1 = "true" to open it as a draft, $2... = reviewers to request
+  # Outputs the PR URL
+  local draft="${1:-false}"
+  shift
+
+  local args=(pr create --fill)
+  [[ "$draft" == "true" ]] && args+=(--draft)
+  local reviewer
+  for reviewer in "$@"; do
+    args+=(--reviewer "$reviewer")
+  done
+
+  local output
+  output=$(_aw_gh "${args[@]}") || return 1
+  echo "$output" | tail -1
+}
+
+_aw_codeowners_pattern_regex() {
+  # Translate a CODEOWNERS (gitignore-style) pattern into an extended regex
+  # matched against repository-relative paths
+  local pattern="$1"
+  local anchored=false
+
+  if [[ "$pattern" == /* ]]; then
+    anchored=true
+    pattern="${pattern#/}"
+  elif [[ "$pattern" == "**/"* ]]; then
+    pattern="${pattern#\*\*/}"
+  elif [[ "${pattern%/}" == */* ]]; then
+    # A slash anywhere but the end anchors the pattern to the root
+    anchored=true
+  fi
+
+  local dir_only=false
+  if [[ "$pattern" == */ ]]; then
+    dir_only=true
+    pattern="${pattern%/}"
+  fi
+
+  local regex
+  regex=$(printf '%s' "$pattern" | sed \
+    -e 's/[.+()|^${}]/\\&/g' \
+    -e 's#/\*\*/#@AW_ANY_DIRS@#g' \
+    -e 's#\*\*#@AW_ANY@#g' \
+    -e 's#\*#[^/]*#g' \
+    -e 's#?#[^/]#g' \
+    -e 's#@AW_ANY_DIRS@#/(.*/)?#g' \
+    -e 's#@AW_ANY@#.*#g')
+
+  local prefix="^(.*/)?"
+  [[ "$anchored" == "true" ]] && prefix="^"
+  local suffix="(/.*)?$"
+  [[ "$dir_only" == "true" ]] && suffix="/.*$"
+
+  echo "${prefix}${regex}${suffix}"
+}
+
+_aw_github_codeowners_reviewers() {
+  # Suggest reviewers for the current branch from CODEOWNERS: the owners of
+  # every file changed since base_ref (the last matching rule wins, as on
+  # GitHub). Email owners and the current user are left out since gh can't
+  # request them.
+  # Outputs one reviewer (login or org/team) per line
+  # Returns 1 if the repository has no CODEOWNERS file
+  local base_ref="$1"
+  local root=$(git rev-parse --show-toplevel 2>/dev/null)
+
+  local codeowners="" candidate
+  for candidate in .github/CODEOWNERS CODEOWNERS docs/CODEOWNERS; do
+    if [[ -f "$root/$candidate" ]]; then
+      codeowners="$root/$candidate"
+      break
+    fi
+  done
+  [[ -z "$codeowners" ]] && return 1
+
+  local range="origin/${base_ref}...HEAD"
+  git rev-parse --verify --quiet "origin/${base_ref}" >/dev/null || range="${base_ref}...HEAD"
+  local changed_files
+  changed_files=$(git diff --name-only "$range" 2>/dev/null)
+  [[ -z "$changed_files" ]] && return 0
+
+  local -a rule_regexes=()
+  local -a rule_owners=()
+  local pattern owners
+  while read -r pattern owners; do
+    [[ -z "$pattern" ]] || [[ "$pattern" == \#* ]] && continue
+    rule_regexes+=("$(_aw_codeowners_pattern_regex "$pattern")")
+    rule_owners+=("${owners%%#*}")
+  done < "$codeowners"
+
+  local self=$(gh api user --jq .login 2>/dev/null)
+  local reviewers=""
+  local file i owner
+  while IFS= read -r file; do
+    [[ -z "$file" ]] && continue
+    # ${array[@]:i:1} counts from 0 in both zsh and bash
+    i=$((${#rule_regexes[@]} - 1))
+    while [[ $i -ge 0 ]]; do
+      if printf '%s\n' "$file" | grep -Eq -- "${rule_regexes[@]:$i:1}"; then
+        for owner in $(echo "${rule_owners[@]:$i:1}"); do
+          [[ "$owner" == @* ]] || continue
+          owner="${owner#@}"
+          [[ -n "$self" ]] && [[ "$owner" == "$self" ]] && continue
+          printf '%s\n' "$reviewers" | grep -qxF -- "$owner" || reviewers+="$owner"$'\n'
+        done
+        break
+      fi
+      i=$((i - 1))
+    done
+  done <<< "$changed_files"
+
+  printf '%s' "$reviewers"
+}
@@ -51,7 +51,8 @@ _aw_gitlab_check_closed() {
 }
 
 _aw_gitlab_list_issues() {
-  # List GitLab issues
+  # List open GitLab issues, followed by closed ones (marked "(closed)") when
+  # _AW_ISSUE_INCLUDE_CLOSED is true (issue --include-closed)
   # Returns formatted issue list similar to GitHub issues
   if ! command -v glab &>/dev/null; then
     return 1
@@ -69,14 +70,33 @@ _aw_gitlab_list_issues() {
     project_args="--repo $project"
   fi
 
-  # List open issues with glab
-  $glab_cmd issue list --state opened --per-page 100 $project_args 2>/dev/null | \
-    awk -F'\t' '{
+  # glab's table has no state column, so closed issues are a second listing
+  local states=(opened)
+  [[ "${_AW_ISSUE_INCLUDE_CLOSED:-false}" == "true" ]] && states+=(closed)
+
+  local state
+  for state in "${states[@]}"; do
+    local marker=""
+    [[ "$state" == "closed" ]] && marker=" (closed)"
+    _aw_gitlab_list_issues_in_state "$glab_cmd" "$state" "$marker" $project_args
+  done
+}
+
+_aw_gitlab_list_issues_in_state() {
+  # List GitLab issues in one state, appending marker to each title
+  # Usage: _aw_gitlab_list_issues_in_state glab_cmd state marker [--repo project]
+  local glab_cmd="$1"
+  local state="$2"
+  local marker="$3"
+  shift 3
+
+  $glab_cmd issue list --state "$state" --per-page "$(_aw_get_issue_list_limit)" "$@" 2>/dev/null | \
+    awk -F'\t' -v marker="$marker" '{
       # glab output format: #NUMBER  TITLE  (LABELS)  (TIME)
       # Extract issue number, title, and labels
       if ($1 ~ /^#[0-9]+/) {
         number = $1
-        title = $2
+        title = $2 marker
         labels = $3
 
         # Remove surrounding parentheses from labels, e.g. "(foo, bar)" -> "foo, bar"
@@ -99,7 +119,7 @@ _aw_gitlab_list_issues() {
 
 _aw_gitlab_get_issue_details() {
   # Get GitLab issue details
-  # Sets variables: title, body (description)
+  # Sets variables: title, body (description), url
   local issue_id="$1"
 
   if [[ -z "$issue_id" ]]; then
@@ -111,7 +131,7 @@ _aw_gitlab_get_issue_details() {
   glab_cmd=$(_aw_gitlab_cmd)
 
   # Get issue details in JSON format
-  local issue_json=$($glab_cmd issue view "$issue_id" --json title,description 2>/dev/null)
+  local issue_json=$($glab_cmd issue view "$issue_id" --json title,description,web_url 2>/dev/null)
 
   if [[ -z "$issue_json" ]]; then
     return 1
@@ -120,13 +140,17 @@ _aw_gitlab_get_issue_details() {
   # Extract title and description using jq
   title=$(echo "$issue_json" | jq -r '.title // ""')
   body=$(echo "$issue_json" | jq -r '.description // ""')
+  url=$(echo "$issue_json" | jq -r '.web_url // ""')
 
   return 0
 }
 
 _aw_gitlab_list_mrs() {
-  # List GitLab merge requests
-  # Returns formatted MR list similar to GitHub PRs
+  # List open GitLab merge requests
+  # Output format matches _aw_github_list_prs: #NUMBER | ○ | Title | source-branch
+  # (glab's list output has no pipeline status, so checks are always pending)
+  # Args: $1 = only MRs by this username (optional)
+  local author="${1:-}"
   local project=$(_aw_get_gitlab_project)
 
   # Build glab command with server option if configured
@@ -140,29 +164,26 @@ _aw_gitlab_list_mrs() {
   fi
 
   # List open MRs with glab
-  $glab_cmd mr list --state opened --per-page 100 $project_args 2>/dev/null | \
+  local author_args=()
+  [[ -n "$author" ]] && author_args=(--author "$author")
+
+  $glab_cmd mr list --state opened --per-page "$(_aw_get_issue_list_limit)" "${author_args[@]}" $project_args 2>/dev/null | \
     awk -F'\t' '{
       # glab output format: !NUMBER  TITLE  (BRANCH)  (TIME)
-      # Extract MR number, title, and branch
       if ($1 ~ /^![0-9]+/) {
-        number = $1
+        number = substr($1, 2)  # Remove ! prefix
         title = $2
         branch = $3
-
-        # Format: !123 | Title | (branch-name)
-        printf "%s | %s", number, title
-        if (branch != "") {
-          printf " | %s", branch
-        }
-        printf "\n"
+        gsub(/[()]/, "", branch)  # Remove parentheses
+        printf "#%s | ○ | %s | %s\n", number, title, branch
       }
     }'
 }
 
 _aw_gitlab_get_mr_details() {
   # Get GitLab MR details
-  # Sets variables: title, body (description), source_branch, target_branch
-  local mr_id="$1"
+  # Sets variables: title, body, head_ref, base_ref, author
+  local mr_id="${1#\!}"
 
   if [[ -z "$mr_id" ]]; then
     return 1
@@ -173,7 +194,7 @@ _aw_gitlab_get_mr_details() {
   glab_cmd=$(_aw_gitlab_cmd)
 
   # Get MR details in JSON format
-  local mr_json=$($glab_cmd mr view "$mr_id" --json title,description,sourceBranch,targetBranch 2>/dev/null)
+  local mr_json=$($glab_cmd mr view "$mr_id" --json title,description,sourceBranch,targetBranch,author 2>/dev/null)
 
   if [[ -z "$mr_json" ]]; then
     return 1
@@ -182,12 +203,33 @@ _aw_gitlab_get_mr_details() {
   # Extract details using jq
   title=$(echo "$mr_json" | jq -r '.title // ""')
   body=$(echo "$mr_json" | jq -r '.description // ""')
-  source_branch=$(echo "$mr_json" | jq -r '.sourceBranch // ""')
-  target_branch=$(echo "$mr_json" | jq -r '.targetBranch // ""')
+  head_ref=$(echo "$mr_json" | jq -r '.sourceBranch // ""')
+  base_ref=$(echo "$mr_json" | jq -r '.targetBranch // ""')
+  author=$(echo "$mr_json" | jq -r '.author.username // .author.login // ""')
 
   return 0
 }
 
+_aw_gitlab_create_mr() {
+  # Open an MR for the current branch, filling title/description from its commits
+  # Args: $1 = "true" to open it as a draft
+  # Outputs the MR URL
+  local draft="${1:-false}"
+
+  local glab_cmd
+  glab_cmd=$(_aw_gitlab_cmd)
+
+  local draft_args=""
+  [[ "$draft" == "true" ]] && draft_args="--draft"
+
+  local output
+  if ! output=$($glab_cmd mr create --fill --yes $draft_args 2>&1); then
+    echo "$output" >&2
+    return 1
+  fi
+  echo "$output" | grep -Eo 'https?://[^ ]+' | tail -1
+}
+
 _aw_gitlab_list_milestones() {
   # List active GitLab milestones
   # Output format: IID | Title | [due: DATE]
@@ -210,7 +252,7 @@ _aw_gitlab_list_milestones() {
     return 1
   fi
 
-  $glab_cmd api "projects/$project_path/milestones?state=active" 2>/dev/null | \
+  $glab_cmd api "projects/$project_path/milestones?state=active&per_page=$(_aw_get_issue_list_limit)" 2>/dev/null | \
     jq -r '.[] | [.iid, .title, .due_date // ""] | @tsv' | \
     while IFS=$'\t' read -r iid title due_date; do
       local labels=""
@@ -243,7 +285,7 @@ _aw_gitlab_list_issues_by_milestone() {
   fi
 
   # shellcheck disable=SC2086
-  $glab_cmd issue list --milestone "$milestone_title" --state opened --per-page 100 $project_args 2>/dev/null | \
+  $glab_cmd issue list --milestone "$milestone_title" --state opened --per-page "$(_aw_get_issue_list_limit)" $project_args 2>/dev/null | \
     awk -F'\t' '{
       if ($1 ~ /^#[0-9]+/) {
         number = $1
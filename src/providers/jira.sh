@@ -17,7 +17,7 @@ _aw_jira_check_resolved() {
   fi
 
   # Get issue status using JIRA CLI
-  local status=$(jira issue view "$jira_key" --plain --columns status 2>/dev/null | tail -1 | awk '{print $NF}')
+  local status=$(_aw_retry_cli jira issue view "$jira_key" --plain --columns status 2>/dev/null | tail -1 | awk '{print $NF}')
 
   if [[ -z "$status" ]]; then
     return 1
@@ -35,24 +35,76 @@ _aw_jira_check_resolved() {
   esac
 }
 
+_aw_jira_scope_jql() {
+  # Limit a JQL query to auto-worktree.jira-project, if one is set. A trailing
+  # ORDER BY can't go inside the parentheses, so it is kept after them.
+  # Usage: _aw_jira_scope_jql jql
+  local jql="$1"
+  local project=$(_aw_get_jira_project)
+  if [[ -z "$project" ]]; then
+    echo "$jql"
+    return 0
+  fi
+
+  # 1-based position of the last ORDER BY keyword, or 0
+  local order_at=$(printf '%s' "$jql" | tr '\n' ' ' | awk '{
+    s = tolower($0); at = 0; base = 0
+    while (match(s, /order[ \t]+by[ \t]/)) {
+      if (base + RSTART == 1 || substr($0, base + RSTART - 1, 1) ~ /[ \t)]/) at = base + RSTART
+      base += RSTART + RLENGTH - 1
+      s = substr(s, RSTART + RLENGTH)
+    }
+    print at
+  }')
+
+  local order_by=""
+  if [[ "${order_at:-0}" -gt 0 ]]; then
+    order_by="${jql:$((order_at - 1))}"
+    jql="${jql:0:$((order_at - 1))}"
+    jql="${jql%"${jql##*[![:space:]]}"}"
+  fi
+
+  if [[ -z "$jql" ]]; then
+    echo "project = $project${order_by:+ $order_by}"
+  else
+    echo "project = $project AND ($jql)${order_by:+ $order_by}"
+  fi
+}
+
 _aw_jira_list_issues() {
-  # List JIRA issues using JQL
+  # List JIRA issues using JQL: issue --jql (_AW_JIRA_JQL), else
+  # auto-worktree.jira-jql, else open issues. The project filter is prepended.
   # Returns formatted issue list similar to GitHub issues
+  # Returns 1 (after showing JIRA's error) if a custom query is rejected
   if ! command -v jira &>/dev/null; then
     return 1
   fi
 
-  local project=$(_aw_get_jira_project)
-  local jql="status != Done AND status != Closed AND status != Resolved"
+  local custom_jql="${_AW_JIRA_JQL:-$(_aw_get_jira_jql)}"
+  local jql="${custom_jql:-status != Done AND status != Closed AND status != Resolved}"
 
   # If a default project is configured, filter by it
-  if [[ -n "$project" ]]; then
-    jql="project = $project AND ($jql)"
-  fi
+  jql=$(_aw_jira_scope_jql "$jql")
+
+  local err_file
+  err_file=$(mktemp "${TMPDIR:-/tmp}/aw-jira.XXXXXX") || return 1
 
   # Use JIRA CLI to list issues
   # Output format: KEY | Summary | [Labels]
-  jira issue list --jql "$jql" --plain --columns key,summary,labels --no-headers 2>/dev/null | \
+  local issues
+  if ! issues=$(_aw_retry_cli jira issue list --jql "$jql" --plain --columns key,summary,labels --no-headers --paginate "0:$(_aw_get_issue_list_limit)" 2>"$err_file"); then
+    # jira-cli fails when nothing matches; that is an empty list, not an error
+    if [[ -n "$custom_jql" ]] && ! grep -qi "no result" "$err_file"; then
+      gum style --foreground 1 "Error: JIRA rejected the JQL: $jql" >&2
+      sed 's/^/  /' "$err_file" | head -5 >&2
+      rm -f "$err_file"
+      return 1
+    fi
+  fi
+  rm -f "$err_file"
+  [[ -z "$issues" ]] && return 0
+
+  printf '%s\n' "$issues" | \
     awk -F'\t' '{
       key = $1
       summary = $2
@@ -73,7 +125,7 @@ _aw_jira_list_issues() {
 
 _aw_jira_get_issue_details() {
   # Get JIRA issue details
-  # Sets variables: title, body (description)
+  # Sets variables: title, body (description), url
   local jira_key="$1"
 
   if [[ -z "$jira_key" ]]; then
@@ -85,7 +137,7 @@ _aw_jira_get_issue_details() {
   fi
 
   # Get issue details in JSON format
-  local issue_json=$(jira issue view "$jira_key" --plain --columns summary,description 2>/dev/null)
+  local issue_json=$(_aw_retry_cli jira issue view "$jira_key" --plain --columns summary,description 2>/dev/null)
 
   if [[ -z "$issue_json" ]]; then
     return 1
@@ -101,6 +153,12 @@ _aw_jira_get_issue_details() {
     body=""
   fi
 
+  local server=$(_aw_get_jira_server)
+  url=""
+  if [[ -n "$server" ]]; then
+    url="${server%/}/browse/${jira_key}"
+  fi
+
   return 0
 }
 
@@ -111,14 +169,9 @@ _aw_jira_list_epics() {
     return 1
   fi
 
-  local project=$(_aw_get_jira_project)
-  local jql="type = Epic AND statusCategory != Done"
-
-  if [[ -n "$project" ]]; then
-    jql="project = $project AND ($jql)"
-  fi
+  local jql=$(_aw_jira_scope_jql "type = Epic AND statusCategory != Done")
 
-  jira issue list --jql "$jql" --plain --columns key,summary,status --no-headers 2>/dev/null | \
+  _aw_retry_cli jira issue list --jql "$jql" --plain --columns key,summary,status --no-headers --paginate "0:$(_aw_get_issue_list_limit)" 2>/dev/null | \
     awk -F'\t' '{
       key = $1
       summary = $2
@@ -137,7 +190,6 @@ _aw_jira_list_issues_by_epic() {
   # Args: $1 = epic key (e.g., PROJ-123)
   # Output format: KEY | Summary | [Labels]
   local epic_key="$1"
-  local project=$(_aw_get_jira_project)
 
   if [[ -z "$epic_key" ]]; then
     return 1
@@ -147,12 +199,9 @@ _aw_jira_list_issues_by_epic() {
     return 1
   fi
 
-  local jql="(\"Epic Link\" = $epic_key OR parent = $epic_key) AND statusCategory != Done"
-  if [[ -n "$project" ]]; then
-    jql="project = $project AND ($jql)"
-  fi
+  local jql=$(_aw_jira_scope_jql "(\"Epic Link\" = $epic_key OR parent = $epic_key) AND statusCategory != Done")
 
-  jira issue list --jql "$jql" --plain --columns key,summary,labels --no-headers 2>/dev/null | \
+  _aw_retry_cli jira issue list --jql "$jql" --plain --columns key,summary,labels --no-headers --paginate "0:$(_aw_get_issue_list_limit)" 2>/dev/null | \
     awk -F'\t' '{
       key = $1
       summary = $2
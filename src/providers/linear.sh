@@ -18,7 +18,7 @@ _aw_linear_check_completed() {
 
   # Get issue details using Linear CLI
   # The 'linear issue view' command outputs markdown with issue details
-  local issue_view=$(linear issue view "$issue_id" 2>/dev/null)
+  local issue_view=$(_aw_retry_cli linear issue view "$issue_id" 2>/dev/null)
 
   if [[ -z "$issue_view" ]]; then
     return 1
@@ -59,18 +59,18 @@ _aw_linear_list_issues() {
   # List issues using Linear CLI
   # Default: lists unstarted issues assigned to you
   # Use -A to list all team's unstarted issues
-  local linear_cmd="linear issue list"
+  local linear_args=(issue list)
 
   # If a team is configured, we'll use -A to get all team issues
   # Note: Linear CLI doesn't have direct team filtering in list command
   # but it respects the LINEAR_TEAM_ID config
   if [[ -n "$team" ]]; then
-    linear_cmd="linear issue list -A"
+    linear_args+=(-A)
   fi
 
   # Execute the command and parse output
   # Linear CLI outputs a table format, we need to parse it
-  $linear_cmd 2>/dev/null | tail -n +2 | awk '{
+  _aw_retry_cli linear "${linear_args[@]}" 2>/dev/null | tail -n +2 | awk '{
     # Parse Linear CLI table output
     # Expected format: ID    Title    State    ...
     if (NF >= 3 && $1 ~ /^[A-Z]+-[0-9]+$/) {
@@ -91,7 +91,7 @@ _aw_linear_list_issues() {
 
 _aw_linear_get_issue_details() {
   # Get Linear issue details
-  # Sets variables: title, body (description)
+  # Sets variables: title, body (description), url
   local issue_id="$1"
 
   if [[ -z "$issue_id" ]]; then
@@ -103,7 +103,7 @@ _aw_linear_get_issue_details() {
   fi
 
   # Get issue details using Linear CLI
-  local issue_view=$(linear issue view "$issue_id" 2>/dev/null)
+  local issue_view=$(_aw_retry_cli linear issue view "$issue_id" 2>/dev/null)
 
   if [[ -z "$issue_view" ]]; then
     return 1
@@ -111,7 +111,7 @@ _aw_linear_get_issue_details() {
 
   # Extract title - Linear outputs markdown format
   # Title is typically in a heading or after "Title:" label
-  title=$(linear issue title "$issue_id" 2>/dev/null)
+  title=$(_aw_retry_cli linear issue title "$issue_id" 2>/dev/null)
 
   if [[ -z "$title" ]]; then
     # Fallback: parse from view output
@@ -127,18 +127,135 @@ _aw_linear_get_issue_details() {
     body=$(echo "$issue_view" | sed '1,/^---$/d' | sed '/^$/d' | head -20)
   fi
 
+  url=$(_aw_retry_cli linear issue url "$issue_id" 2>/dev/null)
+
   return 0
 }
 
+_aw_linear_api() {
+  # Run a GraphQL query against the Linear API
+  # The Linear CLI has no project commands, so project (milestone) lookups go
+  # straight to the API using the same LINEAR_API_KEY the CLI relies on.
+  # Args: $1 = GraphQL query, $2 = variables as a JSON object (optional)
+  # Returns: raw JSON response on stdout, 1 on missing key or request failure
+  local query="$1"
+  local variables="${2:-}"
+  [[ -z "$variables" ]] && variables="{}"
+
+  if [[ -z "${LINEAR_API_KEY:-}" ]]; then
+    echo "LINEAR_API_KEY is not set" >&2
+    return 1
+  fi
+
+  if ! command -v curl &>/dev/null; then
+    echo "curl is required to query the Linear API" >&2
+    return 1
+  fi
+
+  local payload
+  payload=$(jq -nc --arg query "$query" --argjson variables "$variables" \
+    '{query: $query, variables: $variables}') || return 1
+
+  # --fail-with-body makes HTTP errors fail (so 5xx responses are retried)
+  # while keeping the body, which holds GraphQL's error message. curl's
+  # stderr names the HTTP status or the network/DNS failure.
+  local err_file
+  err_file=$(mktemp "${TMPDIR:-/tmp}/aw-linear.XXXXXX") || return 1
+  local response curl_status=0
+  response=$(_aw_retry_cli curl -sS --fail-with-body -X POST "https://api.linear.app/graphql" \
+    -H "Content-Type: application/json" \
+    -H "Authorization: $LINEAR_API_KEY" \
+    --data "$payload" 2>"$err_file") || curl_status=$?
+  local curl_error=$(grep -v '^[[:space:]]*$' "$err_file" | tail -n 1)
+  rm -f "$err_file"
+
+  # GraphQL reports failures in an "errors" array, with a 200 or 4xx status
+  if [[ -n "$response" ]] && echo "$response" | jq -e '.errors | length > 0' &>/dev/null; then
+    echo "$response" | jq -r '.errors[0].message' >&2
+    return 1
+  fi
+
+  if [[ $curl_status -ne 0 ]]; then
+    gum style --foreground 1 "Error: Linear API request failed: ${curl_error:-curl exited with status $curl_status}" >&2
+    return 1
+  fi
+
+  if [[ -z "$response" ]]; then
+    gum style --foreground 1 "Error: Linear API returned an empty response" >&2
+    return 1
+  fi
+
+  echo "$response"
+}
+
 _aw_linear_list_milestones() {
-  # Linear does not support project/milestone listing via CLI
-  echo "Linear does not support project/milestone listing via CLI" >&2
-  return 1
+  # List active Linear projects (Linear's equivalent of milestones)
+  # Projects are filtered to the configured team when one is set.
+  # Output format: PROJECT_ID | Name | [state] [target: DATE]
+  local team=$(_aw_get_linear_team)
+
+  local variables
+  variables=$(jq -nc --argjson first "$(_aw_get_issue_list_limit)" '{first: $first}')
+
+  local response
+  response=$(_aw_linear_api 'query($first: Int!) {
+    projects(first: $first) {
+      nodes { id name state targetDate teams { nodes { key } } }
+    }
+  }' "$variables") || return 1
+
+  echo "$response" | jq -r --arg team "$team" '
+    .data.projects.nodes[]
+    | select(.state != "completed" and .state != "canceled")
+    | select($team == "" or any(.teams.nodes[]; .key == $team))
+    | [.id, .name, .state, .targetDate // ""] | @tsv' | \
+    while IFS=$'\t' read -r id name state target_date; do
+      local labels=" | [${state}]"
+      if [[ -n "$target_date" ]]; then
+        labels="${labels} [target: ${target_date}]"
+      fi
+      echo "${id} | ${name}${labels}"
+    done
 }
 
 _aw_linear_list_issues_by_milestone() {
-  # Linear does not support filtering issues by project via CLI
-  echo "Linear does not support filtering issues by project via CLI" >&2
-  return 1
-}
+  # List open issues that belong to a Linear project
+  # Args: $1 = project ID (as output by _aw_linear_list_milestones)
+  # Output format: TEAM-123 | Title | [label1][label2]
+  local project_id="$1"
 
+  if [[ -z "$project_id" ]]; then
+    return 1
+  fi
+
+  local variables
+  variables=$(jq -nc --arg id "$project_id" --argjson first "$(_aw_get_issue_list_limit)" \
+    '{projectId: $id, first: $first}')
+
+  local response
+  response=$(_aw_linear_api 'query($projectId: ID!, $first: Int!) {
+    issues(first: $first, filter: {
+      project: { id: { eq: $projectId } },
+      state: { type: { nin: ["completed", "canceled"] } }
+    }) {
+      nodes { identifier title labels { nodes { name color } } }
+    }
+  }' "$variables") || return 1
+
+  if [[ -n "${_AW_LABEL_COLORS_FILE:-}" ]]; then
+    echo "$response" | jq -r '[.data.issues.nodes[].labels.nodes[]] | unique_by(.name)[] | "\(.name)\t\(.color)"' >> "$_AW_LABEL_COLORS_FILE"
+  fi
+
+  echo "$response" | jq -r '
+    .data.issues.nodes[]
+    | [.identifier, .title, ([.labels.nodes[].name] | join(","))] | @tsv' | \
+    while IFS=$'\t' read -r identifier title labels; do
+      local formatted_labels
+      formatted_labels=$(_aw_format_labels "$labels")
+      if [[ -n "$formatted_labels" ]]; then
+        echo "${identifier} | ${title} | ${formatted_labels}"
+      else
+        echo "${identifier} | ${title}"
+      fi
+    done
+}
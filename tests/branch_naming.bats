@@ -7,6 +7,8 @@
 
 REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
 
+load 'helpers/setup_git_repo'
+
 setup() {
   # Stub external-tool functions before sourcing so common.sh can load cleanly
   _aw_get_issue_provider() { echo "github"; }
@@ -138,3 +140,56 @@ setup() {
   run _aw_extract_issue_number "$branch_name"
   [ "$output" = "$issue_id" ]
 }
+
+# ===== _aw_get_branch_prefix =====
+
+@test "_aw_get_branch_prefix: defaults to work" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/config.sh"
+  cd "$TEST_REPO_DIR"
+
+  run _aw_get_branch_prefix
+  [ "$output" = "work" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_get_branch_prefix: normalizes slashes and invalid characters" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/config.sh"
+  cd "$TEST_REPO_DIR"
+
+  git config auto-worktree.branch-prefix "feature/"
+  run _aw_get_branch_prefix
+  [ "$output" = "feature" ]
+
+  git config auto-worktree.branch-prefix "/team//fix/"
+  run _aw_get_branch_prefix
+  [ "$output" = "team/fix" ]
+
+  git config auto-worktree.branch-prefix "my prefix..~"
+  run _aw_get_branch_prefix
+  [ "$output" = "my-prefix" ]
+
+  git config auto-worktree.branch-prefix "//"
+  run _aw_get_branch_prefix
+  [ "$output" = "work" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_issue_suggested_branch: uses the configured branch prefix" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/config.sh"
+  source "${REPO_ROOT}/src/commands/issue.sh"
+  cd "$TEST_REPO_DIR"
+
+  git config auto-worktree.branch-prefix "fix/"
+  run _aw_issue_suggested_branch "42" "github" "Implement dark mode"
+  [ "$output" = "fix/42-implement-dark-mode" ]
+
+  run _aw_extract_issue_number "$output"
+  [ "$output" = "42" ]
+
+  teardown_git_repo
+}
@@ -333,3 +333,97 @@ _make_worktree() {
   [ "$status" -eq 0 ]
   [[ "$output" == *"$wt_path"* ]]
 }
+
+# ===========================================================================
+# Batch cleanup: totals and --force for dirty worktrees
+# ===========================================================================
+
+# Select every worktree offered, log confirmation prompts and accept them
+_gum_select_all() {
+  gum() {
+    case "$1" in
+      choose) cat ;;
+      confirm) echo "$2" >> "$BATS_TEST_TMPDIR/confirms" ;;
+      style) echo "${@: -1}" ;;
+    esac
+  }
+}
+
+@test "_aw_cleanup_interactive: confirmation shows the total and dirty worktrees are skipped" {
+  local clean_one clean_two dirty
+  clean_one=$(_make_worktree "work/80-one")
+  clean_two=$(_make_worktree "work/81-two")
+  dirty=$(_make_worktree "work/82-dirty")
+  echo "wip" > "$dirty/wip.txt"
+  _gum_select_all
+
+  cd "$TEST_REPO_DIR"
+  run _aw_cleanup_interactive
+  [ "$status" -eq 0 ]
+  grep -q "Delete 2 worktree(s), .* and their branches?" "$BATS_TEST_TMPDIR/confirms"
+  [[ "$output" == *"auto-worktree cleanup --force"* ]]
+  [ ! -d "$clean_one" ]
+  [ ! -d "$clean_two" ]
+  [ -d "$dirty" ]
+}
+
+@test "_aw_cleanup_interactive: --force removes dirty worktrees after confirming" {
+  local dirty
+  dirty=$(_make_worktree "work/83-dirty")
+  echo "wip" > "$dirty/wip.txt"
+  _gum_select_all
+
+  cd "$TEST_REPO_DIR"
+  run _aw_cleanup_interactive --force
+  [ "$status" -eq 0 ]
+  grep -q "Discard uncommitted changes" "$BATS_TEST_TMPDIR/confirms"
+  [ ! -d "$dirty" ]
+}
+
+@test "_aw_cleanup_interactive --quiet: prints only the removed worktree paths" {
+  local wt_path
+  wt_path=$(_make_worktree "work/84-quiet")
+  _gum_select_all
+
+  cd "$TEST_REPO_DIR"
+  _AW_QUIET=true run _aw_cleanup_interactive
+  [ "$status" -eq 0 ]
+  [ "$output" = "$wt_path" ]
+  [ ! -d "$wt_path" ]
+}
+
+@test "_aw_cleanup_interactive: rejects unknown options" {
+  run _aw_cleanup_interactive --bogus
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+# ===========================================================================
+# Detached-HEAD worktrees
+# ===========================================================================
+
+@test "_aw_cleanup_interactive: skips detached worktrees with a note" {
+  local detached_wt="$(cd "${TEST_REPO_DIR}/.." && pwd -P)/wt-detached"
+  git -C "$TEST_REPO_DIR" worktree add --detach "$detached_wt" HEAD >/dev/null 2>&1
+  _gum_select_all
+
+  cd "$TEST_REPO_DIR"
+  run _aw_cleanup_interactive
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Skipped 1 detached worktree(s): wt-detached"* ]]
+  [[ "$output" == *"--include-detached"* ]]
+  [ -d "$detached_wt" ]
+}
+
+@test "_aw_cleanup_interactive: --include-detached offers detached worktrees without deleting a branch" {
+  local detached_wt="$(cd "${TEST_REPO_DIR}/.." && pwd -P)/wt-detached"
+  git -C "$TEST_REPO_DIR" worktree add --detach "$detached_wt" HEAD >/dev/null 2>&1
+  local branches_before=$(git -C "$TEST_REPO_DIR" branch --list | wc -l)
+  _gum_select_all
+
+  cd "$TEST_REPO_DIR"
+  run _aw_cleanup_interactive --include-detached
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"(detached@"*"[detached]"* ]]
+  [ ! -d "$detached_wt" ]
+  [ "$(git -C "$TEST_REPO_DIR" branch --list | wc -l)" -eq "$branches_before" ]
+}
@@ -0,0 +1,195 @@
+#!/usr/bin/env bats
+# Tests for src/commands/create_issue.sh
+#
+# Covers:
+#   - _aw_create_issue --title: body read from stdin when piped
+#   - --body takes precedence over stdin
+#   - --template by name, by path, and unknown names listing what's available
+#   - --label: passed to gh, validated against gh label list, missing ones created on request
+#   - --assignee: passed to each provider's CLI, @me resolved per provider
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+
+setup() {
+  # Print styled text; decline the final confirmation so nothing is created
+  gum() {
+    case "$1" in
+      style) echo "${@: -1}" ;;
+      confirm) return 1 ;;
+    esac
+  }
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
+  # shellcheck source=../src/providers/github.sh
+  source "${REPO_ROOT}/src/providers/github.sh"
+  # shellcheck source=../src/commands/create_issue.sh
+  source "${REPO_ROOT}/src/commands/create_issue.sh"
+
+  _aw_init_issue_provider() { echo "github"; }
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+}
+
+teardown() {
+  teardown_git_repo
+}
+
+@test "_aw_create_issue: reads the body from stdin when it is piped" {
+  run _aw_create_issue --title "Piped bug" <<< "Steps to reproduce"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Title: Piped bug"* ]]
+  [[ "$output" == *"Steps to reproduce"* ]]
+}
+
+@test "_aw_create_issue: --body wins over piped stdin" {
+  run _aw_create_issue --title "Flag body" --body "From the flag" <<< "ignored"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"From the flag"* ]]
+  [[ "$output" != *"ignored"* ]]
+}
+
+@test "_aw_create_issue: --template loads a provider template by name" {
+  mkdir -p .github/ISSUE_TEMPLATE
+  printf '## Steps\nDescribe the crash\n' > .github/ISSUE_TEMPLATE/bug_report.md
+  mkdir -p sub && cd sub
+
+  run _aw_create_issue --title "Crash" --template bug_report
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Describe the crash"* ]]
+
+  run _aw_create_issue --title "Crash" --template bug_report.md
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Describe the crash"* ]]
+}
+
+@test "_aw_create_issue: --template still accepts a file path" {
+  printf 'Custom body\n' > custom.md
+  run _aw_create_issue --title "Custom" --template custom.md
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Custom body"* ]]
+}
+
+@test "_aw_create_issue: unknown --template fails and lists the available templates" {
+  mkdir -p .github/ISSUE_TEMPLATE
+  printf 'x\n' > .github/ISSUE_TEMPLATE/bug_report.md
+  printf 'y\n' > .github/ISSUE_TEMPLATE/feature_request.md
+
+  run _aw_create_issue --title "Nope" --template missing
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Error: Template not found: missing"* ]]
+  [[ "$output" == *"  bug_report"* ]]
+  [[ "$output" == *"  feature_request"* ]]
+}
+
+@test "_aw_create_issue: --label applies existing labels to the GitHub issue" {
+  gum() {
+    case "$1" in
+      style) echo "${@: -1}" ;;
+      confirm) return 0 ;;
+    esac
+  }
+  _aw_github_list_labels() { printf 'bug\nP1\ndocs\n'; }
+  gh() { echo "gh $*" > "$BATS_TEST_TMPDIR/gh.args"; echo "https://github.com/o/r/issues/5"; }
+
+  run _aw_create_issue --title "Crash" --body "Boom" --label bug --label p1 --no-worktree
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Labels: bug, p1"* ]]
+  [[ "$(cat "$BATS_TEST_TMPDIR/gh.args")" == *"--label bug --label p1"* ]]
+}
+
+@test "_aw_create_issue: a missing label is created only when confirmed" {
+  _aw_github_list_labels() { echo "bug"; }
+  _aw_github_create_label() { echo "created $1" >> "$BATS_TEST_TMPDIR/labels"; }
+  gh() { echo "gh called" > "$BATS_TEST_TMPDIR/gh.args"; }
+
+  # Declined (setup's gum confirm says no): nothing is created
+  run _aw_create_issue --title "Crash" --body "Boom" --label needs-triage --no-worktree
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Label 'needs-triage' doesn't exist"* ]]
+  [ ! -f "$BATS_TEST_TMPDIR/labels" ]
+  [ ! -f "$BATS_TEST_TMPDIR/gh.args" ]
+
+  gum() {
+    case "$1" in
+      style) echo "${@: -1}" ;;
+      confirm) [[ "$2" == "Create label"* ]] ;;
+    esac
+  }
+  run _aw_create_issue --title "Crash" --body "Boom" --label needs-triage --no-worktree
+  [ "$status" -eq 0 ]
+  [ "$(cat "$BATS_TEST_TMPDIR/labels")" = "created needs-triage" ]
+}
+
+@test "_aw_create_issue: --label is ignored with a warning for other providers" {
+  _aw_init_issue_provider() { echo "jira"; }
+  run _aw_create_issue --title "Crash" --body "Boom" --label bug
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"--label is only supported for GitHub issues"* ]]
+  [[ "$output" != *"Labels:"* ]]
+
+  run _aw_create_issue --title "Crash" --label
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_create_issue: --assignee is passed to gh, comma-separated" {
+  gum() {
+    case "$1" in
+      style) echo "${@: -1}" ;;
+      confirm) return 0 ;;
+    esac
+  }
+  gh() { echo "gh $*" > "$BATS_TEST_TMPDIR/gh.args"; echo "https://github.com/o/r/issues/6"; }
+
+  run _aw_create_issue --title "Crash" --body "Boom" --assignee @me --assignee octocat --no-worktree
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Assignees: @me, octocat"* ]]
+  [[ "$(cat "$BATS_TEST_TMPDIR/gh.args")" == *"--assignee @me,octocat"* ]]
+
+  run _aw_create_issue --title "Crash" --assignee
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_resolve_assignee: resolves @me for each provider" {
+  glab() { echo '{"username": "gl-me"}'; }
+  jira() { [[ "$1" == "me" ]] && echo "me@example.com"; }
+
+  [ "$(_aw_resolve_assignee github @me)" = "@me" ]
+  [ "$(_aw_resolve_assignee linear @me)" = "self" ]
+  [ "$(_aw_resolve_assignee gitlab @me)" = "gl-me" ]
+  [ "$(_aw_resolve_assignee jira @me)" = "me@example.com" ]
+  [ "$(_aw_resolve_assignee jira someone)" = "someone" ]
+
+  jira() { return 1; }
+  run _aw_resolve_assignee jira @me
+  [ "$status" -eq 1 ]
+}
+
+@test "_aw_create_issue: JIRA gets a single resolved assignee" {
+  source "${REPO_ROOT}/src/providers/common.sh"
+  _aw_init_issue_provider() { echo "jira"; }
+  _aw_get_jira_project() { echo "PROJ"; }
+  gum() {
+    case "$1" in
+      style) echo "${@: -1}" ;;
+      confirm) [[ "$2" == "Create this issue?" ]] ;;
+    esac
+  }
+  jira() {
+    case "$1" in
+      me) echo "me@example.com" ;;
+      issue) echo "jira $*" > "$BATS_TEST_TMPDIR/jira.args"; echo "PROJ-9 created" ;;
+    esac
+  }
+
+  run _aw_create_issue --title "Crash" --body "Boom" --assignee @me --assignee other --no-worktree
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"JIRA issues take one assignee; using me@example.com"* ]]
+  [[ "$(cat "$BATS_TEST_TMPDIR/jira.args")" == *"--assignee me@example.com"* ]]
+  [[ "$(cat "$BATS_TEST_TMPDIR/jira.args")" != *"other"* ]]
+}
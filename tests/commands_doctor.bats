@@ -0,0 +1,281 @@
+#!/usr/bin/env bats
+# Tests for src/commands/doctor.sh
+#
+# Covers:
+#   - _aw_doctor_check_config: clean config passes
+#   - invalid provider, missing jira-server, settings for another provider
+#   - non-boolean values, non-numeric issue-list-limit, unknown keys, missing custom hooks, missing default-branch,
+#     github-remote naming a remote that doesn't exist
+#   - worktree-naming templates with unknown or missing placeholders
+#   - age-warn-days / age-stale-days that aren't positive numbers or are out of order
+#   - _aw_doctor --repair: moved worktrees are repaired, missing ones pruned on confirmation
+#   - _aw_doctor --self-test: timed git and provider CLI checks, failures and slow steps,
+#     provider CLI versions older than the supported minimum
+#   - _aw_now_ms: milliseconds from EPOCHREALTIME (dot or comma decimal separator)
+#   - _aw_doctor: unknown option is a usage error
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+
+setup() {
+  # Print gum style text so problems show up in $output
+  gum() {
+    if [[ "$1" == "style" ]]; then
+      echo "${@: -1}"
+    fi
+  }
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
+  # shellcheck source=../src/lib/deps.sh
+  source "${REPO_ROOT}/src/lib/deps.sh"
+  # shellcheck source=../src/lib/ai.sh
+  source "${REPO_ROOT}/src/lib/ai.sh"
+  # shellcheck source=../src/lib/hooks.sh
+  source "${REPO_ROOT}/src/lib/hooks.sh"
+  # shellcheck source=../src/commands/doctor.sh
+  source "${REPO_ROOT}/src/commands/doctor.sh"
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  _AW_GIT_ROOT="$TEST_REPO_DIR"
+  _AW_WORKTREE_BASE="$BATS_TEST_TMPDIR/worktrees/repo"
+}
+
+teardown() {
+  teardown_git_repo
+}
+
+@test "_aw_doctor_check_config: passes with a coherent configuration" {
+  git config auto-worktree.issue-provider jira
+  git config auto-worktree.jira-server https://example.atlassian.net
+  git config auto-worktree.run-hooks true
+  run _aw_doctor_check_config
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Configuration looks good"* ]]
+}
+
+@test "_aw_doctor_check_config: rejects an unknown issue provider" {
+  git config auto-worktree.issue-provider bugzilla
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"auto-worktree.issue-provider is 'bugzilla'"* ]]
+}
+
+@test "_aw_doctor_check_config: jira provider requires jira-server" {
+  git config auto-worktree.issue-provider jira
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"git config auto-worktree.jira-server"* ]]
+}
+
+@test "_aw_doctor_check_config: flags settings for a different provider" {
+  git config auto-worktree.issue-provider github
+  git config auto-worktree.linear-team ENG
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"git config --unset auto-worktree.linear-team"* ]]
+}
+
+@test "_aw_doctor_check_config: flags non-boolean values" {
+  git config auto-worktree.run-hooks maybe
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"auto-worktree.run-hooks is 'maybe'"* ]]
+}
+
+@test "_aw_doctor_check_config: flags an issue-list-limit that isn't a positive number" {
+  git config auto-worktree.issue-list-limit lots
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"auto-worktree.issue-list-limit is 'lots'"* ]]
+
+  git config auto-worktree.issue-list-limit 0
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+
+  git config auto-worktree.issue-list-limit 250
+  run _aw_doctor_check_config
+  [ "$status" -eq 0 ]
+}
+
+@test "_aw_doctor_check_config: flags invalid or out-of-order age thresholds" {
+  git config auto-worktree.age-stale-days week
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"auto-worktree.age-stale-days is 'week'"* ]]
+
+  git config auto-worktree.age-stale-days 7
+  git config auto-worktree.age-warn-days 10
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"age-warn-days is greater than"* ]]
+
+  git config auto-worktree.age-warn-days 2
+  run _aw_doctor_check_config
+  [ "$status" -eq 0 ]
+}
+
+@test "_aw_doctor_check_config: flags unknown keys" {
+  git config auto-worktree.isue-provider github
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Unknown setting auto-worktree.isue-provider"* ]]
+}
+
+@test "_aw_doctor_check_config: flags custom hooks that don't exist" {
+  mkdir -p .git/hooks
+  printf '#!/bin/sh\nexit 0\n' > .git/hooks/post-setup
+  chmod +x .git/hooks/post-setup
+  git config auto-worktree.custom-hooks "post-setup post-missing"
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"'post-missing'"* ]]
+  [[ "$output" == *"git config auto-worktree.custom-hooks \"post-setup\""* ]]
+  [[ "$output" != *"'post-setup'"* ]]
+}
+
+@test "_aw_doctor_check_config: flags a default-branch that doesn't exist" {
+  git config auto-worktree.default-branch develop
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"auto-worktree.default-branch 'develop' does not exist"* ]]
+
+  git branch develop
+  run _aw_doctor_check_config
+  [ "$status" -eq 0 ]
+}
+
+@test "_aw_doctor_check_config: flags a github-remote that doesn't exist" {
+  git config auto-worktree.github-remote upstream
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"auto-worktree.github-remote is 'upstream' but there is no such remote"* ]]
+
+  git remote add upstream https://github.com/octo/project.git
+  run _aw_doctor_check_config
+  [ "$status" -eq 0 ]
+}
+
+@test "_aw_doctor_check_config: validates worktree-naming placeholders" {
+  git config auto-worktree.worktree-naming "{repo}-{name}"
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"unknown placeholder '{name}'"* ]]
+
+  git config auto-worktree.worktree-naming "{repo}"
+  run _aw_doctor_check_config
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"has no {branch} or {branch-basename}"* ]]
+
+  git config auto-worktree.worktree-naming "{repo}-{branch-basename}"
+  run _aw_doctor_check_config
+  [ "$status" -eq 0 ]
+}
+
+@test "_aw_doctor: unknown option is a usage error" {
+  run _aw_doctor --bogus
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_doctor --repair: repairs a worktree moved inside the worktree base" {
+  mkdir -p "$_AW_WORKTREE_BASE"
+  git worktree add -q -b feature/moved "$_AW_WORKTREE_BASE/feature-moved"
+  mv "$_AW_WORKTREE_BASE/feature-moved" "$_AW_WORKTREE_BASE/feature-renamed"
+  local moved_path=$(cd "$_AW_WORKTREE_BASE/feature-renamed" && pwd -P)
+  _aw_get_repo_info() { :; }
+
+  run _aw_doctor --repair
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"✓ Repaired gitdir incorrect"* ]]
+  [[ "$output" == *"Every registered worktree exists"* ]]
+  [[ "$output" != *"Checking configuration"* ]]
+  git worktree list --porcelain | grep -qxF "worktree $moved_path"
+  [ "$(git -C "$moved_path" rev-parse --abbrev-ref HEAD)" = "feature/moved" ]
+}
+
+@test "_aw_doctor --repair: offers to prune worktrees whose directories are gone" {
+  mkdir -p "$_AW_WORKTREE_BASE"
+  git worktree add -q -b feature/gone "$_AW_WORKTREE_BASE/feature-gone"
+  rm -rf "$_AW_WORKTREE_BASE/feature-gone"
+  _aw_get_repo_info() { :; }
+
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; elif [[ "$1" == "confirm" ]]; then return 1; fi; }
+  run _aw_doctor --repair
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"1 registered worktree(s) no longer exist"* ]]
+  [[ "$output" == *"feature-gone"* ]]
+  git worktree list --porcelain | grep -q "feature-gone"
+
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  run _aw_doctor --repair
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"✓ Pruned 1 stale registration(s)"* ]]
+  ! git worktree list --porcelain | grep -q "feature-gone"
+  git show-ref --verify --quiet refs/heads/feature/gone
+}
+
+@test "_aw_now_ms: reads milliseconds from EPOCHREALTIME" {
+  [ "$(unset EPOCHREALTIME; EPOCHREALTIME="1700000000.123456"; _aw_now_ms)" = "1700000000123" ]
+  [ "$(unset EPOCHREALTIME; EPOCHREALTIME="1700000000,045678"; _aw_now_ms)" = "1700000000045" ]
+}
+
+@test "_aw_doctor --self-test: times git commands and the provider CLI" {
+  _aw_provider_display_name() { echo "GitHub"; }
+  _aw_check_issue_provider_deps() { return 0; }
+  _aw_check_provider_auth() { return 0; }
+  git config auto-worktree.issue-provider github
+
+  [[ "$(_aw_now_ms)" =~ ^[0-9]+$ ]]
+
+  run _aw_doctor --self-test
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "✓ git rev-parse ("[0-9]+"ms)" ]]
+  [[ "$output" == *"✓ git worktree list ("* ]]
+  [[ "$output" == *"✓ git config --get ("* ]]
+  [[ "$output" == *"✓ GitHub CLI auth check ("* ]]
+  # The self-test only runs on request
+  [[ "$output" != *"Checking configuration"* ]]
+
+  _AW_DOCTOR_SLOW_MS=-1 run _aw_doctor --self-test
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"⚠ git rev-parse took"* ]]
+}
+
+@test "_aw_doctor --self-test: shows the provider CLI version and warns when it's too old" {
+  _aw_provider_display_name() { echo "GitHub"; }
+  _aw_check_issue_provider_deps() { return 0; }
+  _aw_check_provider_auth() { return 0; }
+  git config auto-worktree.issue-provider github
+
+  _aw_provider_cli_version() { echo "2.43.0"; }
+  run _aw_doctor --self-test
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"✓ gh 2.43.0"* ]]
+
+  # Too old only warns
+  _aw_provider_cli_version() { echo "1.9.2"; }
+  run _aw_doctor --self-test
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"⚠ gh 1.9.2 is older than 2.0.0"* ]]
+}
+
+@test "_aw_doctor --self-test: reports a failing provider CLI with its error" {
+  _aw_provider_display_name() { echo "GitHub"; }
+  _aw_check_issue_provider_deps() { return 0; }
+  _aw_check_provider_auth() { echo "not logged in to github.com"; return 1; }
+  git config auto-worktree.issue-provider github
+
+  run _aw_doctor --self-test
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"✗ GitHub CLI auth check failed (exit 1,"* ]]
+  [[ "$output" == *"    not logged in to github.com"* ]]
+
+  git config --unset auto-worktree.issue-provider
+  run _aw_doctor --self-test
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"No issue provider configured"* ]]
+}
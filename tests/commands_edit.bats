@@ -0,0 +1,129 @@
+#!/usr/bin/env bats
+# Tests for src/commands/edit.sh
+#
+# Covers:
+#   - _aw_get_editor (config, $VISUAL, $EDITOR precedence)
+#   - _aw_editor_is_gui
+#   - _aw_edit (usage error, unknown target, terminal and GUI editors, no editor,
+#     defaulting to the current worktree)
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+load 'helpers/mock_cli'
+
+setup() {
+  gum() {
+    if [[ "$1" == "style" ]]; then
+      echo "${@: -1}"
+    fi
+  }
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
+  # shellcheck source=../src/lib/metadata.sh
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  # shellcheck source=../src/lib/worktree.sh
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  # shellcheck source=../src/commands/remove.sh
+  source "${REPO_ROOT}/src/commands/remove.sh"
+  # shellcheck source=../src/commands/edit.sh
+  source "${REPO_ROOT}/src/commands/edit.sh"
+
+  _aw_get_repo_info() { :; }
+
+  setup_mock_cli
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+
+  WT_BASE="${TEST_REPO_DIR}-worktrees"
+  mkdir -p "$WT_BASE"
+  git worktree add -q -b "feature/edit-me" "$WT_BASE/feature-edit-me"
+
+  unset VISUAL EDITOR
+}
+
+teardown() {
+  teardown_git_repo
+  teardown_mock_cli
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+}
+
+@test "_aw_get_editor: config wins over \$VISUAL, which wins over \$EDITOR" {
+  EDITOR=nano
+  run _aw_get_editor
+  [ "$output" = "nano" ]
+
+  VISUAL=vim
+  run _aw_get_editor
+  [ "$output" = "vim" ]
+
+  git config auto-worktree.editor "code --wait"
+  run _aw_get_editor
+  [ "$output" = "code --wait" ]
+}
+
+@test "_aw_editor_is_gui: recognizes GUI editors by their command name" {
+  _aw_editor_is_gui "code"
+  _aw_editor_is_gui "/usr/local/bin/cursor --new-window"
+  ! _aw_editor_is_gui "vim"
+  ! _aw_editor_is_gui "emacs -nw"
+}
+
+@test "_aw_edit: missing target is a usage error" {
+  run _aw_edit
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_edit: unknown target is an error" {
+  EDITOR=vim
+  run _aw_edit "no-such-branch"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"No worktree found"* ]]
+}
+
+@test "_aw_edit: runs a terminal editor in the worktree" {
+  mock_cli vi "" ""
+  EDITOR="vi -n"
+
+  _aw_edit "feature/edit-me"
+
+  [ "$(cat "$MOCK_BIN_DIR/vi.calls")" = "-n $WT_BASE/feature-edit-me" ]
+  [ "$(pwd)" = "$WT_BASE/feature-edit-me" ]
+  [[ "$(git config --get branch.feature/edit-me.aw-last-accessed)" =~ ^[0-9]+$ ]]
+}
+
+@test "_aw_edit: starts a GUI editor without changing directory" {
+  mock_cli code "" ""
+  git config auto-worktree.editor code
+
+  run _aw_edit "$WT_BASE/feature-edit-me"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Opening $WT_BASE/feature-edit-me in code"* ]]
+
+  # The editor is started in the background
+  local waited=0
+  while [[ ! -f "$MOCK_BIN_DIR/code.calls" ]] && [[ $waited -lt 20 ]]; do
+    sleep 0.1
+    waited=$((waited + 1))
+  done
+  assert_cli_called code "$WT_BASE/feature-edit-me"
+  [ "$(pwd)" = "$TEST_REPO_DIR" ]
+}
+
+@test "_aw_edit: prints the path when no editor is configured" {
+  run _aw_edit "feature/edit-me"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"No editor configured"* ]]
+  [ "${lines[${#lines[@]}-1]}" = "$WT_BASE/feature-edit-me" ]
+}
+
+@test "_aw_edit: defaults to the current worktree" {
+  cd "$WT_BASE/feature-edit-me"
+
+  run _aw_edit
+  [ "$status" -eq 0 ]
+  [ "${lines[${#lines[@]}-1]}" = "$(pwd -P)" ]
+}
@@ -0,0 +1,140 @@
+#!/usr/bin/env bats
+# Tests for src/commands/grep.sh
+#
+# Covers:
+#   - _aw_grep: matches prefixed by branch across all worktrees
+#   - --branch filter (match, unknown branch, missing name)
+#   - -- before a pattern that starts with a dash
+#   - bounded concurrency (AW_GREP_JOBS=1 still searches every worktree)
+#   - only its own searches are waited for, not other background jobs
+#   - no matches / missing pattern exit codes
+#   - ripgrep skips a worktree base nested in the checkout
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+
+setup() {
+  gum() { return 0; }
+  export -f gum
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/worktree.sh
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  # shellcheck source=../src/commands/grep.sh
+  source "${REPO_ROOT}/src/commands/grep.sh"
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git branch -m main
+  echo "shared needle" > shared.txt
+  git add shared.txt && git commit -q -m "add shared"
+
+  WT_BASE="${TEST_REPO_DIR}-worktrees"
+  mkdir -p "$WT_BASE"
+  git worktree add -q -b "feature/one" "$WT_BASE/feature-one"
+  echo "only in one: needle" > "$WT_BASE/feature-one/one.txt"
+  git -C "$WT_BASE/feature-one" add one.txt
+  git worktree add -q -b "feature/two" "$WT_BASE/feature-two"
+
+  # Force the git grep path so results don't depend on ripgrep's presence
+  command() {
+    if [[ "$1" == "-v" && "$2" == "rg" ]]; then
+      return 1
+    fi
+    builtin command "$@"
+  }
+}
+
+teardown() {
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+}
+
+@test "_aw_grep: prefixes matches with the worktree branch" {
+  run _aw_grep "needle"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"[main] shared.txt:1:shared needle"* ]]
+  [[ "$output" == *"[feature/one] one.txt:1:only in one: needle"* ]]
+  [[ "$output" == *"[feature/two] shared.txt:1:shared needle"* ]]
+}
+
+@test "_aw_grep: --branch limits the search to one worktree" {
+  run _aw_grep --branch "feature/one" "needle"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"[feature/one]"* ]]
+  [[ "$output" != *"[main]"* ]]
+  [[ "$output" != *"[feature/two]"* ]]
+}
+
+@test "_aw_grep: --branch with no matching worktree fails" {
+  run _aw_grep --branch "nope" "needle"
+  [ "$status" -eq 1 ]
+
+  # The error goes to stderr, so stdout only ever holds matches
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  local out
+  out=$(_aw_grep --branch "nope" "needle" 2>/dev/null) || true
+  [ -z "$out" ]
+}
+
+@test "_aw_grep: --branch without a name is a usage error" {
+  run _aw_grep "needle" --branch
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_grep: -- lets the pattern start with a dash" {
+  echo "flag -foo here" > "$WT_BASE/feature-one/flags.txt"
+  git -C "$WT_BASE/feature-one" add flags.txt
+
+  run _aw_grep -- "-foo"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"[feature/one] flags.txt:1:flag -foo here"* ]]
+
+  run _aw_grep "-foo"
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_grep: searches every worktree when AW_GREP_JOBS=1" {
+  AW_GREP_JOBS=1 run _aw_grep "needle"
+  [ "$status" -eq 0 ]
+  [ "${#lines[@]}" -eq 4 ]
+}
+
+@test "_aw_grep: doesn't wait for unrelated background jobs" {
+  sleep 30 &
+  local unrelated=$!
+
+  SECONDS=0
+  _aw_grep "needle" > /dev/null
+  local elapsed=$SECONDS
+  kill "$unrelated"
+  [ "$elapsed" -lt 10 ]
+}
+
+@test "_aw_grep: returns 1 when nothing matches" {
+  run _aw_grep "no-such-text-anywhere"
+  [ "$status" -eq 1 ]
+  [ -z "$output" ]
+}
+
+@test "_aw_grep: missing pattern is a usage error" {
+  run _aw_grep
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_grep_worktree: ripgrep skips a worktree base nested in the checkout" {
+  command -v rg &>/dev/null || skip "ripgrep not installed"
+  unset -f command
+  local nested="$TEST_REPO_DIR/.trees"
+  git worktree add -q -b "feature/nested" "$nested/feature-nested"
+  echo "nested needle" > "$nested/feature-nested/nested.txt"
+
+  run _aw_grep_worktree "$TEST_REPO_DIR" "needle" false "$nested"
+  [[ "$output" == *"shared.txt"* ]]
+  [[ "$output" != *"nested.txt"* ]]
+
+  run _aw_grep_worktree "$TEST_REPO_DIR" "needle" false
+  [[ "$output" == *"nested.txt"* ]]
+}
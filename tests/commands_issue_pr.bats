@@ -3,6 +3,21 @@
 # Covers:
 #   - _aw_extract_id_from_selection (with active-worktree ● prefix)
 #   - _aw_validate_worktree_path (skips main git root and non-existent dirs)
+#   - _aw_issue_preview (lazy fetch + cache, confirm/decline)
+#   - _aw_resolve_milestone (match by title or ID)
+#   - _aw_issue_create_all (created/skipped/failed summary)
+#   - _aw_issue: an empty issue list succeeds, a failing provider doesn't
+#   - _aw_issue --jql: passed to the JIRA list, usage errors elsewhere
+#   - _aw_issue --milestone: usage error without a name
+#   - _aw_issue --include-closed: closed issues listed, a closed pick warns and asks
+#   - _aw_issue <id> --resume: reattaches to the issue's worktree, also without a terminal
+#   - _aw_issue / _aw_pr --quiet: only the worktree path on stdout
+#   - _aw_pr: --draft requires --create; _aw_pr_create refuses the default branch
+#   - _aw_pr --author/--not-author: passed to the provider, usage errors, empty filtered list
+#   - _aw_pr_create --suggest-reviewers (CODEOWNERS reviewers)
+#   - _aw_ensure_pr_worktree (fork PRs are checked out on a pr-<n> branch, an unrelated pr-<n> is kept)
+#   - _aw_pr_show_checks (failing checks warn, unavailable checks are skipped)
+#   - _aw_pr_show_diff_stat (colored graph and totals, local base fallback)
 
 REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
 
@@ -94,3 +109,521 @@ teardown() {
   git -C "$TEST_REPO_DIR" worktree remove --force "$wt_path" 2>/dev/null || true
   git -C "$TEST_REPO_DIR" branch -D "issue-pr-branch" 2>/dev/null || true
 }
+
+# ============================================================================
+# _aw_issue_preview — lazily fetched, cached issue details
+# ============================================================================
+
+_preview_twice() {
+  # Mirrors the caches _aw_issue declares for --preview
+  typeset -A preview_titles preview_bodies preview_urls
+  _aw_issue_preview "ENG-1" "linear"
+  _aw_issue_preview "ENG-1" "linear"
+}
+
+@test "_aw_issue_preview: fetches details once and reuses the cache" {
+  source "${REPO_ROOT}/src/commands/issue.sh"
+  _aw_get_issue_details() {
+    echo "fetch" >> "$BATS_TEST_TMPDIR/fetches"
+    title="Preview me"
+    body="Some description"
+  }
+  run _preview_twice
+  [ "$status" -eq 0 ]
+  [ "$(wc -l < "$BATS_TEST_TMPDIR/fetches" | tr -d ' ')" = "1" ]
+}
+
+@test "_aw_issue_preview: returns 1 when the user declines" {
+  source "${REPO_ROOT}/src/commands/issue.sh"
+  _aw_get_issue_details() { title="Preview me"; body=""; }
+  gum() { [[ "$1" == "confirm" ]] && return 1; return 0; }
+  typeset -A preview_titles preview_bodies preview_urls
+  run _aw_issue_preview "ENG-1" "linear"
+  [ "$status" -eq 1 ]
+}
+
+@test "_aw_issue_preview: returns 1 when the issue cannot be fetched" {
+  source "${REPO_ROOT}/src/commands/issue.sh"
+  _aw_get_issue_details() { return 1; }
+  typeset -A preview_titles preview_bodies preview_urls
+  run _aw_issue_preview "ENG-404" "linear"
+  [ "$status" -eq 1 ]
+}
+
+# ============================================================================
+# issue --milestone <name> --all
+# ============================================================================
+
+_stub_milestone_provider() {
+  source "${REPO_ROOT}/src/commands/milestone.sh"
+  source "${REPO_ROOT}/src/commands/issue.sh"
+  _aw_github_list_milestones() {
+    echo "3 | Sprint 12 | [open]"
+    echo "4 | Sprint 13 | [open]"
+  }
+  _aw_github_list_issues_by_milestone() {
+    echo "#1 | Already started"
+    echo "#2 | New work"
+    echo "#3 | Broken"
+  }
+  _aw_find_worktree_for_issue() {
+    [[ "$1" == "1" ]] && echo "/tmp/wt-1" && return 0
+    return 1
+  }
+  _aw_get_issue_details() {
+    [[ "$1" == "3" ]] && return 1
+    title="Issue $1"
+    url="https://example.com/$1"
+  }
+  _aw_issue_link_branch() { return 0; }
+  _aw_record_issue_metadata() { echo "$1" >> "$BATS_TEST_TMPDIR/recorded"; }
+  _aw_add_worktree() { echo "$1" >> "$BATS_TEST_TMPDIR/added"; }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+}
+
+@test "_aw_resolve_milestone: matches title case-insensitively" {
+  _stub_milestone_provider
+  local milestone_id="" milestone_title=""
+  _aw_resolve_milestone github "sprint 13"
+  [ "$milestone_id" = "4" ]
+  [ "$milestone_title" = "Sprint 13" ]
+}
+
+@test "_aw_resolve_milestone: matches by ID and fails on unknown names" {
+  _stub_milestone_provider
+  local milestone_id="" milestone_title=""
+  _aw_resolve_milestone github "3"
+  [ "$milestone_title" = "Sprint 12" ]
+  run _aw_resolve_milestone github "Sprint 99"
+  [ "$status" -eq 1 ]
+}
+
+@test "_aw_issue_create_all: creates missing worktrees, skips existing, continues past failures" {
+  _stub_milestone_provider
+  run _aw_issue_create_all github 3 "Sprint 12"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Created: 1  Skipped: 1  Failed: 1"* ]]
+  [[ "$output" == *"skipped"*"#1"*"/tmp/wt-1"* ]]
+  [[ "$output" == *"created"*"#2"*"work/2-issue-2"* ]]
+  [[ "$output" == *"failed"*"#3"* ]]
+  [ "$(cat "$BATS_TEST_TMPDIR/added")" = "work/2-issue-2" ]
+  [ "$(cat "$BATS_TEST_TMPDIR/recorded")" = "work/2-issue-2" ]
+}
+
+@test "_aw_issue_create_all: reports an existing branch worktree as skipped" {
+  _stub_milestone_provider
+  _aw_add_worktree() { return "$AW_EXIT_EXISTS"; }
+  _aw_get_issue_details() { title="Issue $1"; }
+  _aw_find_worktree_for_issue() { return 1; }
+  run _aw_issue_create_all github 3 "Sprint 12"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Created: 0  Skipped: 3  Failed: 0"* ]]
+}
+
+@test "_aw_issue: no open issues is reported, not treated as a failure" {
+  _stub_milestone_provider
+  _aw_init_issue_provider() { echo "github"; }
+  _aw_list_issues() { return 0; }
+
+  run _aw_issue
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"No open GitHub issues found"* ]]
+}
+
+@test "_aw_issue: a provider failure while listing issues is an error" {
+  _stub_milestone_provider
+  _aw_init_issue_provider() { echo "github"; }
+  _aw_list_issues() { echo "HTTP 502" >&2; return 1; }
+
+  run _aw_issue
+  [ "$status" -eq "$AW_EXIT_PROVIDER" ]
+  [[ "$output" == *"Could not list GitHub issues"* ]]
+}
+
+@test "_aw_issue --jql: lists JIRA issues from the query, usage error otherwise" {
+  _stub_milestone_provider
+  _aw_init_issue_provider() { echo "jira"; }
+  _aw_list_issues() { echo "jql=$_AW_JIRA_JQL" >&2; return 0; }
+
+  run _aw_issue --jql "sprint in openSprints()"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"jql=sprint in openSprints()"* ]]
+
+  run _aw_issue --jql
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  run _aw_issue --jql "status = Open" PROJ-1
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  _aw_init_issue_provider() { echo "github"; }
+  run _aw_issue --jql "status = Open"
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_issue --include-closed: warns about a closed pick and asks before creating" {
+  _stub_milestone_provider
+  _aw_init_issue_provider() { echo "github"; }
+  _aw_list_issues() { [[ "$_AW_ISSUE_INCLUDE_CLOSED" == "true" ]] && echo "#2 | New work (closed)"; }
+  _aw_issue_finished_state() { echo "closed"; }
+  _aw_launch_worktree() { :; }
+  gum() {
+    case "$1" in
+      style) echo "${@: -1}" ;;
+      filter) head -n 1 ;;
+      input) echo "work/2-new-work" ;;
+      confirm) echo "confirm: $2" >> "$BATS_TEST_TMPDIR/confirms"; [[ -z "${DECLINE:-}" ]] ;;
+    esac
+  }
+  _is_autoselect_disabled() { return 0; }
+
+  DECLINE=1 run _aw_issue --include-closed
+  [ "$status" -eq "$AW_EXIT_CANCELLED" ]
+  [[ "$output" == *"GitHub issue #2 is already closed"* ]]
+  [ ! -f "$BATS_TEST_TMPDIR/added" ]
+
+  run _aw_issue --include-closed
+  [ "$status" -eq 0 ]
+  grep -q "Create a worktree for it anyway?" "$BATS_TEST_TMPDIR/confirms"
+  grep -qx "work/2-new-work" "$BATS_TEST_TMPDIR/added"
+
+  _aw_init_issue_provider() { echo "jira"; }
+  run _aw_issue --include-closed
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_issue <id> --resume: prints the cd path of the issue's worktree" {
+  _stub_milestone_provider
+  source "${REPO_ROOT}/src/commands/resume.sh"
+  _aw_init_issue_provider() { echo "github"; }
+  _aw_touch_last_accessed() { :; }
+  _aw_stdin_is_tty() { return 0; }
+
+  run _aw_issue 1 --resume
+  [ "$status" -eq 0 ]
+  [[ "$output" == *'cd "/tmp/wt-1"'* ]]
+  [ ! -f "$BATS_TEST_TMPDIR/added" ]
+
+  run _aw_issue 2 --resume
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"No worktree for GitHub issue #2"* ]]
+
+  run _aw_issue --resume
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_issue <id>: without a terminal, resumes an existing worktree without asking" {
+  _stub_milestone_provider
+  source "${REPO_ROOT}/src/commands/resume.sh"
+  _aw_init_issue_provider() { echo "github"; }
+  _aw_touch_last_accessed() { :; }
+  _aw_stdin_is_tty() { return 1; }
+  gum() {
+    case "$1" in
+      style) echo "${@: -1}" ;;
+      confirm) echo "asked" >> "$BATS_TEST_TMPDIR/confirms"; return 1 ;;
+    esac
+  }
+
+  run _aw_issue 1
+  [ "$status" -eq 0 ]
+  [[ "$output" == *'cd "/tmp/wt-1"'* ]]
+  [ ! -f "$BATS_TEST_TMPDIR/confirms" ]
+}
+
+@test "_aw_issue <id> --quiet: prints only the created worktree path" {
+  _stub_milestone_provider
+  _aw_init_issue_provider() { echo "github"; }
+  # The real _aw_add_worktree prints just the path in quiet mode
+  _aw_add_worktree() {
+    _AW_CREATED_WORKTREE_PATH="/tmp/wt-2"
+    _aw_is_quiet && echo "$_AW_CREATED_WORKTREE_PATH"
+    return 0
+  }
+  _aw_launch_worktree() { :; }
+  gum() {
+    case "$1" in
+      style) echo "${@: -1}" ;;
+      input) echo "work/2-issue-2" ;;
+    esac
+  }
+
+  run _aw_issue 2
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Confirm branch name:"* ]]
+
+  _AW_QUIET=true run _aw_issue 2
+  [ "$status" -eq 0 ]
+  [ "$output" = "/tmp/wt-2" ]
+
+  # Errors still reach stderr
+  _AW_QUIET=true run _aw_issue --bogus
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  [[ "$output" == *"Unknown option: --bogus"* ]]
+}
+
+@test "_aw_issue --milestone: an empty milestone is reported, not treated as a failure" {
+  _stub_milestone_provider
+  _aw_init_issue_provider() { echo "github"; }
+  _aw_github_list_issues_by_milestone() { return 0; }
+  _aw_list_issues() { echo "#9 | Outside the milestone"; }
+
+  run _aw_issue --milestone "Sprint 12"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *'No open issues found in milestone "Sprint 12"'* ]]
+  [[ "$output" != *"Outside the milestone"* ]]
+}
+
+@test "_aw_issue --milestone: a missing name is a usage error" {
+  _stub_milestone_provider
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  run _aw_issue --milestone
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  [[ "$output" == *"--milestone requires a name"* ]]
+}
+
+# ============================================================================
+# pr --create [--draft]
+# ============================================================================
+
+@test "_aw_pr: --draft without --create is a usage error" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  run _aw_pr --draft
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_pr_create: refuses to open a PR from the default branch" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  git branch -m main
+  _aw_create_pr() { echo "should not be called"; }
+  run _aw_pr_create github true
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  [[ "$output" != *"should not be called"* ]]
+}
+
+@test "_aw_pr_create: creates a draft PR for a pushed branch" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  git branch -m main
+  git checkout -q -b feature/draft-me
+  # An upstream is already configured, so nothing is pushed
+  git branch -q --set-upstream-to=main
+  _aw_create_pr() { echo "https://github.com/o/r/pull/1 draft=$2"; }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  run _aw_pr_create github true
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Created draft PR: https://github.com/o/r/pull/1 draft=true"* ]]
+}
+
+@test "_aw_pr: --suggest-reviewers without --create is a usage error" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  run _aw_pr --suggest-reviewers
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_pr --author/--not-author: filter the PR list" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  _aw_get_pr_provider() { echo "github"; }
+  _aw_require_provider() { return 0; }
+  _aw_list_prs() { echo "provider=$1 author=$2 not_author=$3" > "$BATS_TEST_TMPDIR/list_prs"; }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  run _aw_pr --author alice --not-author @me
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"No open PRs by alice not by @me found"* ]]
+  [ "$(cat "$BATS_TEST_TMPDIR/list_prs")" = "provider=github author=alice not_author=@me" ]
+}
+
+@test "_aw_pr --author: usage errors" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  _aw_get_pr_provider() { echo "gitlab"; }
+  _aw_require_provider() { return 0; }
+
+  run _aw_pr --author
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  run _aw_pr --create --author alice
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  run _aw_pr 12 --not-author alice
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  # glab has no way to leave an author out
+  run _aw_pr --not-author alice
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_pr <n> --quiet: prints only the worktree path" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  _aw_get_pr_provider() { echo "github"; }
+  _aw_require_provider() { return 0; }
+  _aw_get_pr_details() { title="Fix it"; head_ref="feature/fix"; base_ref="main"; author="alice"; }
+  _aw_get_pr_checks() { echo "1 0 0"; }
+  _aw_ensure_pr_worktree() { echo "$5" > "$BATS_TEST_TMPDIR/pr_worktree"; }
+  _aw_pr_action_menu() { echo "continue"; }
+  _aw_name_tmux_window() { :; }
+  _resolve_ai_command() { AI_CMD=("skip" "skip"); }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  run _aw_pr 12
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"feature/fix -> main"* ]]
+
+  _AW_QUIET=true run _aw_pr 12
+  [ "$status" -eq 0 ]
+  [ "$output" = "$(cat "$BATS_TEST_TMPDIR/pr_worktree")" ]
+  [[ "$output" == */pr-12 ]]
+}
+
+@test "_aw_pr_create: --suggest-reviewers passes CODEOWNERS reviewers along" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  git branch -m main
+  git checkout -q -b feature/review-me
+  git branch -q --set-upstream-to=main
+  _aw_github_codeowners_reviewers() { printf 'alice\nmy-org/web\n'; }
+  _aw_create_pr() { shift 2; echo "https://github.com/o/r/pull/2 reviewers=$*"; }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  run _aw_pr_create github false true
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Requesting reviews from CODEOWNERS: alice my-org/web"* ]]
+  [[ "$output" == *"reviewers=alice my-org/web"* ]]
+
+  # Without the flag no reviewers are requested
+  run _aw_pr_create github false
+  [[ "$output" == *"reviewers="* ]]
+  [[ "$output" != *"alice"* ]]
+}
+
+@test "_aw_ensure_pr_worktree: checks out a fork PR on a temporary pr-<n> branch" {
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  # Run spinner commands directly
+  gum() {
+    if [[ "$1" == "spin" ]]; then
+      while [[ "$1" != "--" ]]; do shift; done
+      shift
+      "$@"
+    fi
+  }
+  _aw_setup_environment() { :; }
+
+  # The fork's head branch shares the name of a local branch
+  git checkout -q -b feature/fork-head
+  echo "fork" > fork.txt
+  git add fork.txt
+  git commit -q -m "Fork change"
+  local fork_sha=$(git rev-parse HEAD)
+  git checkout -q -
+  local base_ref=$(git rev-parse --abbrev-ref HEAD)
+  git update-ref refs/pull/9/head "$fork_sha"
+  git branch -q -f feature/fork-head HEAD
+  git remote add origin "$TEST_REPO_DIR"
+
+  _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"
+  local wt_path="$_AW_WORKTREE_BASE/pr-9"
+  _aw_ensure_pr_worktree github 9 feature/fork-head "$base_ref" "$wt_path" true
+  cd "$TEST_REPO_DIR"
+
+  assert_worktree_exists "$wt_path"
+  [ "$(git -C "$wt_path" rev-parse --abbrev-ref HEAD)" = "pr-9" ]
+  [ "$(git rev-parse pr-9)" = "$fork_sha" ]
+  [ "$(git config branch.pr-9.aw-fork-pr)" = "9" ]
+  # The local branch of the same name is left alone
+  [ "$(git rev-parse feature/fork-head)" != "$fork_sha" ]
+
+  rm -rf "$_AW_WORKTREE_BASE"
+}
+
+@test "_aw_ensure_pr_worktree: refuses a fork PR when pr-<n> is an unrelated local branch" {
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  gum() {
+    if [[ "$1" == "spin" ]]; then
+      while [[ "$1" != "--" ]]; do shift; done
+      shift
+      "$@"
+    elif [[ "$1" == "style" ]]; then
+      echo "${@: -1}"
+    fi
+  }
+
+  local base_ref=$(git rev-parse --abbrev-ref HEAD)
+  git commit -q --allow-empty -m "Fork change"
+  local fork_sha=$(git rev-parse HEAD)
+  git reset -q --hard HEAD~1
+  git update-ref refs/pull/9/head "$fork_sha"
+  git remote add origin "$TEST_REPO_DIR"
+  # A branch of the user's own that happens to be called pr-9
+  git branch -q pr-9
+  local user_sha=$(git rev-parse pr-9)
+
+  _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"
+  local wt_path="$_AW_WORKTREE_BASE/pr-9"
+  run _aw_ensure_pr_worktree github 9 feature/fork-head "$base_ref" "$wt_path" true
+  [ "$status" -eq "$AW_EXIT_EXISTS" ]
+  [[ "$output" == *"Branch 'pr-9' already exists"* ]]
+  [ ! -d "$wt_path" ]
+  [ "$(git rev-parse pr-9)" = "$user_sha" ]
+  [ -z "$(git config branch.pr-9.aw-fork-pr)" ]
+
+  rm -rf "$_AW_WORKTREE_BASE"
+}
+
+@test "_aw_pr_show_checks: warns when checks are failing" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  _aw_get_pr_checks() { echo "3 1 2"; }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  run _aw_pr_show_checks github 7
+  [ "$status" -eq 0 ]
+  [ "$output" = "⚠ Checks: ✓ 3 passed, ✗ 1 failed, ● 2 pending" ]
+}
+
+@test "_aw_pr_show_checks: stays quiet when checks are unavailable" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  run _aw_pr_show_checks gitlab 7
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
+}
+
+@test "_aw_pr_show_diff_stat: colors additions green and deletions red" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  gum() { [[ "$1" == "style" ]] && echo "<${3}:${@: -1}>"; return 0; }
+
+  local base=$(git rev-parse --abbrev-ref HEAD)
+  git update-ref "refs/remotes/origin/$base" HEAD
+  printf 'one\ntwo\nthree\n' > file.txt
+  git add file.txt
+  git commit -q -m "base file"
+  git update-ref "refs/remotes/origin/$base" HEAD
+  git checkout -q -b feature/stat
+  printf 'one\n2\nthree\nfour\n' > file.txt
+  git commit -q -am "change"
+
+  run _aw_pr_show_diff_stat "$base"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"file.txt | 3 <2:++><1:->"* ]]
+  [[ "$output" == *"1 file(s) changed, <2:+2> <1:-1>"* ]]
+}
+
+@test "_aw_pr_show_diff_stat: compares against the local base without origin's, and shows nothing without a merge base" {
+  source "${REPO_ROOT}/src/commands/pr.sh"
+  gum() { [[ "$1" == "style" ]] && echo "${@: -1}"; return 0; }
+
+  local base=$(git rev-parse --abbrev-ref HEAD)
+  local i
+  for i in 1 2 3 4 5 6; do
+    echo "$i" > "old-$i.txt"
+    git add "old-$i.txt" && git commit -q -m "old $i"
+  done
+  git checkout -q -b feature/local-base
+  echo "new" > new.txt
+  git add new.txt && git commit -q -m "change"
+
+  run _aw_pr_show_diff_stat "$base"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Changes vs $base"* ]]
+  [[ "$output" == *"new.txt"* ]]
+  [[ "$output" != *"old-"* ]]
+
+  run _aw_pr_show_diff_stat "no-such-base"
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
+}
@@ -6,7 +6,24 @@
 #   - _aw_get_worktree_list: returns main worktree only when no extras
 #   - _aw_list: empty worktree list handling
 #   - _aw_list: merged/closed issue detection (mocked _aw_check_issue_merged)
+#   - _aw_list: shows the recorded source issue under a worktree
+#   - _aw_list --size: size column, _aw_format_size, waiting only for its own jobs
+#   - _aw_get_ahead_behind / _aw_format_ahead_behind: ↑ahead ↓behind in list and resume
+#   - _aw_register_repo / _aw_list --all-repos: repository registry, grouped listing
+#   - _aw_list: keep-alive worktrees are tagged and never offered as stale
+#   - _aw_list: locked worktrees are tagged and never offered for cleanup
+#   - _aw_list: age colors follow auto-worktree.age-warn-days / age-stale-days
+#   - _aw_list: worktrees show how many times they were opened
+#   - _aw_list --format: placeholder templates, escapes, unknown fields rejected up front
+#   - _aw_list --since / _aw_parse_duration: only recently committed or opened worktrees
+#   - _aw_list --count-only: a bare worktree count for shell prompts
+#   - _aw_list_compute_status: per-worktree git state read in batches, in list order,
+#     waiting only for its own jobs
+#   - _aw_list --no-status / auto-worktree.list-no-status: name, branch and age only
+#   - _aw_list --quiet: only the worktree rows, no header or cleanup offer
+#   - _aw_truncate / _aw_terminal_width: names and paths fit the terminal, piped output is untouched
 #   - _aw_resume: empty worktree list handling
+#   - _aw_resume --list: most recently accessed first, cd path fallback
 
 REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
 
@@ -29,10 +46,14 @@ setup() {
   # Source the utility and library files
   # shellcheck source=../src/lib/utils.sh
   source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
   # shellcheck source=../src/providers/common.sh
   source "${REPO_ROOT}/src/providers/common.sh"
   # shellcheck source=../src/lib/worktree.sh
   source "${REPO_ROOT}/src/lib/worktree.sh"
+  # shellcheck source=../src/lib/metadata.sh
+  source "${REPO_ROOT}/src/lib/metadata.sh"
   # shellcheck source=../src/commands/list.sh
   source "${REPO_ROOT}/src/commands/list.sh"
   # shellcheck source=../src/commands/resume.sh
@@ -283,6 +304,89 @@ _make_worktree() {
 # _aw_list — worktree with no changes from default marked [no changes]
 # ===========================================================================
 
+@test "_aw_list: shows recorded issue metadata beneath the worktree" {
+  cd "$TEST_REPO_DIR"
+  _make_worktree "work/ENG-7-login" >/dev/null
+  _aw_record_issue_metadata "work/ENG-7-login" "linear" "ENG-7" "Fix login"
+
+  _aw_check_issue_merged() { return 1; }
+  _aw_check_issue_closed() { return 1; }
+  _aw_linear_check_completed() { return 1; }
+  _aw_jira_check_resolved() { return 1; }
+  _aw_check_branch_pr_merged() { return 1; }
+  _aw_has_unpushed_commits() { return 1; }
+  _aw_check_no_changes_from_default() { return 1; }
+  gum() {
+    if [[ "$1" == "style" ]]; then
+      echo "${@: -1}"
+    elif [[ "$1" == "confirm" ]]; then
+      return 1
+    fi
+  }
+
+  run _aw_list
+  [ "$status" -eq 0 ]
+  echo "$output" | grep -q "↳ Linear ENG-7: Fix login"
+}
+
+@test "_aw_list --size: shows a size column for each worktree" {
+  cd "$TEST_REPO_DIR"
+  _make_worktree "work/55-sized" >/dev/null
+
+  _aw_check_issue_merged() { return 1; }
+  _aw_check_issue_closed() { return 1; }
+  _aw_check_branch_pr_merged() { return 1; }
+  _aw_has_unpushed_commits() { return 1; }
+  _aw_check_no_changes_from_default() { return 1; }
+  _aw_get_dir_size_kb() { echo 2048; }
+  gum() {
+    if [[ "$1" == "style" ]]; then
+      echo "${@: -1}"
+    elif [[ "$1" == "confirm" ]]; then
+      return 1
+    fi
+  }
+
+  run _aw_list --size
+  [ "$status" -eq 0 ]
+  echo "$output" | grep -q "2.0M  wt-work-55-sized"
+}
+
+@test "_aw_list: rejects unknown options" {
+  cd "$TEST_REPO_DIR"
+  run _aw_list --bogus
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_list_compute_sizes: doesn't wait for unrelated background jobs" {
+  cd "$TEST_REPO_DIR"
+  _make_worktree "work/56-sized" >/dev/null
+  _aw_get_dir_size_kb() { echo 2048; }
+  local results_dir="$BATS_TEST_TMPDIR/sizes"
+  mkdir -p "$results_dir"
+  sleep 30 &
+  local unrelated=$!
+
+  SECONDS=0
+  _aw_list_compute_sizes "$results_dir" "$(_aw_get_worktree_list)"
+  local elapsed=$SECONDS
+  kill "$unrelated"
+  [ "$elapsed" -lt 10 ]
+  [ "$(cat "$results_dir/2")" = "2.0M" ]
+}
+
+@test "_aw_format_size: formats kilobytes as K, M and G" {
+  [ "$(_aw_format_size 512)" = "512K" ]
+  [ "$(_aw_format_size 1536)" = "1.5M" ]
+  [ "$(_aw_format_size 3145728)" = "3.0G" ]
+}
+
+@test "_aw_get_dir_size_kb: measures a directory" {
+  run _aw_get_dir_size_kb "$TEST_REPO_DIR" 10
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ ^[0-9]+$ ]]
+}
+
 @test "_aw_list: marks worktree as [no changes] when identical to default branch" {
   cd "$TEST_REPO_DIR"
   local wt_path
@@ -446,3 +550,502 @@ _make_worktree() {
   # resume returns AW_EXIT_CANCELLED (130) or 0 when cancelled
   [ "$status" -eq 0 ] || [ "$status" -eq 130 ]
 }
+
+# ===========================================================================
+# _aw_resume --list — recent worktrees
+# ===========================================================================
+
+@test "_aw_resume --list: orders worktrees by last access, most recent first" {
+  cd "$TEST_REPO_DIR"
+  _make_worktree "work/1-older" >/dev/null
+  _make_worktree "work/2-newer" >/dev/null
+  git config branch.work/1-older.aw-last-accessed 1000
+  git config branch.work/2-newer.aw-last-accessed 2000
+
+  local capture_file="$BATS_TEST_TMPDIR/filter"
+  gum() {
+    if [[ "$1" == "filter" ]]; then
+      cat > "$capture_file"
+      echo ""
+    fi
+  }
+
+  run _aw_resume --list
+  [ "$status" -eq "$AW_EXIT_CANCELLED" ]
+  [[ "$(head -1 "$capture_file")" == *"(work/2-newer) accessed"* ]]
+  [[ "$(sed -n 2p "$capture_file")" == *"(work/1-older) accessed"* ]]
+}
+
+@test "_aw_resume --list: prints the cd path when no tmux session exists" {
+  cd "$TEST_REPO_DIR"
+  local wt_path
+  wt_path=$(_make_worktree "work/3-pick-me")
+
+  gum() {
+    if [[ "$1" == "filter" ]]; then
+      head -1
+    fi
+  }
+  tmux() { return 1; }
+
+  run _aw_resume --list
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"cd \"$wt_path\""* ]]
+  [[ "$(git config --get branch.work/3-pick-me.aw-last-accessed)" =~ ^[0-9]+$ ]]
+}
+
+@test "_aw_resume: rejects unknown options" {
+  cd "$TEST_REPO_DIR"
+  run _aw_resume --bogus
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+# ---------------------------------------------------------------------------
+# Repository registry / list --all-repos
+# ---------------------------------------------------------------------------
+
+@test "_aw_register_repo: records the main worktree once, even from a linked worktree" {
+  export XDG_CONFIG_HOME="$BATS_TEST_TMPDIR/config"
+  local wt_path="${TEST_REPO_DIR}-wt"
+  git worktree add -q -b feature/registry "$wt_path"
+
+  cd "$TEST_REPO_DIR" && _aw_register_repo
+  cd "$wt_path" && _aw_register_repo
+  cd "$TEST_REPO_DIR"
+
+  [ "$(cat "$XDG_CONFIG_HOME/auto-worktree/repos")" = "$TEST_REPO_DIR" ]
+  rm -rf "$wt_path"
+}
+
+@test "_aw_registered_repos: skips repositories that no longer exist" {
+  export XDG_CONFIG_HOME="$BATS_TEST_TMPDIR/config"
+  mkdir -p "$XDG_CONFIG_HOME/auto-worktree"
+  printf '%s\n%s\n' "/nonexistent/repo" "$TEST_REPO_DIR" > "$XDG_CONFIG_HOME/auto-worktree/repos"
+
+  run _aw_registered_repos
+  [ "$output" = "$TEST_REPO_DIR" ]
+}
+
+@test "_aw_list --all-repos: lists worktrees grouped by repository from anywhere" {
+  export XDG_CONFIG_HOME="$BATS_TEST_TMPDIR/config"
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  local other_repo="${TEST_REPO_DIR}-other"
+  git init -q "$other_repo"
+  git -C "$other_repo" -c user.email=t@e.com -c user.name=T commit -q --allow-empty -m init
+  git -C "$other_repo" worktree add -q -b feature/elsewhere "${other_repo}-wt"
+  git worktree add -q -b feature/here "${TEST_REPO_DIR}-wt"
+
+  cd "$TEST_REPO_DIR" && _aw_register_repo
+  cd "$other_repo" && _aw_register_repo
+  cd /
+
+  run _aw_list --all-repos
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"$(basename "$TEST_REPO_DIR")-wt (feature/here)"* ]]
+  [[ "$output" == *"$(basename "$other_repo")-wt (feature/elsewhere)"* ]]
+  [[ "$output" == *"${other_repo}-wt"* ]]
+  [[ "$output" == *"2 worktree(s) across 2 repositories"* ]]
+
+  rm -rf "$other_repo" "${other_repo}-wt" "${TEST_REPO_DIR}-wt"
+}
+
+@test "_aw_list --all-repos: explains an empty registry and rejects --size" {
+  export XDG_CONFIG_HOME="$BATS_TEST_TMPDIR/config"
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  run _aw_list --all-repos
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"No repositories registered yet"* ]]
+
+  run _aw_list --all-repos --size
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+# ---------------------------------------------------------------------------
+# Ahead/behind upstream
+# ---------------------------------------------------------------------------
+
+@test "_aw_format_ahead_behind: shows commits ahead of and behind the upstream" {
+  cd "$TEST_REPO_DIR"
+  local wt_path
+  wt_path=$(_make_worktree "feature/sync")
+  git -C "$wt_path" branch -q --set-upstream-to=main
+
+  # In sync: nothing to show
+  run _aw_format_ahead_behind "$wt_path"
+  [ -z "$output" ]
+
+  git -C "$wt_path" commit -q --allow-empty -m "ahead 1"
+  git -C "$wt_path" commit -q --allow-empty -m "ahead 2"
+  run _aw_get_ahead_behind "$wt_path"
+  [ "$output" = "2 0" ]
+  run _aw_format_ahead_behind "$wt_path"
+  [ "$output" = "↑2" ]
+
+  git commit -q --allow-empty -m "upstream moved"
+  run _aw_format_ahead_behind "$wt_path"
+  [ "$output" = "↑2 ↓1" ]
+}
+
+@test "_aw_format_ahead_behind: shows nothing without an upstream" {
+  cd "$TEST_REPO_DIR"
+  local wt_path
+  wt_path=$(_make_worktree "feature/no-upstream")
+  git -C "$wt_path" commit -q --allow-empty -m "local only"
+
+  run _aw_get_ahead_behind "$wt_path"
+  [ "$status" -eq 1 ]
+  run _aw_format_ahead_behind "$wt_path"
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
+}
+
+@test "_aw_list: shows ↑ahead ↓behind next to a worktree's age" {
+  cd "$TEST_REPO_DIR"
+  local wt_path
+  wt_path=$(_make_worktree "feature/list-sync")
+  git -C "$wt_path" branch -q --set-upstream-to=main
+  git -C "$wt_path" commit -q --allow-empty -m "ahead"
+  git commit -q --allow-empty -m "behind"
+
+  _aw_check_branch_pr_merged() { return 1; }
+  _aw_check_no_changes_from_default() { return 1; }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; elif [[ "$1" == "confirm" ]]; then return 1; fi; }
+
+  run _aw_list
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"(feature/list-sync)"*"↑1 ↓1"* ]]
+}
+
+@test "_aw_list: never offers a keep-alive worktree as stale" {
+  cd "$TEST_REPO_DIR"
+  local wt_path
+  wt_path=$(_make_worktree "feature/long-running")
+  local week_ago=$(( $(date +%s) - 7 * 24 * 60 * 60 ))
+  GIT_COMMITTER_DATE="@$week_ago" GIT_AUTHOR_DATE="@$week_ago" \
+    git -C "$wt_path" commit -q --allow-empty -m "old work"
+
+  _aw_check_branch_pr_merged() { return 1; }
+  _aw_check_no_changes_from_default() { return 1; }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; elif [[ "$1" == "confirm" ]]; then return 1; fi; }
+
+  run _aw_list
+  [[ "$output" == *"Worktrees that can be cleaned up"* ]]
+
+  _aw_set_branch_metadata "feature/long-running" "keep-alive" "true"
+  run _aw_list
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"(feature/long-running)"*"[keep-alive]"* ]]
+  [[ "$output" != *"Worktrees that can be cleaned up"* ]]
+}
+
+@test "_aw_list: never offers a locked worktree for cleanup" {
+  cd "$TEST_REPO_DIR"
+  local wt_path
+  wt_path=$(_make_worktree "feature/integration")
+  local week_ago=$(( $(date +%s) - 7 * 24 * 60 * 60 ))
+  GIT_COMMITTER_DATE="@$week_ago" GIT_AUTHOR_DATE="@$week_ago" \
+    git -C "$wt_path" commit -q --allow-empty -m "old work"
+
+  _aw_check_branch_pr_merged() { return 1; }
+  _aw_check_no_changes_from_default() { return 1; }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; elif [[ "$1" == "confirm" ]]; then return 1; fi; }
+
+  run _aw_list
+  [[ "$output" == *"Worktrees that can be cleaned up"* ]]
+
+  git worktree lock "$wt_path"
+  run _aw_list
+  git worktree unlock "$wt_path"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"(feature/integration)"*"[locked]"* ]]
+  [[ "$output" != *"Worktrees that can be cleaned up"* ]]
+}
+
+@test "_aw_list --quiet: prints only the worktree rows" {
+  cd "$TEST_REPO_DIR"
+  local wt_path
+  wt_path=$(_make_worktree "feature/quiet")
+  local week_ago=$(( $(date +%s) - 7 * 24 * 60 * 60 ))
+  GIT_COMMITTER_DATE="@$week_ago" GIT_AUTHOR_DATE="@$week_ago" \
+    git -C "$wt_path" commit -q --allow-empty -m "old work"
+
+  _aw_check_branch_pr_merged() { return 1; }
+  _aw_check_no_changes_from_default() { return 1; }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; elif [[ "$1" == "confirm" ]]; then return 1; fi; }
+
+  _AW_QUIET=true run _aw_list
+  [ "$status" -eq 0 ]
+  [ "${#lines[@]}" -eq 1 ]
+  [[ "${lines[0]}" == *"wt-feature-quiet (feature/quiet) [7d ago]"* ]]
+}
+
+@test "_aw_list: age colors follow age-warn-days and age-stale-days" {
+  cd "$TEST_REPO_DIR"
+  local wt_path
+  wt_path=$(_make_worktree "feature/week-old")
+  local week_ago=$(( $(date +%s) - 7 * 24 * 60 * 60 ))
+  GIT_COMMITTER_DATE="@$week_ago" GIT_AUTHOR_DATE="@$week_ago" \
+    git -C "$wt_path" commit -q --allow-empty -m "old work"
+
+  _aw_check_branch_pr_merged() { return 1; }
+  _aw_check_no_changes_from_default() { return 1; }
+  gum() {
+    if [[ "$1" == "style" ]] && [[ "${@: -1}" == "[7d ago]" ]]; then
+      echo "color=$3"
+    elif [[ "$1" == "style" ]]; then
+      echo "${@: -1}"
+    elif [[ "$1" == "confirm" ]]; then
+      return 1
+    fi
+  }
+
+  run _aw_list
+  [[ "$output" == *"color=1"* ]]
+  [[ "$output" == *"Worktrees that can be cleaned up"* ]]
+
+  git config auto-worktree.age-stale-days 10
+  run _aw_list
+  [[ "$output" == *"color=3"* ]]
+  [[ "$output" != *"Worktrees that can be cleaned up"* ]]
+
+  git config auto-worktree.age-warn-days 8
+  run _aw_list
+  [[ "$output" == *"color=2"* ]]
+}
+
+@test "_aw_list: shows how many times a worktree was opened" {
+  cd "$TEST_REPO_DIR"
+  _make_worktree "feature/opened" >/dev/null
+  _make_worktree "feature/never-opened" >/dev/null
+  _aw_touch_last_accessed "feature/opened"
+  _aw_touch_last_accessed "feature/opened"
+  _aw_touch_last_accessed "feature/opened"
+
+  _aw_check_branch_pr_merged() { return 1; }
+  _aw_check_no_changes_from_default() { return 1; }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; elif [[ "$1" == "confirm" ]]; then return 1; fi; }
+
+  run _aw_list
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"(feature/opened)"*"[opened 3×]"* ]]
+  [[ "$(echo "$output" | grep -F "(feature/never-opened)")" != *"[opened"* ]]
+}
+
+@test "_aw_list --format: prints one templated line per worktree" {
+  cd "$TEST_REPO_DIR"
+  local wt_path
+  wt_path=$(_make_worktree "feature/formatted")
+  _aw_touch_last_accessed "feature/formatted"
+  _aw_record_issue_metadata "feature/formatted" "github" "12" "Fix A & B"
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  run _aw_list --format '{branch}\t{path}\t{opened}'
+  [ "$status" -eq 0 ]
+  [ "$output" = "feature/formatted"$'\t'"$wt_path"$'\t'"1" ]
+
+  run _aw_list --format '{name}: {issue} ({age})'
+  [ "$output" = "wt-feature-formatted: GitHub #12: Fix A & B (0h ago)" ]
+}
+
+@test "_aw_list --format: rejects unknown fields and lists the available ones" {
+  cd "$TEST_REPO_DIR"
+  _make_worktree "feature/formatted" >/dev/null
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  run _aw_list --format '{branch} {Path}'
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  [[ "$output" == *"Unknown field '{Path}'"* ]]
+  [[ "$output" == *"Available fields: {name} {branch} {path} {timestamp} {age} {opened} {issue}"* ]]
+  [[ "$output" != *"feature/formatted"* ]]
+
+  run _aw_list --format
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  run _aw_list --format '{path}' --size
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_parse_duration: converts durations to seconds" {
+  [ "$(_aw_parse_duration 30m)" = "1800" ]
+  [ "$(_aw_parse_duration 24h)" = "86400" ]
+  [ "$(_aw_parse_duration 7d)" = "604800" ]
+  [ "$(_aw_parse_duration 1h30m)" = "5400" ]
+  [ "$(_aw_parse_duration 2w)" = "1209600" ]
+  [ "$(_aw_parse_duration 08h)" = "28800" ]
+  run _aw_parse_duration 24
+  [ "$status" -ne 0 ]
+  run _aw_parse_duration 1h5x
+  [ "$status" -ne 0 ]
+  run _aw_parse_duration ""
+  [ "$status" -ne 0 ]
+}
+
+@test "_aw_list --since: keeps worktrees committed to or opened within the window" {
+  cd "$TEST_REPO_DIR"
+  local week_ago=$(( $(date +%s) - 7 * 24 * 60 * 60 ))
+  local wt_path
+  _make_worktree "feature/recent" >/dev/null
+  wt_path=$(_make_worktree "feature/old")
+  GIT_COMMITTER_DATE="@$week_ago" GIT_AUTHOR_DATE="@$week_ago" \
+    git -C "$wt_path" commit -q --allow-empty -m "old work"
+  wt_path=$(_make_worktree "feature/old-but-opened")
+  GIT_COMMITTER_DATE="@$week_ago" GIT_AUTHOR_DATE="@$week_ago" \
+    git -C "$wt_path" commit -q --allow-empty -m "old work"
+  _aw_touch_last_accessed "feature/old-but-opened"
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  run _aw_list --since 24h --format '{branch}'
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"feature/recent"* ]]
+  [[ "$output" == *"feature/old-but-opened"* ]]
+  [[ "$(echo "$output" | grep -cx "feature/old")" -eq 0 ]]
+
+  run _aw_list --since 8d --format '{branch}'
+  [[ "$(echo "$output" | grep -cx "feature/old")" -eq 1 ]]
+}
+
+@test "_aw_list --since: reports when nothing was active and rejects bad durations" {
+  cd "$TEST_REPO_DIR"
+  local wt_path
+  wt_path=$(_make_worktree "feature/old")
+  local week_ago=$(( $(date +%s) - 7 * 24 * 60 * 60 ))
+  GIT_COMMITTER_DATE="@$week_ago" GIT_AUTHOR_DATE="@$week_ago" \
+    git -C "$wt_path" commit -q --allow-empty -m "old work"
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  run _aw_list --since 24h
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"No worktrees active in the last 24h"* ]]
+
+  run _aw_list --since yesterday
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  [[ "$output" == *"--since requires a duration"* ]]
+
+  run _aw_list --since
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_truncate: shortens from the end, or from the start for paths" {
+  [ "$(_aw_truncate "feature-branch" 8)" = "feature…" ]
+  [ "$(_aw_truncate "/home/me/src/repo" 8 start)" = "…rc/repo" ]
+  [ "$(_aw_truncate "short" 8)" = "short" ]
+}
+
+@test "_aw_terminal_width: prefers \$COLUMNS" {
+  COLUMNS=97 run _aw_terminal_width
+  [ "$output" = "97" ]
+}
+
+@test "_aw_list: shortens long worktree names to fit the terminal" {
+  cd "$TEST_REPO_DIR"
+  local long_branch="feature/a-really-quite-long-branch-name-for-layout"
+  _make_worktree "$long_branch" > /dev/null
+
+  _aw_check_branch_pr_merged() { return 1; }
+  _aw_check_no_changes_from_default() { return 1; }
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; elif [[ "$1" == "confirm" ]]; then return 1; fi; }
+
+  # Not a terminal: the full name is kept
+  run _aw_list
+  [[ "$output" == *"wt-feature-a-really-quite-long-branch-name-for-layout ($long_branch)"* ]]
+
+  _aw_stdout_is_tty() { return 0; }
+  COLUMNS=90 run _aw_list
+  [ "$status" -eq 0 ]
+  local line=$(echo "$output" | grep "($long_branch)")
+  [[ "$line" == "  wt-feature-a-real"*"… ($long_branch) [0h ago]" ]]
+}
+
+@test "_aw_list --count-only: prints only the number of worktrees" {
+  cd "$TEST_REPO_DIR"
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  # The count must not need the per-worktree age lookups
+  _aw_get_worktree_timestamp() { echo "called" >> "$BATS_TEST_TMPDIR/timestamps"; }
+
+  run _aw_list --count-only
+  [ "$status" -eq 0 ]
+  [ "$output" = "1" ]
+
+  _make_worktree "feature/one" >/dev/null
+  _make_worktree "feature/two" >/dev/null
+  run _aw_list --count-only
+  [ "$output" = "3" ]
+  [ ! -f "$BATS_TEST_TMPDIR/timestamps" ]
+
+  run _aw_list --count-only --size
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_list_compute_status: records each worktree's git state by its position" {
+  cd "$TEST_REPO_DIR"
+  local wt_path
+  wt_path=$(_make_worktree "feature/ahead")
+  git -C "$wt_path" commit -q --allow-empty -m "local work"
+  _make_worktree "feature/same" >/dev/null
+  local results_dir="$BATS_TEST_TMPDIR/status"
+  mkdir -p "$results_dir"
+
+  _AW_LIST_STATUS_JOBS=2 _aw_list_compute_status "$results_dir" "$(_aw_get_worktree_list)"
+
+  [ "$(sed -n 1p "$results_dir/2")" = "feature/ahead" ]
+  [[ "$(sed -n 2p "$results_dir/2")" =~ ^[0-9]+$ ]]
+  [ "$(sed -n 3p "$results_dir/2")" -gt 0 ]
+  [ "$(sed -n 4p "$results_dir/2")" = "false" ]
+  [ "$(sed -n 1p "$results_dir/3")" = "feature/same" ]
+  [ "$(sed -n 4p "$results_dir/3")" = "true" ]
+}
+
+@test "_aw_list_compute_status: doesn't wait for unrelated background jobs" {
+  cd "$TEST_REPO_DIR"
+  _make_worktree "feature/quick" >/dev/null
+  local results_dir="$BATS_TEST_TMPDIR/status"
+  mkdir -p "$results_dir"
+  sleep 30 &
+  local unrelated=$!
+
+  SECONDS=0
+  _aw_list_compute_status "$results_dir" "$(_aw_get_worktree_list)"
+  local elapsed=$SECONDS
+  kill "$unrelated"
+  [ "$elapsed" -lt 10 ]
+  [ "$(sed -n 1p "$results_dir/2")" = "feature/quick" ]
+}
+
+@test "_aw_list: keeps worktree order when status is read in batches" {
+  cd "$TEST_REPO_DIR"
+  local i
+  for i in 1 2 3 4 5 6 7; do
+    _make_worktree "feature/order-$i" >/dev/null
+  done
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; return 1; }
+  _aw_check_branch_pr_merged() { return 1; }
+  _AW_LIST_STATUS_JOBS=3
+
+  run _aw_list
+  [ "$status" -eq 0 ]
+  local order=$(echo "$output" | grep -o "(feature/order-[0-9])" | tr -d '()' | tr '\n' ' ')
+  [ "$order" = "feature/order-1 feature/order-2 feature/order-3 feature/order-4 feature/order-5 feature/order-6 feature/order-7 " ]
+}
+
+@test "_aw_list --no-status: shows branch and age without merge or sync checks" {
+  cd "$TEST_REPO_DIR"
+  _make_worktree "feature/untouched" >/dev/null
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; return 1; }
+  _aw_check_branch_pr_merged() { echo "checked" >> "$BATS_TEST_TMPDIR/pr-checks"; return 1; }
+
+  run _aw_list
+  [ -f "$BATS_TEST_TMPDIR/pr-checks" ]
+  rm "$BATS_TEST_TMPDIR/pr-checks"
+
+  run _aw_list --no-status
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"(feature/untouched)"* ]]
+  [ ! -f "$BATS_TEST_TMPDIR/pr-checks" ]
+
+  git config auto-worktree.list-no-status true
+  run _aw_list
+  [[ "$output" == *"(feature/untouched)"* ]]
+  [ ! -f "$BATS_TEST_TMPDIR/pr-checks" ]
+}
@@ -0,0 +1,128 @@
+#!/usr/bin/env bats
+# Tests for src/commands/lock.sh
+#
+# Covers:
+#   - _aw_get_locked_worktrees / _aw_worktree_is_locked
+#   - _aw_lock_worktree (by branch, current worktree, --reason, main worktree,
+#     unknown branch, already locked)
+#   - _aw_unlock_worktree (locked and unlocked worktrees)
+#   - _aw_remove refusing a locked worktree
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+load 'helpers/git_assertions'
+
+setup() {
+  gum() {
+    case "$1" in
+      style) echo "${@: -1}" ;;
+      confirm) return 0 ;;
+    esac
+  }
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
+  # shellcheck source=../src/lib/metadata.sh
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  # shellcheck source=../src/lib/worktree.sh
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  # shellcheck source=../src/commands/remove.sh
+  source "${REPO_ROOT}/src/commands/remove.sh"
+  # shellcheck source=../src/commands/lock.sh
+  source "${REPO_ROOT}/src/commands/lock.sh"
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+
+  WT_BASE="${TEST_REPO_DIR}-worktrees"
+  mkdir -p "$WT_BASE"
+  git worktree add -q -b "integration" "$WT_BASE/integration"
+  WT_PATH=$(_aw_get_worktree_for_branch "integration")
+}
+
+teardown() {
+  git worktree unlock "$WT_PATH" 2>/dev/null || true
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+}
+
+@test "_aw_worktree_is_locked: follows git worktree lock" {
+  ! _aw_worktree_is_locked "$WT_PATH"
+  [ -z "$(_aw_get_locked_worktrees)" ]
+
+  git worktree lock --reason "long-lived" "$WT_PATH"
+  _aw_worktree_is_locked "$WT_PATH"
+  [ "$(_aw_get_locked_worktrees)" = "$WT_PATH" ]
+  # A list passed in is used instead of asking git
+  ! _aw_worktree_is_locked "$WT_PATH" ""
+}
+
+@test "_aw_lock_worktree: locks a worktree by branch" {
+  run _aw_lock_worktree integration
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Locked integration"* ]]
+  _aw_worktree_is_locked "$WT_PATH"
+}
+
+@test "_aw_lock_worktree: --reason is recorded with git" {
+  run _aw_lock_worktree --reason "nightly builds" integration
+  [ "$status" -eq 0 ]
+  git worktree list --porcelain | grep -q "^locked nightly builds$"
+}
+
+@test "_aw_lock_worktree: defaults to the current worktree" {
+  cd "$WT_PATH"
+  run _aw_lock_worktree
+  [ "$status" -eq 0 ]
+  _aw_worktree_is_locked "$WT_PATH"
+}
+
+@test "_aw_lock_worktree: refuses the main worktree and unknown branches" {
+  run _aw_lock_worktree "$(git symbolic-ref --short HEAD)"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"main worktree can't be locked"* ]]
+
+  run _aw_lock_worktree no-such-branch
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"No worktree found for branch or path: no-such-branch"* ]]
+}
+
+@test "_aw_lock_worktree: usage errors" {
+  run _aw_lock_worktree
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  run _aw_lock_worktree --reason
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  run _aw_lock_worktree integration other
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_lock_worktree: locking twice is not an error" {
+  git worktree lock "$WT_PATH"
+  run _aw_lock_worktree integration
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Already locked: integration"* ]]
+}
+
+@test "_aw_unlock_worktree: unlocks a locked worktree" {
+  git worktree lock "$WT_PATH"
+  run _aw_unlock_worktree integration
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Unlocked integration"* ]]
+  ! _aw_worktree_is_locked "$WT_PATH"
+
+  run _aw_unlock_worktree integration
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Not locked: integration"* ]]
+}
+
+@test "_aw_remove: refuses a locked worktree and says how to unlock it" {
+  git worktree lock "$WT_PATH"
+  run _aw_remove integration
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Worktree is locked"* ]]
+  [[ "$output" == *"auto-worktree unlock integration"* ]]
+  assert_worktree_exists "$WT_PATH"
+}
@@ -4,8 +4,22 @@
 # Coverage:
 #   - Branch name generation: kebab-case, issue numbers, truncation, special chars
 #   - Existing worktree detection: command switches to existing, no duplicate created
-#   - Hook execution: _aw_run_git_hooks called on creation, failing hook propagates error
+#   - Registered-but-missing worktrees: offer git worktree prune, then recreate
+#   - Hook execution: _aw_run_git_hooks called on creation, failing hook propagates error,
+#     --hooks/--no-hooks override auto-worktree.run-hooks
 #   - Environment setup trigger: _aw_setup_environment called after creation
+#   - Dependency install gate: auto-worktree.install-deps=false skips installs
+#   - Event stream: --events NDJSON lines for each creation phase
+#   - Worktree naming: auto-worktree.worktree-naming templates, flat dirs for nested branches, path collisions
+#   - tmux window naming: auto-worktree.tmux-window-name, only inside tmux
+#   - Shallow creation: new --depth N in shallow clones, fallbacks elsewhere
+#   - Dry run: new --dry-run validates and prints the plan without creating anything
+#   - Remote-only branches: checked out as tracking branches, fetched when needed;
+#     the remote is only asked for typed names or with fetching on
+#   - Fetch before create: auto-worktree.fetch-before-create, --fetch/--no-fetch, offline warning
+#   - Detached worktrees: new --detach <commit> checks out a commit without a branch
+#   - Batch creation: new --batch reads branch names from stdin, continues past failures
+#   - CI output: new --write-branch <file>, GITHUB_OUTPUT-style under GitHub Actions
 
 REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
 
@@ -17,6 +31,9 @@ setup() {
   gum() { :; }
   export -f gum
 
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
+
   _aw_get_issue_provider() { echo "github"; }
   export -f _aw_get_issue_provider
 
@@ -178,6 +195,93 @@ setup() {
   # Attempt to create another worktree for the same branch — must fail
   run _aw_create_worktree "work/100-dupe"
   [ "$status" -ne 0 ]
+  [ "$status" -eq "$AW_EXIT_EXISTS" ]
+
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+}
+
+@test "_aw_add_worktree: prunes a registered worktree whose directory was deleted and recreates it" {
+  setup_git_repo
+
+  gum() {
+    case "$1" in
+      spin) shift; while [[ "$1" != "--" && $# -gt 0 ]]; do shift; done; shift; "$@" ;;
+      confirm) return 0 ;;
+      style) echo "${@: -1}" ;;
+    esac
+  }
+  _aw_run_git_hooks() { :; }
+  _aw_install_dependencies_enabled() { return 1; }
+  _aw_install_dependencies() { :; }
+
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+
+  _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"
+  cd "$TEST_REPO_DIR"
+
+  local worktree_path="${_AW_WORKTREE_BASE}/work-110-gone"
+  mkdir -p "$_AW_WORKTREE_BASE"
+  git worktree add -q -b "work/110-gone" "$worktree_path"
+  rm -rf "$worktree_path"
+
+  run _aw_add_worktree "work/110-gone"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"registered"* ]]
+  [[ "$output" == *"Pruned the stale worktree registration"* ]]
+  assert_worktree_exists "$worktree_path"
+  [ -d "$worktree_path" ]
+
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+}
+
+@test "_aw_add_worktree: keeps the registration when pruning is declined" {
+  setup_git_repo
+
+  gum() {
+    case "$1" in
+      confirm) return 1 ;;
+      style) echo "${@: -1}" ;;
+    esac
+  }
+
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+
+  _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"
+  cd "$TEST_REPO_DIR"
+
+  local worktree_path="${_AW_WORKTREE_BASE}/work-111-gone"
+  mkdir -p "$_AW_WORKTREE_BASE"
+  git worktree add -q -b "work/111-gone" "$worktree_path"
+  rm -rf "$worktree_path"
+
+  run _aw_add_worktree "work/111-gone"
+  [ "$status" -eq "$AW_EXIT_EXISTS" ]
+  [[ "$output" == *"git still has it registered"* ]]
+  [[ "$output" == *"git worktree prune"* ]]
+  [[ "$output" != *"already has a worktree"* ]]
+  git worktree list --porcelain | grep -qxF "worktree $worktree_path"
+
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+}
+
+@test "_aw_add_worktree: reports an existing directory separately from a registration" {
+  setup_git_repo
+
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+
+  _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"
+  cd "$TEST_REPO_DIR"
+  mkdir -p "${_AW_WORKTREE_BASE}/work-112-taken"
+
+  run _aw_add_worktree "work/112-taken"
+  [ "$status" -eq "$AW_EXIT_EXISTS" ]
+  [[ "$output" == *"the directory is on disk"* ]]
+  [[ "$output" != *"registered"* ]]
 
   teardown_git_repo
   rm -rf "${TEST_REPO_DIR}-worktrees"
@@ -198,12 +302,15 @@ setup() {
   }
   export -f gum
 
-  # Stub _aw_setup_environment and _resolve_ai_command so they are no-ops
-  _aw_setup_environment() { :; }
+  # Stub environment setup and _resolve_ai_command so they are no-ops
+  _aw_run_git_hooks() { :; }
+  _aw_install_dependencies_enabled() { return 0; }
+  _aw_install_dependencies() { :; }
   _resolve_ai_command() { AI_CMD=("skip"); AI_CMD[1]="skip"; return 0; }
-  export -f _aw_setup_environment _resolve_ai_command
+  export -f _aw_run_git_hooks _aw_install_dependencies_enabled _aw_install_dependencies _resolve_ai_command
 
   source "${REPO_ROOT}/src/lib/worktree.sh"
+  source "${REPO_ROOT}/src/lib/metadata.sh"
 
   _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees-new"
   export _AW_WORKTREE_BASE
@@ -216,6 +323,7 @@ setup() {
 
   assert_worktree_exists "${_AW_WORKTREE_BASE}/work-101-new-feature"
   assert_branch_exists "work/101-new-feature"
+  [[ "$(git config --get branch.work/101-new-feature.aw-last-accessed)" =~ ^[0-9]+$ ]]
 
   teardown_git_repo
   rm -rf "${TEST_REPO_DIR}-worktrees-new"
@@ -380,6 +488,36 @@ setup() {
   teardown_git_repo
 }
 
+@test "_aw_setup_environment: installs dependencies unless auto-worktree.install-deps is false" {
+  setup_git_repo
+
+  source "${REPO_ROOT}/src/lib/hooks.sh"
+  source "${REPO_ROOT}/src/lib/environment.sh"
+
+  # Run spinner commands directly so the install step is observable
+  gum() {
+    case "$1" in
+      style) echo "${@: -1}" ;;
+      spin) while [[ "$1" != "--" ]]; do shift; done; shift; "$@" ;;
+    esac
+  }
+  npm() { echo "npm $*"; }
+
+  echo '{}' > "$TEST_REPO_DIR/package.json"
+
+  run _aw_setup_environment "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"npm --prefix $TEST_REPO_DIR install"* ]]
+
+  git -C "$TEST_REPO_DIR" config auto-worktree.install-deps false
+  run _aw_setup_environment "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [[ "$output" != *"npm --prefix"* ]]
+  [[ "$output" == *"Skipping dependency installation"* ]]
+
+  teardown_git_repo
+}
+
 @test "_aw_setup_environment: returns 0 (does not fail) for missing worktree path" {
   source "${REPO_ROOT}/src/lib/hooks.sh"
   source "${REPO_ROOT}/src/lib/environment.sh"
@@ -387,3 +525,907 @@ setup() {
   run _aw_setup_environment "/nonexistent/path/that/does/not/exist"
   [ "$status" -eq 0 ]
 }
+
+# ============================================================================
+# Quiet mode — _aw_is_quiet / --quiet output
+# ============================================================================
+
+@test "_aw_is_quiet: false by default, true when _AW_QUIET=true" {
+  run _aw_is_quiet
+  [ "$status" -eq 1 ]
+  _AW_QUIET=true run _aw_is_quiet
+  [ "$status" -eq 0 ]
+}
+
+@test "_aw_create_worktree: quiet mode prints only the created path" {
+  setup_git_repo
+
+  # Echo every gum call so decorative output would show up in $output
+  gum() {
+    if [[ "$1" == "spin" ]]; then
+      shift
+      while [[ "$1" != "--" && $# -gt 0 ]]; do shift; done
+      shift
+      "$@" >/dev/null 2>&1
+    else
+      echo "gum $*"
+    fi
+  }
+  _aw_run_git_hooks() { echo "running hooks"; }
+  _aw_install_dependencies_enabled() { return 0; }
+  _aw_install_dependencies() { echo "installing dependencies"; }
+  _resolve_ai_command() { AI_CMD=("skip"); AI_CMD[1]="skip"; return 0; }
+
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+
+  _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees-quiet"
+  mkdir -p "$_AW_WORKTREE_BASE"
+  cd "$TEST_REPO_DIR"
+
+  _AW_QUIET=true
+  run _aw_create_worktree "work/102-quiet"
+  [ "$status" -eq 0 ]
+  [ "$output" = "${_AW_WORKTREE_BASE}/work-102-quiet" ]
+
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees-quiet"
+}
+
+# ============================================================================
+# Event stream — --events NDJSON output
+# ============================================================================
+
+@test "_aw_emit_event: prints nothing unless --events is active" {
+  run _aw_emit_event creating-worktree started
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
+}
+
+@test "_aw_emit_event: escapes quotes, backslashes and newlines in errors" {
+  _AW_EVENTS=true
+  run _aw_emit_event creating-branch failed $'bad "name"\\x\nnext' "/tmp/wt"
+  [ "$status" -eq 0 ]
+  [ "$output" = '{"phase":"creating-branch","status":"failed","error":"bad \"name\"\\x\nnext","path":"/tmp/wt"}' ]
+}
+
+@test "_aw_create_worktree: --events emits one JSON line per phase and skips the AI tool" {
+  setup_git_repo
+
+  gum() {
+    if [[ "$1" == "spin" ]]; then
+      shift
+      while [[ "$1" != "--" && $# -gt 0 ]]; do shift; done
+      shift
+      "$@" >/dev/null 2>&1
+    else
+      echo "gum $*"
+    fi
+  }
+  _aw_run_git_hooks() { echo "running hooks"; }
+  _aw_install_dependencies_enabled() { return 1; }
+  _aw_install_dependencies() { echo "skipping"; }
+  _aw_launch_worktree() { echo "launched"; }
+
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+
+  _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees-events"
+  cd "$TEST_REPO_DIR"
+
+  _AW_QUIET=true
+  _AW_EVENTS=true
+  run _aw_create_worktree "work/103-events"
+  [ "$status" -eq 0 ]
+  [ "${#lines[@]}" -eq 10 ]
+  [ "${lines[0]}" = '{"phase":"detecting-repo","status":"started"}' ]
+  [ "${lines[3]}" = '{"phase":"creating-branch","status":"done"}' ]
+  [ "${lines[5]}" = '{"phase":"creating-worktree","status":"done"}' ]
+  [ "${lines[8]}" = '{"phase":"installing-deps","status":"skipped"}' ]
+  [ "${lines[9]}" = "{\"phase\":\"done\",\"status\":\"done\",\"path\":\"${_AW_WORKTREE_BASE}/work-103-events\"}" ]
+  assert_worktree_exists "${_AW_WORKTREE_BASE}/work-103-events"
+
+  run _aw_create_worktree "work/103-events"
+  [ "$status" -eq "$AW_EXIT_EXISTS" ]
+  [[ "${lines[1]}" == '{"phase":"detecting-repo","status":"failed","error":"Branch '"'"'work/103-events'"'"' already has a worktree at '* ]]
+
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees-events"
+}
+
+# ============================================================================
+# Hook overrides — new/issue --hooks and --no-hooks
+# ============================================================================
+
+@test "_aw_run_git_hooks: --hooks override runs hooks even when run-hooks is false" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/hooks.sh"
+  cd "$TEST_REPO_DIR"
+
+  git config auto-worktree.run-hooks false
+  mkdir -p "$TEST_REPO_DIR/.git/hooks"
+  printf '#!/bin/sh\necho "hook-ran"\n' > "$TEST_REPO_DIR/.git/hooks/post-worktree"
+  chmod +x "$TEST_REPO_DIR/.git/hooks/post-worktree"
+
+  _AW_HOOKS_OVERRIDE=true
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"hook-ran"* ]]
+
+  teardown_git_repo
+}
+
+@test "_aw_run_git_hooks: --no-hooks override skips hooks even when run-hooks is true" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/hooks.sh"
+  cd "$TEST_REPO_DIR"
+
+  git config auto-worktree.run-hooks true
+  mkdir -p "$TEST_REPO_DIR/.git/hooks"
+  printf '#!/bin/sh\necho "hook-ran"\n' > "$TEST_REPO_DIR/.git/hooks/post-worktree"
+  chmod +x "$TEST_REPO_DIR/.git/hooks/post-worktree"
+
+  _AW_HOOKS_OVERRIDE=false
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [[ "$output" != *"hook-ran"* ]]
+
+  teardown_git_repo
+}
+
+@test "_aw_new: --no-hooks and --hooks apply to that invocation only" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  source "${REPO_ROOT}/src/commands/new.sh"
+  cd "$TEST_REPO_DIR"
+
+  gum() { [[ "$1" == "input" ]] && echo "work/hooks-flag"; return 0; }
+  _aw_prune_worktrees() { :; }
+  _aw_list() { :; }
+  _aw_create_worktree() { echo "override=${_AW_HOOKS_OVERRIDE:-unset}"; }
+
+  run _aw_new true --no-hooks
+  [[ "$output" == *"override=false" ]]
+
+  run _aw_new --hooks
+  [[ "$output" == *"override=true" ]]
+
+  run _aw_new true
+  [[ "$output" == *"override=unset" ]]
+
+  run _aw_new --bogus
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  teardown_git_repo
+}
+
+# ============================================================================
+# Worktree naming — auto-worktree.worktree-naming
+# ============================================================================
+
+@test "_aw_worktree_dir_name: defaults to the sanitized branch" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  cd "$TEST_REPO_DIR"
+
+  run _aw_worktree_dir_name "work/123-Fix"
+  [ "$output" = "work-123-fix" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_worktree_dir_name: nested branches get one flat directory that still resolves" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  cd "$TEST_REPO_DIR"
+
+  local dir_name
+  dir_name=$(_aw_worktree_dir_name "feature/a/b/c")
+  [ "$dir_name" = "feature-a-b-c" ]
+
+  git worktree add -q -b "feature/a/b/c" "${TEST_REPO_DIR}-wt/$dir_name"
+  [ "$(_aw_get_worktree_for_branch "feature/a/b/c")" = "${TEST_REPO_DIR}-wt/feature-a-b-c" ]
+
+  rm -rf "${TEST_REPO_DIR}-wt"
+  teardown_git_repo
+}
+
+@test "_aw_worktree_dir_name: expands {repo}, {branch} and {branch-basename}" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  cd "$TEST_REPO_DIR"
+  _AW_SOURCE_FOLDER="my-repo"
+
+  git config auto-worktree.worktree-naming "{repo}-{branch}"
+  run _aw_worktree_dir_name "work/123-fix"
+  [ "$output" = "my-repo-work-123-fix" ]
+
+  git config auto-worktree.worktree-naming "{branch-basename}"
+  run _aw_worktree_dir_name "work/123-fix"
+  [ "$output" = "123-fix" ]
+
+  # Path separators and other unsafe characters in the template are sanitized
+  git config auto-worktree.worktree-naming "../{repo}/{branch-basename}"
+  run _aw_worktree_dir_name "work/123-fix"
+  [ "$output" = "my-repo-123-fix" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_tmux_window_name: defaults to the branch basename and expands {issue}" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  cd "$TEST_REPO_DIR"
+  git branch "work/42-fix-login"
+
+  run _aw_tmux_window_name "work/42-fix-login"
+  [ "$output" = "42-fix-login" ]
+
+  git config auto-worktree.tmux-window-name "{issue} {branch-basename}"
+  # No recorded issue: the stray separator is dropped
+  run _aw_tmux_window_name "work/42-fix-login"
+  [ "$output" = "42-fix-login" ]
+
+  _aw_record_issue_metadata "work/42-fix-login" "linear" "ENG-42" "Fix login"
+  run _aw_tmux_window_name "work/42-fix-login"
+  [ "$output" = "ENG-42 42-fix-login" ]
+
+  git config auto-worktree.tmux-window-name "{issue}"
+  _aw_record_issue_metadata "work/42-fix-login" "github" "42" "Fix login"
+  run _aw_tmux_window_name "work/42-fix-login"
+  [ "$output" = "#42" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_post_create_message: defaults to a cd hint and expands {path} and {branch}" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  cd "$TEST_REPO_DIR"
+
+  run _aw_post_create_message "/tmp/wt/42-fix" "work/42-fix"
+  [ "$output" = "To start working: cd /tmp/wt/42-fix" ]
+
+  git config auto-worktree.post-create-message "Open {branch} with: tmux new -c {path}"
+  run _aw_post_create_message "/tmp/wt/42-fix" "work/42-fix"
+  [ "$output" = "Open work/42-fix with: tmux new -c /tmp/wt/42-fix" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_name_tmux_window: renames the current window only inside tmux" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  cd "$TEST_REPO_DIR"
+  tmux() { echo "$*" >> "$BATS_TEST_TMPDIR/tmux.calls"; }
+
+  TMUX="" _aw_name_tmux_window "work/7-docs"
+  [ ! -f "$BATS_TEST_TMPDIR/tmux.calls" ]
+
+  TMUX="/tmp/tmux-1000/default,1,0" TMUX_PANE="%3" _aw_name_tmux_window "work/7-docs"
+  [ "$(cat "$BATS_TEST_TMPDIR/tmux.calls")" = "rename-window -t %3 7-docs" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_create_worktree: refuses a worktree path that is already taken" {
+  setup_git_repo
+  _aw_run_git_hooks() { :; }
+  _aw_install_dependencies_enabled() { return 0; }
+  _aw_install_dependencies() { :; }
+
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+
+  _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"
+  mkdir -p "$_AW_WORKTREE_BASE"
+  cd "$TEST_REPO_DIR"
+
+  git config auto-worktree.worktree-naming "{branch-basename}"
+  git worktree add -q -b "work/123-fix" "$_AW_WORKTREE_BASE/123-fix"
+
+  run _aw_create_worktree "feature/123-fix"
+  [ "$status" -eq "$AW_EXIT_EXISTS" ]
+  assert_branch_not_exists "feature/123-fix"
+
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+}
+
+# ============================================================================
+# Shallow creation — new --depth N
+# ============================================================================
+
+@test "_aw_new: --depth requires a positive number" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  source "${REPO_ROOT}/src/commands/new.sh"
+  cd "$TEST_REPO_DIR"
+
+  gum() { [[ "$1" == "input" ]] && echo "work/shallow"; return 0; }
+  _aw_prune_worktrees() { :; }
+  _aw_create_worktree() { echo "depth=${_AW_WORKTREE_DEPTH:-unset}"; }
+
+  run _aw_new true --depth 3
+  [[ "$output" == *"depth=3" ]]
+
+  run _aw_new true --depth=1
+  [[ "$output" == *"depth=1" ]]
+
+  run _aw_new true --depth 0
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  run _aw_new true --depth
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_fetch_shallow_base: ignores --depth in a full clone" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  cd "$TEST_REPO_DIR"
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  run _aw_fetch_shallow_base "$(git rev-parse --abbrev-ref HEAD)" 1
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"ignoring --depth"* ]]
+  [ ! -f .git/shallow ]
+
+  teardown_git_repo
+}
+
+@test "_aw_fetch_shallow_base: falls back with a warning on git without shallow support" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  cd "$TEST_REPO_DIR"
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  # git before 2.15 echoes unknown rev-parse flags back
+  git() {
+    if [[ "$1" == "rev-parse" && "$2" == "--is-shallow-repository" ]]; then
+      echo "$2"
+      return 0
+    fi
+    command git "$@"
+  }
+
+  run _aw_fetch_shallow_base main 1
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Warning:"*"creating a normal one"* ]]
+
+  teardown_git_repo
+}
+
+@test "_aw_add_worktree: --depth branches from origin's tip in a shallow clone" {
+  setup_git_repo
+  _aw_run_git_hooks() { :; }
+  _aw_install_dependencies_enabled() { return 0; }
+  _aw_install_dependencies() { :; }
+  gum() {
+    if [[ "$1" == "spin" ]]; then
+      while [[ "$1" != "--" ]]; do shift; done
+      shift
+      "$@"
+    fi
+  }
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+
+  local default_branch=$(git -C "$TEST_REPO_DIR" rev-parse --abbrev-ref HEAD)
+  for n in 1 2 3; do
+    git -C "$TEST_REPO_DIR" commit -q --allow-empty -m "commit $n"
+  done
+
+  local clone_dir="${TEST_REPO_DIR}-clone"
+  git clone -q --depth 1 "file://$TEST_REPO_DIR" "$clone_dir"
+  git -C "$TEST_REPO_DIR" commit -q --allow-empty -m "upstream tip"
+  local upstream_tip=$(git -C "$TEST_REPO_DIR" rev-parse HEAD)
+
+  cd "$clone_dir"
+  _AW_GIT_ROOT="$clone_dir"
+  _AW_WORKTREE_BASE="${clone_dir}-worktrees"
+  _AW_WORKTREE_DEPTH=1 _aw_add_worktree "work/ci-tip"
+
+  [ "$(git rev-parse work/ci-tip)" = "$upstream_tip" ]
+  [ "$(git rev-list --count work/ci-tip)" -eq 1 ]
+  [ "$(git rev-parse --is-shallow-repository)" = "true" ]
+
+  cd /
+  rm -rf "$clone_dir" "${clone_dir}-worktrees"
+  teardown_git_repo
+}
+
+# ============================================================================
+# Dry run — new --dry-run
+# ============================================================================
+
+@test "_aw_new: --dry-run prints the path and branch without creating anything" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  source "${REPO_ROOT}/src/commands/new.sh"
+  cd "$TEST_REPO_DIR"
+
+  gum() {
+    case "$1" in
+      input) echo "feature/dry" ;;
+      style) echo "${@: -1}" ;;
+    esac
+    return 0
+  }
+  _aw_list() { :; }
+  _aw_get_repo_info() { _AW_GIT_ROOT="$TEST_REPO_DIR"; _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"; }
+  _aw_launch_worktree() { echo "should not launch"; }
+  _aw_run_git_hooks() { echo "should not run hooks"; }
+
+  run _aw_new true --dry-run
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Nothing was created"* ]]
+  [[ "$output" != *"should not"* ]]
+  assert_branch_not_exists "feature/dry"
+  [ ! -e "${TEST_REPO_DIR}-worktrees" ]
+
+  # The plan itself (gum style only echoes its last line above)
+  _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"
+  gum() { [[ "$1" == "style" ]] && printf '%s\n' "${@:2}"; return 0; }
+  run _aw_print_worktree_plan "$_AW_WORKTREE_BASE/feature-dry" "feature/dry" false "$(git rev-parse --abbrev-ref HEAD)"
+  [[ "$output" == *"Path:   $_AW_WORKTREE_BASE/feature-dry"* ]]
+  [[ "$output" == *"Branch: feature/dry (new, from "* ]]
+
+  teardown_git_repo
+}
+
+@test "_aw_add_worktree: --dry-run fails like a real run would" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  cd "$TEST_REPO_DIR"
+  _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"
+  mkdir -p "$_AW_WORKTREE_BASE"
+  git worktree add -q -b "feature/taken" "$_AW_WORKTREE_BASE/feature-taken"
+  mkdir -p "$_AW_WORKTREE_BASE/feature-stray"
+
+  _AW_DRY_RUN=true run _aw_add_worktree "feature/taken"
+  [ "$status" -eq "$AW_EXIT_EXISTS" ]
+
+  _AW_DRY_RUN=true run _aw_add_worktree "feature/stray"
+  [ "$status" -eq "$AW_EXIT_EXISTS" ]
+
+  _AW_DRY_RUN=true run _aw_add_worktree "bad..name"
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  # Detached HEAD with an unresolvable default branch
+  git checkout -q --detach
+  _aw_get_default_branch() { echo "does-not-exist"; }
+  _AW_DRY_RUN=true run _aw_add_worktree "feature/no-base"
+  [ "$status" -eq 1 ]
+  assert_branch_not_exists "feature/no-base"
+
+  # An existing branch without a worktree only needs a free path
+  git branch "feature/existing"
+  _AW_DRY_RUN=true _AW_QUIET=true run _aw_add_worktree "feature/existing"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"$_AW_WORKTREE_BASE/feature-existing" ]]
+  [ ! -e "$_AW_WORKTREE_BASE/feature-existing" ]
+
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+}
+
+# ============================================================================
+# Branches that only exist on a remote
+# ============================================================================
+
+@test "_aw_add_worktree: checks out a remote-only branch as a tracking branch" {
+  setup_git_repo
+  _aw_run_git_hooks() { :; }
+  _aw_install_dependencies_enabled() { return 0; }
+  _aw_install_dependencies() { :; }
+  gum() {
+    if [[ "$1" == "spin" ]]; then
+      while [[ "$1" != "--" ]]; do shift; done
+      shift
+      "$@"
+    fi
+  }
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+
+  git -C "$TEST_REPO_DIR" branch "feature/fetched"
+  local clone_dir="${TEST_REPO_DIR}-clone"
+  git clone -q "file://$TEST_REPO_DIR" "$clone_dir"
+  # Pushed after the clone: only ls-remote knows about it
+  git -C "$TEST_REPO_DIR" checkout -q -b "feature/unfetched"
+  git -C "$TEST_REPO_DIR" commit -q --allow-empty -m "remote work"
+  local remote_tip=$(git -C "$TEST_REPO_DIR" rev-parse HEAD)
+
+  cd "$clone_dir"
+  _AW_GIT_ROOT="$clone_dir"
+  _AW_WORKTREE_BASE="${clone_dir}-worktrees"
+
+  _aw_add_worktree "feature/fetched"
+  assert_worktree_exists "$_AW_WORKTREE_BASE/feature-fetched"
+  [ "$(git rev-parse --abbrev-ref feature/fetched@{upstream})" = "origin/feature/fetched" ]
+
+  _AW_EXPLICIT_BRANCH=true _aw_add_worktree "feature/unfetched"
+  [ "$(git rev-parse feature/unfetched)" = "$remote_tip" ]
+  [ "$(git rev-parse --abbrev-ref feature/unfetched@{upstream})" = "origin/feature/unfetched" ]
+
+  cd /
+  rm -rf "$clone_dir" "${clone_dir}-worktrees"
+  teardown_git_repo
+}
+
+@test "_aw_add_worktree: --dry-run reports a remote-only branch without fetching it" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  gum() { [[ "$1" == "style" ]] && printf '%s\n' "${@:2}"; return 0; }
+
+  local clone_dir="${TEST_REPO_DIR}-clone"
+  git clone -q "file://$TEST_REPO_DIR" "$clone_dir"
+  git -C "$TEST_REPO_DIR" branch "feature/unfetched"
+
+  cd "$clone_dir"
+  _AW_GIT_ROOT="$clone_dir"
+  _AW_WORKTREE_BASE="${clone_dir}-worktrees"
+
+  _AW_EXPLICIT_BRANCH=true _AW_DRY_RUN=true run _aw_add_worktree "feature/unfetched"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Branch: feature/unfetched (tracking origin/feature/unfetched)"* ]]
+  assert_branch_not_exists "feature/unfetched"
+  run git show-ref --verify --quiet "refs/remotes/origin/feature/unfetched"
+  [ "$status" -ne 0 ]
+
+  cd /
+  rm -rf "$clone_dir"
+  teardown_git_repo
+}
+
+_setup_stale_clone() {
+  # A clone whose base branch is one commit behind origin. Sets clone_dir,
+  # base and remote_tip.
+  setup_git_repo
+  _aw_run_git_hooks() { :; }
+  _aw_install_dependencies_enabled() { return 0; }
+  _aw_install_dependencies() { :; }
+  gum() {
+    if [[ "$1" == "spin" ]]; then
+      while [[ "$1" != "--" ]]; do shift; done
+      shift
+      "$@"
+    elif [[ "$1" == "style" ]]; then
+      echo "${@: -1}"
+    fi
+  }
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+
+  clone_dir="${TEST_REPO_DIR}-clone"
+  git clone -q "file://$TEST_REPO_DIR" "$clone_dir"
+  git -C "$clone_dir" config user.email "test@example.com"
+  git -C "$clone_dir" config user.name "Test User"
+  base=$(git -C "$TEST_REPO_DIR" symbolic-ref --short HEAD)
+  git -C "$TEST_REPO_DIR" commit -q --allow-empty -m "newer work on origin"
+  remote_tip=$(git -C "$TEST_REPO_DIR" rev-parse HEAD)
+
+  cd "$clone_dir"
+  _AW_GIT_ROOT="$clone_dir"
+  _AW_WORKTREE_BASE="${clone_dir}-worktrees"
+}
+
+@test "_aw_add_worktree: fetch-before-create branches from origin's latest base" {
+  _setup_stale_clone
+  local local_tip=$(git rev-parse HEAD)
+
+  # Off by default
+  _aw_add_worktree "feature/stale" >/dev/null
+  [ "$(git rev-parse feature/stale)" = "$local_tip" ]
+
+  git config auto-worktree.fetch-before-create true
+  _aw_add_worktree "feature/fresh" >/dev/null
+  [ "$(git rev-parse feature/fresh)" = "$remote_tip" ]
+  # The new branch doesn't track the base
+  run git rev-parse --abbrev-ref "feature/fresh@{upstream}"
+  [ "$status" -ne 0 ]
+
+  # --no-fetch overrides the config for one invocation
+  _AW_FETCH_OVERRIDE=false _aw_add_worktree "feature/no-fetch" >/dev/null
+  [ "$(git rev-parse feature/no-fetch)" = "$local_tip" ]
+
+  cd /
+  rm -rf "$clone_dir" "${clone_dir}-worktrees"
+  teardown_git_repo
+}
+
+@test "_aw_add_worktree: --fetch keeps local base commits and only warns when offline" {
+  _setup_stale_clone
+
+  # Local commits not on origin win
+  git commit -q --allow-empty -m "local only"
+  local local_tip=$(git rev-parse HEAD)
+  _AW_FETCH_OVERRIDE=true _aw_add_worktree "feature/diverged" >/dev/null
+  [ "$(git rev-parse feature/diverged)" = "$local_tip" ]
+
+  git remote set-url origin "file://${TEST_REPO_DIR}-missing"
+  _AW_FETCH_OVERRIDE=true run _aw_add_worktree "feature/offline"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Could not fetch origin/$base (offline?)"* ]]
+  [ "$(git rev-parse feature/offline)" = "$local_tip" ]
+
+  cd /
+  rm -rf "$clone_dir" "${clone_dir}-worktrees"
+  teardown_git_repo
+}
+
+@test "_aw_add_worktree: only asks the remote for typed names or with fetching on" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  gum() { [[ "$1" == "style" ]] && printf '%s\n' "${@:2}"; return 0; }
+
+  local clone_dir="${TEST_REPO_DIR}-clone"
+  git clone -q "file://$TEST_REPO_DIR" "$clone_dir"
+  git -C "$TEST_REPO_DIR" branch "feature/unfetched"
+
+  cd "$clone_dir"
+  _AW_GIT_ROOT="$clone_dir"
+  _AW_WORKTREE_BASE="${clone_dir}-worktrees"
+
+  # Generated and issue-derived names stay local
+  _AW_DRY_RUN=true run _aw_add_worktree "feature/unfetched"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Branch: feature/unfetched (new, from "* ]]
+
+  # --no-fetch wins over a typed name
+  _AW_EXPLICIT_BRANCH=true _AW_FETCH_OVERRIDE=false _AW_DRY_RUN=true run _aw_add_worktree "feature/unfetched"
+  [[ "$output" == *"Branch: feature/unfetched (new, from "* ]]
+
+  # fetch-before-create asks the remote for any name
+  git config auto-worktree.fetch-before-create true
+  _AW_DRY_RUN=true run _aw_add_worktree "feature/unfetched"
+  [[ "$output" == *"Branch: feature/unfetched (tracking origin/feature/unfetched)"* ]]
+
+  cd /
+  rm -rf "$clone_dir" "${clone_dir}-worktrees"
+  teardown_git_repo
+}
+
+@test "_aw_find_remote_branch: returns 1 without remotes or a matching branch" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  cd "$TEST_REPO_DIR"
+
+  run _aw_find_remote_branch "feature/nowhere"
+  [ "$status" -eq 1 ]
+
+  git remote add origin "file://$TEST_REPO_DIR"
+  run _aw_find_remote_branch "feature/nowhere" --query-remote
+  [ "$status" -eq 1 ]
+  [ -z "$output" ]
+
+  # Without --query-remote only remote-tracking refs count
+  git branch "feature/on-remote"
+  run _aw_find_remote_branch "feature/on-remote"
+  [ "$status" -eq 1 ]
+  run _aw_find_remote_branch "feature/on-remote" --query-remote --no-fetch
+  [ "$output" = "origin/feature/on-remote" ]
+
+  teardown_git_repo
+}
+
+# ============================================================================
+# Detached worktrees — new --detach <commit>
+# ============================================================================
+
+@test "_aw_new --detach: creates a worktree at the commit without a branch" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  source "${REPO_ROOT}/src/commands/new.sh"
+  cd "$TEST_REPO_DIR"
+  git commit -q --allow-empty -m "second"
+  local first_sha=$(git rev-parse HEAD~1)
+  local short_sha=$(git rev-parse --short HEAD~1)
+  local branches_before=$(git branch --list | wc -l)
+
+  gum() {
+    case "$1" in
+      spin) shift; while [[ "$1" != "--" ]]; do shift; done; shift; "$@" ;;
+      style) echo "${@: -1}" ;;
+    esac
+  }
+  _aw_list() { :; }
+  _aw_get_repo_info() { _AW_GIT_ROOT="$TEST_REPO_DIR"; _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"; }
+  _aw_run_git_hooks() { :; }
+  _aw_install_dependencies_enabled() { return 1; }
+  _aw_install_dependencies() { :; }
+  _aw_launch_worktree() { echo "launch $1 $2"; }
+
+  run _aw_new true --detach HEAD~1
+  [ "$status" -eq 0 ]
+  local wt_path="${TEST_REPO_DIR}-worktrees/detached-$short_sha"
+  [[ "$output" == *"launch $wt_path detached-$short_sha"* ]]
+  [[ "$output" == *"To start working: cd $wt_path"* ]]
+  assert_worktree_exists "$wt_path"
+  [ "$(git -C "$wt_path" rev-parse HEAD)" = "$first_sha" ]
+  [ "$(git -C "$wt_path" rev-parse --abbrev-ref HEAD)" = "HEAD" ]
+  [ "$(git branch --list | wc -l)" -eq "$branches_before" ]
+
+  git worktree remove --force "$wt_path"
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+  teardown_git_repo
+}
+
+@test "_aw_new --detach: rejects something that isn't a commit" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  source "${REPO_ROOT}/src/commands/new.sh"
+  cd "$TEST_REPO_DIR"
+  gum() { [[ "$1" == "style" ]] && echo "${@: -1}"; return 0; }
+  _aw_list() { :; }
+  _aw_get_repo_info() { _AW_GIT_ROOT="$TEST_REPO_DIR"; _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"; }
+
+  run _aw_new true --detach deadbeefdeadbeef
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  [[ "$output" == *"'deadbeefdeadbeef' doesn't resolve to a commit"* ]]
+  [ ! -e "${TEST_REPO_DIR}-worktrees" ]
+
+  run _aw_new true --detach
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  run _aw_new true --detach HEAD --depth 1
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  teardown_git_repo
+}
+
+# ============================================================================
+# Batch creation — new --batch
+# ============================================================================
+
+_setup_batch() {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  source "${REPO_ROOT}/src/commands/new.sh"
+  cd "$TEST_REPO_DIR"
+
+  gum() {
+    case "$1" in
+      spin) shift; while [[ "$1" != "--" ]]; do shift; done; shift; "$@" ;;
+      style) echo "${@: -1}" ;;
+    esac
+  }
+  _aw_list() { :; }
+  _aw_get_repo_info() { _AW_GIT_ROOT="$TEST_REPO_DIR"; _AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"; }
+  _aw_stdin_is_tty() { return 1; }
+  _aw_run_git_hooks() { :; }
+  _aw_install_dependencies_enabled() { return 1; }
+  _aw_install_dependencies() { :; }
+  _aw_launch_worktree() { echo "should not launch"; }
+}
+
+@test "_aw_new --batch: creates a worktree per line and summarizes" {
+  _setup_batch
+  git worktree add -q -b "feature/taken" "${TEST_REPO_DIR}-worktrees/feature-taken"
+
+  run _aw_new --batch <<< $'feature/one\n\n  # onboarding list\nfeature/taken\nbad..name\n  feature/two  # trailing comment'
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"✓ feature/one → ${TEST_REPO_DIR}-worktrees/feature-one"* ]]
+  [[ "$output" == *"✗ feature/taken: already exists"* ]]
+  [[ "$output" == *"✗ bad..name: invalid branch name"* ]]
+  [[ "$output" == *"✓ feature/two → ${TEST_REPO_DIR}-worktrees/feature-two"* ]]
+  [[ "$output" == *"Created 2 of 4 worktree(s)"* ]]
+  [[ "$output" == *"2 failed:"* ]]
+  [[ "$output" != *"should not launch"* ]]
+  assert_worktree_exists "${TEST_REPO_DIR}-worktrees/feature-one"
+  assert_worktree_exists "${TEST_REPO_DIR}-worktrees/feature-two"
+
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+  teardown_git_repo
+}
+
+@test "_aw_new --batch: succeeds when every line is created" {
+  _setup_batch
+
+  run _aw_new --batch <<< $'feature/a\nfeature/b'
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Created 2 of 2 worktree(s)"* ]]
+  [[ "$output" != *"failed"* ]]
+
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+  teardown_git_repo
+}
+
+@test "_aw_new --batch: with --dry-run nothing is created" {
+  _setup_batch
+
+  run _aw_new --batch --dry-run <<< $'feature/a\nfeature/b'
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Dry run: 2 of 2 worktree(s) would be created"* ]]
+  assert_branch_not_exists "feature/a"
+  [ ! -e "${TEST_REPO_DIR}-worktrees" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_new --batch: needs branch names on stdin and no --detach" {
+  _setup_batch
+
+  _aw_stdin_is_tty() { return 0; }
+  run _aw_new --batch
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  [[ "$output" == *"--batch reads branch names from stdin"* ]]
+
+  _aw_stdin_is_tty() { return 1; }
+  run _aw_new --batch --detach HEAD < /dev/null
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  run _aw_new --batch < /dev/null
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"No branch names on stdin"* ]]
+
+  teardown_git_repo
+}
+
+# ============================================================================
+# CI output — new --write-branch
+# ============================================================================
+
+@test "_aw_new --write-branch: writes the created branch name to the file" {
+  _setup_batch
+  gum() {
+    case "$1" in
+      input) echo "feature/ci" ;;
+      spin) shift; while [[ "$1" != "--" ]]; do shift; done; shift; "$@" ;;
+      style) echo "${@: -1}" ;;
+    esac
+  }
+  _aw_launch_worktree() { :; }
+  local out_file="${TEST_REPO_DIR}-branch.txt"
+  echo "stale" > "$out_file"
+
+  GITHUB_ACTIONS="" run _aw_new true --write-branch "$out_file"
+  [ "$status" -eq 0 ]
+  [ "$(cat "$out_file")" = "feature/ci" ]
+
+  rm -rf "${TEST_REPO_DIR}-worktrees" "$out_file"
+  teardown_git_repo
+}
+
+@test "_aw_write_branch_file: appends branch=<name> under GitHub Actions" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  local out_file="${TEST_REPO_DIR}-output"
+  echo "previous=1" > "$out_file"
+
+  GITHUB_ACTIONS=true _AW_WRITE_BRANCH_FILE="$out_file" _aw_write_branch_file "feature/ci"
+  [ "$(cat "$out_file")" = $'previous=1\nbranch=feature/ci' ]
+
+  # Nothing is written without --write-branch
+  _AW_WRITE_BRANCH_FILE="" _aw_write_branch_file "feature/other"
+  [ "$(tail -n 1 "$out_file")" = "branch=feature/ci" ]
+
+  rm -f "$out_file"
+  teardown_git_repo
+}
+
+@test "_aw_write_branch_file: fails when the file can't be written" {
+  setup_git_repo
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  gum() { [[ "$1" == "style" ]] && echo "${@: -1}"; return 0; }
+
+  _AW_WRITE_BRANCH_FILE="${TEST_REPO_DIR}/no/such/dir/branch" run _aw_write_branch_file "feature/ci"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Could not write the branch name"* ]]
+
+  teardown_git_repo
+}
+
+@test "_aw_new --write-branch: needs a file and a single new branch" {
+  _setup_batch
+
+  run _aw_new true --write-branch
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  run _aw_new --batch --write-branch out.txt < /dev/null
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  run _aw_new --detach HEAD --write-branch=out.txt
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  [ ! -e out.txt ]
+
+  teardown_git_repo
+}
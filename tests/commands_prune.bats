@@ -0,0 +1,181 @@
+#!/usr/bin/env bats
+# Tests for src/commands/prune.sh
+#
+# Covers:
+#   - _aw_prune (orphaned references, --all merged worktrees, dirty and
+#     unmerged worktrees skipped, fresh worktrees kept, confirmation, reasons,
+#     auto-worktree.prune-no-confirm, locked worktrees skipped, --quiet,
+#     errors on stderr)
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+load 'helpers/git_assertions'
+
+setup() {
+  PRUNE_CONFIRM=0
+  gum() {
+    case "$1" in
+      style) echo "${@: -1}" ;;
+      confirm) return "$PRUNE_CONFIRM" ;;
+    esac
+  }
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
+  # shellcheck source=../src/providers/common.sh
+  source "${REPO_ROOT}/src/providers/common.sh"
+  # shellcheck source=../src/lib/worktree.sh
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  # shellcheck source=../src/commands/prune.sh
+  source "${REPO_ROOT}/src/commands/prune.sh"
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git config auto-worktree.default-branch "$(git symbolic-ref --short HEAD)"
+
+  WT_BASE="${TEST_REPO_DIR}-worktrees"
+  mkdir -p "$WT_BASE"
+}
+
+teardown() {
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+}
+
+# Add a worktree for feature/<name> with a commit that is then merged into
+# the default branch
+add_merged_worktree() {
+  local name="$1"
+  git worktree add -q -b "feature/$name" "$WT_BASE/feature-$name"
+  git -C "$WT_BASE/feature-$name" commit -q --allow-empty -m "$name work"
+  git merge -q --ff-only "feature/$name"
+}
+
+@test "_aw_prune: unknown option is a usage error" {
+  run _aw_prune --bogus
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_prune: removes references to deleted worktree directories" {
+  git worktree add -q -b "feature/gone" "$WT_BASE/feature-gone"
+  rm -rf "$WT_BASE/feature-gone"
+
+  run _aw_prune
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Pruned 1 orphaned worktree reference(s)"* ]]
+  [ -z "$(git worktree list --porcelain | grep "feature-gone")" ]
+  # Without --all, existing worktrees are left alone
+  assert_branch_exists "feature/gone"
+}
+
+@test "_aw_prune: without --all leaves merged worktrees in place" {
+  git worktree add -q -b "feature/merged" "$WT_BASE/feature-merged"
+
+  run _aw_prune
+  [ "$status" -eq 0 ]
+  assert_worktree_exists "$WT_BASE/feature-merged"
+}
+
+@test "_aw_prune --all: removes merged worktrees and explains why" {
+  local default_branch=$(git symbolic-ref --short HEAD)
+
+  git worktree add -q -b "feature/merged" "$WT_BASE/feature-merged"
+  git -C "$WT_BASE/feature-merged" commit -q --allow-empty -m "merged work"
+  git merge -q --ff-only "feature/merged"
+  git commit -q --allow-empty -m "later work on the default branch"
+
+  git worktree add -q -b "feature/untouched" "$WT_BASE/feature-untouched"
+
+  git worktree add -q -b "feature/unmerged" "$WT_BASE/feature-unmerged"
+  git -C "$WT_BASE/feature-unmerged" commit -q --allow-empty -m "unmerged work"
+
+  run _aw_prune --all
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"feature-merged (feature/merged): fully merged into $default_branch"* ]]
+  [[ "$output" == *"Removed 1 merged worktree(s)"* ]]
+
+  assert_no_worktree "$WT_BASE/feature-merged"
+  assert_branch_not_exists "feature/merged"
+  assert_worktree_exists "$WT_BASE/feature-untouched"
+  assert_worktree_exists "$WT_BASE/feature-unmerged"
+  assert_branch_exists "feature/unmerged"
+}
+
+@test "_aw_prune --all: keeps a fresh worktree with no commits of its own" {
+  git config auto-worktree.prune-no-confirm true
+  git worktree add -q -b "feature/fresh" "$WT_BASE/feature-fresh"
+  git commit -q --allow-empty -m "later work on the default branch"
+
+  run _aw_prune --all
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"No merged worktrees to remove"* ]]
+  assert_worktree_exists "$WT_BASE/feature-fresh"
+  assert_branch_exists "feature/fresh"
+}
+
+@test "_aw_prune --all: skips dirty worktrees" {
+  add_merged_worktree "dirty"
+  echo "wip" > "$WT_BASE/feature-dirty/wip.txt"
+
+  run _aw_prune --all
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Skipping feature-dirty (feature/dirty): uncommitted changes"* ]]
+  [[ "$output" == *"No merged worktrees to remove"* ]]
+  assert_worktree_exists "$WT_BASE/feature-dirty"
+}
+
+@test "_aw_prune --all: skips locked worktrees" {
+  add_merged_worktree "locked"
+  git worktree lock "$WT_BASE/feature-locked"
+
+  run _aw_prune --all
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Skipping feature-locked (feature/locked): locked"* ]]
+  [[ "$output" == *"No merged worktrees to remove"* ]]
+  assert_worktree_exists "$WT_BASE/feature-locked"
+  git worktree unlock "$WT_BASE/feature-locked"
+}
+
+@test "_aw_prune --all: nothing is removed when the confirmation is declined" {
+  add_merged_worktree "merged"
+  PRUNE_CONFIRM=1
+
+  run _aw_prune --all
+  [ "$status" -eq "$AW_EXIT_CANCELLED" ]
+  assert_worktree_exists "$WT_BASE/feature-merged"
+  assert_branch_exists "feature/merged"
+}
+
+@test "_aw_prune --all: auto-worktree.prune-no-confirm skips the confirmation" {
+  add_merged_worktree "merged"
+  git config auto-worktree.prune-no-confirm true
+  PRUNE_CONFIRM=1
+
+  run _aw_prune --all
+  [ "$status" -eq 0 ]
+  assert_no_worktree "$WT_BASE/feature-merged"
+  assert_branch_not_exists "feature/merged"
+}
+
+@test "_aw_prune --all --quiet: prints nothing on stdout" {
+  add_merged_worktree "merged"
+  git worktree add -q -b "feature/gone" "$WT_BASE/feature-gone"
+  rm -rf "$WT_BASE/feature-gone"
+  git config auto-worktree.prune-no-confirm true
+
+  local out
+  out=$(_AW_QUIET=true _aw_prune --all)
+  [ -z "$out" ]
+  assert_no_worktree "$WT_BASE/feature-merged"
+}
+
+@test "_aw_prune: errors go to stderr" {
+  local out
+  out=$(_aw_prune --bogus 2>/dev/null) || true
+  [ -z "$out" ]
+  run _aw_prune --bogus
+  [[ "$output" == *"Unknown option: --bogus"* ]]
+}
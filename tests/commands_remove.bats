@@ -0,0 +1,230 @@
+#!/usr/bin/env bats
+# Tests for src/commands/remove.sh
+#
+# Covers:
+#   - _aw_get_worktree_for_branch (branch → worktree path lookup)
+#   - _aw_resolve_worktree_target (branch first, path fallback, no match)
+#   - _aw_remove (removes by branch or path, keeps branch, guards main worktree,
+#     runs from inside the worktree being removed,
+#     --keep-branch/--delete-branch/--force/-D, temporary fork PR branches)
+#   - _aw_remove --interactive (pick from a list, confirm, cancel)
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+load 'helpers/git_assertions'
+
+setup() {
+  gum() { return 0; }
+  export -f gum
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/worktree.sh
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  # shellcheck source=../src/lib/metadata.sh
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  # shellcheck source=../src/commands/remove.sh
+  source "${REPO_ROOT}/src/commands/remove.sh"
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+
+  WT_BASE="${TEST_REPO_DIR}-worktrees"
+  mkdir -p "$WT_BASE"
+  git worktree add -q -b "feature/remove-me" "$WT_BASE/feature-remove-me"
+}
+
+teardown() {
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+}
+
+@test "_aw_get_worktree_for_branch: returns the worktree path for a checked-out branch" {
+  run _aw_get_worktree_for_branch "feature/remove-me"
+  [ "$status" -eq 0 ]
+  [ "$output" = "$WT_BASE/feature-remove-me" ]
+}
+
+@test "_aw_get_worktree_for_branch: returns 1 for a branch without a worktree" {
+  git branch "no-worktree"
+  run _aw_get_worktree_for_branch "no-worktree"
+  [ "$status" -eq 1 ]
+  [ -z "$output" ]
+}
+
+@test "_aw_resolve_worktree_target: resolves a branch name" {
+  run _aw_resolve_worktree_target "feature/remove-me"
+  [ "$status" -eq 0 ]
+  [ "$output" = "$WT_BASE/feature-remove-me" ]
+}
+
+@test "_aw_resolve_worktree_target: falls back to a worktree path" {
+  run _aw_resolve_worktree_target "$WT_BASE/feature-remove-me"
+  [ "$status" -eq 0 ]
+  [ "$output" = "$WT_BASE/feature-remove-me" ]
+}
+
+@test "_aw_resolve_worktree_target: returns 1 for an unknown target" {
+  run _aw_resolve_worktree_target "does-not-exist"
+  [ "$status" -eq 1 ]
+}
+
+@test "_aw_remove: removes a worktree by branch name and keeps the branch" {
+  run _aw_remove "feature/remove-me"
+  [ "$status" -eq 0 ]
+  assert_no_worktree "$WT_BASE/feature-remove-me"
+  assert_branch_exists "feature/remove-me"
+}
+
+@test "_aw_remove: removes a worktree by path" {
+  run _aw_remove "$WT_BASE/feature-remove-me"
+  [ "$status" -eq 0 ]
+  assert_no_worktree "$WT_BASE/feature-remove-me"
+}
+
+@test "_aw_remove: refuses to remove the main worktree" {
+  run _aw_remove "$TEST_REPO_DIR"
+  [ "$status" -eq 1 ]
+  [ -d "$TEST_REPO_DIR/.git" ]
+}
+
+@test "_aw_remove: explains that the main checkout can't be removed, even from a worktree" {
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  local main_branch=$(git symbolic-ref --short HEAD)
+  cd "$WT_BASE/feature-remove-me"
+
+  run _aw_remove "$TEST_REPO_DIR"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Cannot remove the main worktree: $TEST_REPO_DIR"* ]]
+  [ -d "$TEST_REPO_DIR/.git" ]
+
+  run _aw_remove "$main_branch"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Cannot remove the main worktree"* ]]
+}
+
+@test "_aw_remove: removes the worktree it is run from and steps into the main checkout" {
+  cd "$WT_BASE/feature-remove-me"
+
+  _aw_remove "feature/remove-me"
+  assert_no_worktree "$WT_BASE/feature-remove-me"
+  assert_branch_exists "feature/remove-me"
+  [ "$(pwd -P)" = "$(cd "$TEST_REPO_DIR" && pwd -P)" ]
+}
+
+@test "_aw_remove: errors go to stderr" {
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  local out
+  out=$(_aw_remove "does-not-exist" 2>/dev/null) || true
+  [ -z "$out" ]
+
+  run _aw_remove "does-not-exist"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"No worktree found for branch or path: does-not-exist"* ]]
+}
+
+@test "_aw_remove: usage error without a target" {
+  run _aw_remove
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_remove: unknown option is a usage error" {
+  run _aw_remove --bogus "feature/remove-me"
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  assert_worktree_exists "$WT_BASE/feature-remove-me"
+}
+
+@test "_aw_remove: --keep-branch keeps the branch" {
+  run _aw_remove --keep-branch "feature/remove-me"
+  [ "$status" -eq 0 ]
+  assert_no_worktree "$WT_BASE/feature-remove-me"
+  assert_branch_exists "feature/remove-me"
+}
+
+@test "_aw_remove: --delete-branch deletes a merged branch" {
+  run _aw_remove --delete-branch "feature/remove-me"
+  [ "$status" -eq 0 ]
+  assert_no_worktree "$WT_BASE/feature-remove-me"
+  assert_branch_not_exists "feature/remove-me"
+}
+
+@test "_aw_remove: --delete-branch keeps an unmerged branch without --force" {
+  git -C "$WT_BASE/feature-remove-me" commit -q --allow-empty -m "unmerged work"
+
+  run _aw_remove --delete-branch "feature/remove-me"
+  [ "$status" -eq 1 ]
+  assert_no_worktree "$WT_BASE/feature-remove-me"
+  assert_branch_exists "feature/remove-me"
+}
+
+@test "_aw_remove: --delete-branch --force deletes an unmerged branch" {
+  git -C "$WT_BASE/feature-remove-me" commit -q --allow-empty -m "unmerged work"
+
+  run _aw_remove --delete-branch --force "feature/remove-me"
+  [ "$status" -eq 0 ]
+  assert_branch_not_exists "feature/remove-me"
+}
+
+@test "_aw_remove: -D deletes an unmerged branch" {
+  git -C "$WT_BASE/feature-remove-me" commit -q --allow-empty -m "unmerged work"
+
+  run _aw_remove -D "feature/remove-me"
+  [ "$status" -eq 0 ]
+  assert_no_worktree "$WT_BASE/feature-remove-me"
+  assert_branch_not_exists "feature/remove-me"
+}
+
+@test "_aw_remove: deletes the temporary pr-<n> branch of a fork PR" {
+  git worktree add -q -b "pr-12" "$WT_BASE/pr-12"
+  git config branch.pr-12.aw-fork-pr 12
+  run _aw_remove "pr-12"
+  [ "$status" -eq 0 ]
+  assert_no_worktree "$WT_BASE/pr-12"
+  assert_branch_not_exists "pr-12"
+}
+
+@test "_aw_remove --interactive: removes the picked worktree after confirming" {
+  git worktree add -q -b "feature/stays" "$WT_BASE/feature-stays"
+  gum() {
+    case "$1" in
+      filter) grep "feature/remove-me" ;;
+      confirm) echo "$2" > "$BATS_TEST_TMPDIR/prompt"; return 0 ;;
+    esac
+  }
+
+  run _aw_remove --interactive --delete-branch
+  [ "$status" -eq 0 ]
+  assert_no_worktree "$WT_BASE/feature-remove-me"
+  assert_branch_not_exists "feature/remove-me"
+  assert_worktree_exists "$WT_BASE/feature-stays"
+  [ "$(cat "$BATS_TEST_TMPDIR/prompt")" = "Remove worktree feature-remove-me and delete its branch?" ]
+}
+
+@test "_aw_remove --interactive: offers only linked worktrees" {
+  gum() { [[ "$1" == "filter" ]] && cat > "$BATS_TEST_TMPDIR/choices"; return 0; }
+
+  run _aw_remove -i
+  [ "$status" -eq "$AW_EXIT_CANCELLED" ]
+  [ "$(wc -l < "$BATS_TEST_TMPDIR/choices")" -eq 1 ]
+  [[ "$(cat "$BATS_TEST_TMPDIR/choices")" == "feature-remove-me (feature/remove-me) "* ]]
+}
+
+@test "_aw_remove --interactive: declining the confirmation keeps the worktree" {
+  gum() {
+    case "$1" in
+      filter) head -n 1 ;;
+      confirm) return 1 ;;
+    esac
+  }
+
+  run _aw_remove --interactive
+  [ "$status" -eq "$AW_EXIT_CANCELLED" ]
+  assert_worktree_exists "$WT_BASE/feature-remove-me"
+}
+
+@test "_aw_remove --interactive: can't be combined with a target" {
+  run _aw_remove --interactive "feature/remove-me"
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  assert_worktree_exists "$WT_BASE/feature-remove-me"
+}
@@ -0,0 +1,134 @@
+#!/usr/bin/env bats
+# Tests for src/commands/rename.sh
+#
+# Covers:
+#   - _aw_rename (usage error, unknown branch, main worktree, invalid and
+#     colliding names, branch rename + worktree move, metadata, cwd tracking,
+#     renaming the current worktree)
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+
+setup() {
+  gum() {
+    if [[ "$1" == "style" ]]; then
+      echo "${@: -1}"
+    fi
+  }
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
+  # shellcheck source=../src/lib/metadata.sh
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  # shellcheck source=../src/lib/worktree.sh
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  # shellcheck source=../src/commands/resume.sh
+  source "${REPO_ROOT}/src/commands/resume.sh"
+  # shellcheck source=../src/commands/rename.sh
+  source "${REPO_ROOT}/src/commands/rename.sh"
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+
+  export AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"
+  WT_BASE="${AW_WORKTREE_BASE}/$(basename "$TEST_REPO_DIR")"
+  mkdir -p "$WT_BASE"
+  git worktree add -q -b "feature/old-name" "$WT_BASE/feature-old-name"
+}
+
+teardown() {
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+  unset AW_WORKTREE_BASE
+}
+
+@test "_aw_rename: missing arguments are a usage error" {
+  run _aw_rename "feature/old-name"
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  [[ "$output" == *"Usage: auto-worktree rename"* ]]
+}
+
+@test "_aw_rename: unknown branch is an error" {
+  run _aw_rename "no-such-branch" "feature/new"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"No worktree found for branch: no-such-branch"* ]]
+}
+
+@test "_aw_rename: refuses to rename the main worktree's branch" {
+  local main_branch=$(git symbolic-ref --short HEAD)
+  run _aw_rename "$main_branch" "renamed-main"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"main worktree"* ]]
+  git show-ref --verify --quiet "refs/heads/$main_branch"
+}
+
+@test "_aw_rename: rejects an invalid branch name" {
+  run _aw_rename "feature/old-name" "bad..name"
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+  [[ "$output" == *"Invalid branch name"* ]]
+}
+
+@test "_aw_rename: rejects an existing branch" {
+  git branch "feature/taken"
+  run _aw_rename "feature/old-name" "feature/taken"
+  [ "$status" -eq "$AW_EXIT_EXISTS" ]
+  [ -d "$WT_BASE/feature-old-name" ]
+}
+
+@test "_aw_rename: rejects a name whose worktree path is already used" {
+  mkdir -p "$WT_BASE/feature-new-name"
+  run _aw_rename "feature/old-name" "feature/new-name"
+  [ "$status" -eq "$AW_EXIT_EXISTS" ]
+  [[ "$output" == *"already exists at: $WT_BASE/feature-new-name"* ]]
+  git show-ref --verify --quiet "refs/heads/feature/old-name"
+}
+
+@test "_aw_rename: renames the branch and moves the worktree" {
+  run _aw_rename "feature/old-name" "feature/new-name"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Renamed branch: feature/old-name → feature/new-name"* ]]
+
+  ! git show-ref --verify --quiet "refs/heads/feature/old-name"
+  git show-ref --verify --quiet "refs/heads/feature/new-name"
+  [ ! -e "$WT_BASE/feature-old-name" ]
+  [ "$(_aw_get_worktree_for_branch "feature/new-name")" = "$WT_BASE/feature-new-name" ]
+  [ "$(git -C "$WT_BASE/feature-new-name" rev-parse --abbrev-ref HEAD)" = "feature/new-name" ]
+  [ -z "$(git worktree list --porcelain | grep '^prunable')" ]
+}
+
+@test "_aw_rename: carries branch metadata over to the new name" {
+  _aw_set_branch_metadata "feature/old-name" issue-id "42"
+
+  _aw_rename "feature/old-name" "feature/new-name" >/dev/null
+
+  [ "$(_aw_get_branch_metadata "feature/new-name" issue-id)" = "42" ]
+  [ -z "$(_aw_get_branch_metadata "feature/old-name" issue-id)" ]
+}
+
+@test "_aw_rename: follows the move when run from inside the worktree" {
+  mkdir -p "$WT_BASE/feature-old-name/sub"
+  cd "$WT_BASE/feature-old-name/sub"
+
+  _aw_rename "feature/old-name" "feature/new-name" >/dev/null
+
+  [ "$(pwd -P)" = "$(cd "$WT_BASE/feature-new-name/sub" && pwd -P)" ]
+}
+
+@test "_aw_rename: quiet mode prints only the new path" {
+  _AW_QUIET=true
+  run _aw_rename "feature/old-name" "feature/new-name"
+  [ "$status" -eq 0 ]
+  [ "$output" = "$WT_BASE/feature-new-name" ]
+}
+
+@test "_aw_rename: a single argument renames the current worktree" {
+  cd "$WT_BASE/feature-old-name"
+
+  run _aw_rename "feature/new-name"
+  [ "$status" -eq 0 ]
+  git show-ref --verify --quiet "refs/heads/feature/new-name"
+  ! git show-ref --verify --quiet "refs/heads/feature/old-name"
+}
@@ -0,0 +1,126 @@
+#!/usr/bin/env bats
+# Tests for src/commands/sessions.sh
+#
+# Covers:
+#   - _aw_sessions keep: sets/clears keep-alive metadata, requires a worktree,
+#     defaults to the current worktree
+#   - _aw_sessions list: prints every worktree with how often it was opened,
+#     its keep-alive mark and the issue it was created from
+#   - _aw_is_kept_alive
+#   - usage errors for missing branches and unknown subcommands/options
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+
+setup() {
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  export -f gum
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/worktree.sh
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  # shellcheck source=../src/lib/metadata.sh
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  # shellcheck source=../src/providers/common.sh
+  source "${REPO_ROOT}/src/providers/common.sh"
+  # shellcheck source=../src/commands/sessions.sh
+  source "${REPO_ROOT}/src/commands/sessions.sh"
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+
+  WT_PATH="${TEST_REPO_DIR}-kept"
+  git worktree add -q -b "feature/kept" "$WT_PATH"
+}
+
+teardown() {
+  rm -rf "$WT_PATH"
+  teardown_git_repo
+}
+
+@test "_aw_sessions keep: marks a worktree's branch keep-alive" {
+  run _aw_sessions keep "feature/kept"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"✓ feature/kept will be kept alive"* ]]
+  [ "$(git config branch.feature/kept.aw-keep-alive)" = "true" ]
+  _aw_is_kept_alive "feature/kept"
+}
+
+@test "_aw_sessions keep --off: clears the keep-alive mark" {
+  _aw_set_branch_metadata "feature/kept" "keep-alive" "true"
+
+  run _aw_sessions keep --off "feature/kept"
+  [ "$status" -eq 0 ]
+  run git config branch.feature/kept.aw-keep-alive
+  [ "$status" -ne 0 ]
+  run _aw_is_kept_alive "feature/kept"
+  [ "$status" -ne 0 ]
+}
+
+@test "_aw_sessions keep: requires a branch with a worktree" {
+  run _aw_sessions keep
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  git branch "no-worktree"
+  run _aw_sessions keep "no-worktree"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"No worktree found for branch: no-worktree"* ]]
+  [ -z "$(git config branch.no-worktree.aw-keep-alive)" ]
+}
+
+@test "_aw_sessions: lists every worktree by default, tagging keep-alive ones" {
+  run _aw_sessions
+  [ "$status" -eq 0 ]
+  [[ "$output" == "feature/kept  "*"-kept  opened 0×" ]]
+
+  _aw_set_branch_metadata "feature/kept" "keep-alive" "true"
+  run _aw_sessions list
+  [ "$status" -eq 0 ]
+  [[ "$output" == "feature/kept  "*"-kept  opened 0×  [keep-alive]" ]]
+}
+
+@test "_aw_sessions list: shows the issue a worktree was created from" {
+  _aw_record_issue_metadata "feature/kept" "github" "42" "Fix login bug"
+
+  run _aw_sessions list
+  [ "$status" -eq 0 ]
+  [[ "$output" == "feature/kept  "*"-kept  opened 0×"* ]]
+  [[ "$output" == *"↳ GitHub #42: Fix login bug"* ]]
+  [[ "$output" != *"[keep-alive]"* ]]
+}
+
+@test "_aw_sessions list: says so when there are no worktrees besides the main checkout" {
+  git worktree remove "$WT_PATH"
+
+  run _aw_sessions list
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"No additional worktrees for"* ]]
+}
+
+@test "_aw_sessions list: shows the open count and when the worktree was last opened" {
+  _aw_set_branch_metadata "feature/kept" "keep-alive" "true"
+  _aw_touch_last_accessed "feature/kept"
+  _aw_touch_last_accessed "feature/kept"
+
+  run _aw_sessions list
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"-kept  opened 2×, last [0h ago]  [keep-alive]" ]]
+}
+
+@test "_aw_sessions: rejects unknown subcommands and options" {
+  run _aw_sessions reap
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  run _aw_sessions keep --forever "feature/kept"
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_sessions keep: defaults to the current worktree's branch" {
+  cd "$WT_PATH"
+
+  run _aw_sessions keep
+  [ "$status" -eq 0 ]
+  [ "$(git config branch.feature/kept.aw-keep-alive)" = "true" ]
+}
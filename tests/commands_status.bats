@@ -0,0 +1,127 @@
+#!/usr/bin/env bats
+# Tests for src/commands/status.sh
+#
+# Covers:
+#   - _aw_status (counts of worktrees, dirty, unpushed, stale, merged; base path)
+#   - keep-alive worktrees are not counted as stale
+#   - auto-worktree.age-stale-days sets the stale threshold
+#   - the current worktree line
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+
+setup() {
+  # Print every line of styled boxes so the summary rows can be checked
+  gum() {
+    if [[ "$1" == "style" ]]; then
+      shift
+      while [[ "$1" == --* ]]; do shift 2; done
+      printf '%s\n' "$@"
+    fi
+  }
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
+  # shellcheck source=../src/providers/common.sh
+  source "${REPO_ROOT}/src/providers/common.sh"
+  # shellcheck source=../src/lib/worktree.sh
+  source "${REPO_ROOT}/src/lib/worktree.sh"
+  # shellcheck source=../src/lib/metadata.sh
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  # shellcheck source=../src/commands/status.sh
+  source "${REPO_ROOT}/src/commands/status.sh"
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git config auto-worktree.issue-provider none
+
+  export AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees"
+  WT_BASE="${AW_WORKTREE_BASE}/$(basename "$TEST_REPO_DIR")"
+  mkdir -p "$WT_BASE"
+}
+
+teardown() {
+  teardown_git_repo
+  rm -rf "${TEST_REPO_DIR}-worktrees"
+  unset AW_WORKTREE_BASE
+}
+
+@test "_aw_status: rejects unknown options" {
+  run _aw_status --bogus
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_status: reports zero counts without additional worktrees" {
+  run _aw_status
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Worktrees:         0"* ]]
+  [[ "$output" == *"Location:          $WT_BASE"* ]]
+}
+
+@test "_aw_status: counts dirty, unpushed, stale and merged worktrees" {
+  # Branch whose PR was merged: a cleanup candidate
+  git worktree add -q -b "feature/merged" "$WT_BASE/feature-merged"
+  _aw_check_branch_pr_merged() { [[ "$1" == "feature/merged" ]]; }
+
+  # Dirty worktree with an unpushed commit
+  git worktree add -q -b "feature/dirty" "$WT_BASE/feature-dirty"
+  git -C "$WT_BASE/feature-dirty" commit -q --allow-empty -m "work"
+  echo "change" > "$WT_BASE/feature-dirty/untracked.txt"
+
+  # Stale worktree: last commit a week ago
+  git worktree add -q -b "feature/stale" "$WT_BASE/feature-stale"
+  local week_ago=$(( $(date +%s) - 7 * 24 * 60 * 60 ))
+  GIT_COMMITTER_DATE="@$week_ago" GIT_AUTHOR_DATE="@$week_ago" \
+    git -C "$WT_BASE/feature-stale" commit -q --allow-empty -m "old work"
+
+  run _aw_status
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Worktrees:         3"* ]]
+  [[ "$output" == *"Dirty:             1"* ]]
+  [[ "$output" == *"Stale (>4 days):   1"* ]]
+  [[ "$output" == *"Merged:            1"* ]]
+  [[ "$output" == *"auto-worktree cleanup"* ]]
+}
+
+@test "_aw_status: auto-worktree.age-stale-days sets the stale threshold" {
+  git worktree add -q -b "feature/week-old" "$WT_BASE/feature-week-old"
+  local week_ago=$(( $(date +%s) - 7 * 24 * 60 * 60 ))
+  GIT_COMMITTER_DATE="@$week_ago" GIT_AUTHOR_DATE="@$week_ago" \
+    git -C "$WT_BASE/feature-week-old" commit -q --allow-empty -m "old work"
+  _aw_check_branch_pr_merged() { return 1; }
+  git config auto-worktree.age-stale-days 10
+
+  run _aw_status
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Stale (>10 days):  0"* ]]
+
+  git config auto-worktree.age-stale-days 1
+  run _aw_status
+  [[ "$output" == *"Stale (>1 day):    1"* ]]
+}
+
+@test "_aw_status: does not count keep-alive worktrees as stale" {
+  git worktree add -q -b "feature/kept" "$WT_BASE/feature-kept"
+  local week_ago=$(( $(date +%s) - 7 * 24 * 60 * 60 ))
+  GIT_COMMITTER_DATE="@$week_ago" GIT_AUTHOR_DATE="@$week_ago" \
+    git -C "$WT_BASE/feature-kept" commit -q --allow-empty -m "old work"
+  _aw_check_branch_pr_merged() { return 1; }
+  _aw_set_branch_metadata "feature/kept" "keep-alive" "true"
+
+  run _aw_status
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Stale (>4 days):   0"* ]]
+}
+
+@test "_aw_status: shows the current worktree" {
+  git worktree add -q -b "feature/here" "$WT_BASE/feature-here"
+  _aw_check_branch_pr_merged() { return 1; }
+  cd "$WT_BASE/feature-here"
+
+  run _aw_status
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Current:           feature-here (feature/here)"* ]]
+}
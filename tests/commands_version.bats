@@ -0,0 +1,65 @@
+#!/usr/bin/env bats
+# Tests for src/commands/version.sh
+#
+# Covers:
+#   - _aw_version (human string, --json with git/gh versions, null for missing CLIs)
+#   - _aw_version_string (stamped version, source checkout fallback)
+#   - _aw_cli_version
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/mock_cli'
+
+setup() {
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  export -f gum
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/commands/version.sh
+  source "${REPO_ROOT}/src/commands/version.sh"
+
+  setup_mock_cli
+  _AW_VERSION="1.2.3"
+}
+
+teardown() {
+  teardown_mock_cli
+}
+
+@test "_aw_version: prints a human-readable version" {
+  run _aw_version
+  [ "$status" -eq 0 ]
+  [ "$output" = "auto-worktree 1.2.3" ]
+}
+
+@test "_aw_version --json: reports the git and gh versions" {
+  mock_cli gh "" "gh version 2.40.1 (2023-12-13)"
+
+  run _aw_version --json
+  [ "$status" -eq 0 ]
+  [ "$output" = "{\"version\": \"1.2.3\", \"git\": \"$(git --version | awk '{print $3}')\", \"gh\": \"2.40.1\"}" ]
+}
+
+@test "_aw_version --json: gh is null when it isn't installed" {
+  _aw_cli_version() { [[ "$1" == "git" ]] && echo "2.43.0"; }
+
+  run _aw_version --json
+  [ "$output" = '{"version": "1.2.3", "git": "2.43.0", "gh": null}' ]
+}
+
+@test "_aw_version_string: describes a source checkout when not stamped" {
+  _AW_VERSION=""
+  _AW_INSTALL_DIR="$REPO_ROOT"
+  run _aw_version_string
+  [ "$output" = "$(git -C "$REPO_ROOT" describe --tags --always --dirty)" ]
+
+  _AW_INSTALL_DIR="$BATS_TEST_TMPDIR"
+  run _aw_version_string
+  [ "$output" = "unknown" ]
+}
+
+@test "_aw_version: rejects unknown options" {
+  run _aw_version --yaml
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
@@ -0,0 +1,168 @@
+#!/usr/bin/env bats
+# Tests for src/lib/deps.sh
+#
+# Covers:
+#   - _aw_require_provider: missing CLI, unauthenticated CLI, authenticated CLI
+#   - Linear: LINEAR_API_KEY missing, rejected, or set
+#   - remediation messages go to stderr with AW_EXIT_PROVIDER
+#   - GitHub Enterprise hosts from auto-worktree.github-host
+#   - provider CLI minimum versions: _aw_version_lt, _aw_provider_cli_version, warn-only check
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/mock_cli'
+
+setup() {
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
+  # shellcheck source=../src/lib/deps.sh
+  source "${REPO_ROOT}/src/lib/deps.sh"
+
+  setup_mock_cli
+  # Only the mocks are visible, so "not installed" is deterministic
+  SAVED_PATH="$PATH"
+  PATH="$MOCK_BIN_DIR:/usr/bin:/bin"
+}
+
+teardown() {
+  PATH="$SAVED_PATH"
+  teardown_mock_cli
+}
+
+# Mock CLI that exits with the given status for every invocation
+_mock_cli_status() {
+  local tool="$1"
+  local exit_status="$2"
+  cat > "$MOCK_BIN_DIR/$tool" <<MOCK
+#!/usr/bin/env bash
+echo "\$*" >> "$MOCK_BIN_DIR/${tool}.calls"
+exit $exit_status
+MOCK
+  chmod +x "$MOCK_BIN_DIR/$tool"
+}
+
+@test "_aw_require_provider: fails with install guidance when the CLI is missing" {
+  if command -v gh &>/dev/null; then
+    skip "gh is installed system-wide"
+  fi
+  run _aw_require_provider github
+  [ "$status" -eq "$AW_EXIT_PROVIDER" ]
+  [[ "$output" == *"GitHub CLI (gh) is required"* ]]
+}
+
+@test "_aw_require_provider: github tells the user to run gh auth login" {
+  _mock_cli_status gh 1
+  run _aw_require_provider github
+  [ "$status" -eq "$AW_EXIT_PROVIDER" ]
+  [[ "$output" == *"gh auth login"* ]]
+  assert_cli_called gh "auth status"
+}
+
+@test "_aw_require_provider: github checks the configured enterprise host" {
+  _mock_cli_status gh 1
+  _aw_get_config() { [[ "$1" == "github-host" ]] && echo "ghe.example.com"; }
+  run _aw_require_provider github
+  [ "$status" -eq "$AW_EXIT_PROVIDER" ]
+  [[ "$output" == *"gh auth login --hostname ghe.example.com"* ]]
+  assert_cli_called gh "auth status --hostname ghe.example.com"
+}
+
+@test "_aw_require_provider: gitlab tells the user to run glab auth login" {
+  _mock_cli_status glab 1
+  run _aw_require_provider gitlab
+  [ "$status" -eq "$AW_EXIT_PROVIDER" ]
+  [[ "$output" == *"glab auth login"* ]]
+}
+
+@test "_aw_require_provider: jira tells the user to run jira init" {
+  _mock_cli_status jira 1
+  run _aw_require_provider jira
+  [ "$status" -eq "$AW_EXIT_PROVIDER" ]
+  [[ "$output" == *"jira init"* ]]
+}
+
+@test "_aw_require_provider: linear tells the user to set LINEAR_API_KEY" {
+  _mock_cli_status linear 1
+  unset LINEAR_API_KEY
+  run _aw_require_provider linear
+  [ "$status" -eq "$AW_EXIT_PROVIDER" ]
+  [[ "$output" == *"LINEAR_API_KEY"* ]]
+  [[ "$output" == *"LINEAR_API_KEY is not set"* ]]
+}
+
+@test "_aw_require_provider: linear accepts a key from the CLI config when LINEAR_API_KEY is unset" {
+  _mock_cli_status linear 0
+  unset LINEAR_API_KEY
+  run _aw_require_provider linear
+  [ "$status" -eq 0 ]
+}
+
+@test "_aw_require_provider: linear reports a rejected LINEAR_API_KEY" {
+  _mock_cli_status linear 1
+  LINEAR_API_KEY="lin_api_invalid"
+  run _aw_require_provider linear
+  [ "$status" -eq "$AW_EXIT_PROVIDER" ]
+  [[ "$output" == *"rejected the API key in LINEAR_API_KEY"* ]]
+  [[ "$output" != *"is not set"* ]]
+  assert_cli_called linear "team list"
+}
+
+@test "_aw_require_provider: linear succeeds with LINEAR_API_KEY set" {
+  _mock_cli_status linear 0
+  LINEAR_API_KEY="lin_api_valid"
+  run _aw_require_provider linear
+  [ "$status" -eq 0 ]
+}
+
+@test "_aw_require_provider: succeeds when the CLI is authenticated" {
+  _mock_cli_status gh 0
+  run _aw_require_provider github
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
+}
+
+@test "_aw_require_provider: guidance is written to stderr" {
+  _mock_cli_status gh 1
+  local stdout
+  stdout=$(_aw_require_provider github 2>/dev/null) || true
+  [ -z "$stdout" ]
+}
+
+@test "_aw_version_lt: compares dotted versions numerically" {
+  _aw_version_lt 1.9.2 2.0.0
+  _aw_version_lt 2.9 2.10
+  _aw_version_lt 1.4 1.4.1
+  ! _aw_version_lt 2.10.0 2.9.0
+  ! _aw_version_lt 2.0.0 2.0
+}
+
+@test "_aw_provider_cli_version: reads gh --version and jira version" {
+  mock_cli gh "--version" 'gh version 2.43.0 (2024-01-01)'
+  mock_cli jira "version" '(Version="1.4.0", GitCommit="abc", GoVersion="go1.21")'
+  [ "$(_aw_provider_cli_version github)" = "2.43.0" ]
+  [ "$(_aw_provider_cli_version jira)" = "1.4.0" ]
+  assert_cli_called jira "version"
+}
+
+@test "_aw_require_provider: warns about, but allows, a CLI older than the minimum" {
+  mock_cli gh "--version" 'gh version 1.9.2 (2021-04-20)'
+  run _aw_require_provider github
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"gh 1.9.2 is older than 2.0.0"* ]]
+}
+
+@test "_aw_require_provider: no warning for a supported or unreadable version" {
+  mock_cli gh "--version" 'gh version 2.43.0 (2024-01-01)'
+  run _aw_require_provider github
+  [ "$status" -eq 0 ]
+  [[ "$output" != *"older than"* ]]
+
+  mock_cli glab "--version" 'not a version'
+  run _aw_require_provider gitlab
+  [ "$status" -eq 0 ]
+  [[ "$output" != *"older than"* ]]
+}
@@ -6,8 +6,12 @@
 #   - Very large / epoch timestamps (_aw_format_worktree_age)
 #   - Empty/null inputs to extraction functions
 #   - _aw_format_labels edge cases (single label, spaces, pipe, empty entries)
-#   - AW_EXIT_CANCELLED constant value
+#   - AW_EXIT_CANCELLED and error-category exit code values
 #   - _aw_set_config / _aw_get_config allowed-values validation
+#   - AW_ISSUE_PROVIDER / AW_WORKTREE_BASE environment overrides
+#   - worktree base nested in (or containing) the checkout: warning, size scans
+#   - repository lock (_aw_acquire_lock / _aw_release_lock / _aw_with_lock)
+#   - --verbose tracing (_aw_log / _aw_trace_start / _aw_trace_stop)
 
 REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
 
@@ -176,6 +180,20 @@ teardown() {
   [ "$AW_EXIT_CANCELLED" -eq 130 ]
 }
 
+@test "error-category exit codes are distinct and stable" {
+  [ "$AW_EXIT_USAGE" -eq 2 ]
+  [ "$AW_EXIT_NOT_GIT_REPO" -eq 3 ]
+  [ "$AW_EXIT_PROVIDER" -eq 4 ]
+  [ "$AW_EXIT_EXISTS" -eq 5 ]
+  [ "$AW_EXIT_LOCKED" -eq 6 ]
+}
+
+@test "_aw_ensure_git_repo: returns AW_EXIT_NOT_GIT_REPO outside a git repository" {
+  cd "$BATS_TEST_TMPDIR"
+  GIT_CEILING_DIRECTORIES="$BATS_TEST_TMPDIR" run _aw_ensure_git_repo
+  [ "$status" -eq "$AW_EXIT_NOT_GIT_REPO" ]
+}
+
 # ===== _aw_get_config / _aw_set_config with allowed-values validation =====
 
 @test "_aw_set_config: invalid value against allowed list returns non-zero" {
@@ -210,3 +228,195 @@ teardown() {
   [ "$status" -eq 0 ]
   [ -z "$output" ]
 }
+
+# ===== Environment overrides =====
+
+@test "_aw_get_issue_provider: AW_ISSUE_PROVIDER takes precedence over git config" {
+  cd "$TEST_REPO_DIR"
+  unset -f _aw_get_issue_provider
+  source "${REPO_ROOT}/src/lib/config.sh"
+  git config auto-worktree.issue-provider github
+  [ "$(_aw_get_issue_provider)" = "github" ]
+  [ "$(AW_ISSUE_PROVIDER=linear _aw_get_issue_provider)" = "linear" ]
+}
+
+@test "_aw_get_repo_info: worktree base defaults to ~/worktrees/<repo>" {
+  cd "$TEST_REPO_DIR"
+  unset AW_WORKTREE_BASE
+  _aw_get_repo_info
+  [ "$_AW_WORKTREE_BASE" = "$HOME/worktrees/$(basename "$TEST_REPO_DIR")" ]
+}
+
+@test "_aw_get_repo_info: worktree-base config is used, with ~ expanded" {
+  cd "$TEST_REPO_DIR"
+  unset AW_WORKTREE_BASE
+  git config auto-worktree.worktree-base "~/src/trees/"
+  _aw_get_repo_info
+  [ "$_AW_WORKTREE_BASE" = "$HOME/src/trees/$(basename "$TEST_REPO_DIR")" ]
+}
+
+@test "_aw_get_repo_info: AW_WORKTREE_BASE overrides worktree-base config" {
+  cd "$TEST_REPO_DIR"
+  git config auto-worktree.worktree-base "/from/config"
+  AW_WORKTREE_BASE="/from/env"
+  _aw_get_repo_info
+  unset AW_WORKTREE_BASE
+  [ "$_AW_WORKTREE_BASE" = "/from/env/$(basename "$TEST_REPO_DIR")" ]
+}
+
+@test "_aw_worktree_base_overlap: detects either path inside the other" {
+  [ "$(_aw_worktree_base_overlap "$TEST_REPO_DIR" "$TEST_REPO_DIR/.trees/repo")" = "inside" ]
+  [ "$(_aw_worktree_base_overlap "$TEST_REPO_DIR" "$TEST_REPO_DIR")" = "inside" ]
+  [ "$(_aw_worktree_base_overlap "$TEST_REPO_DIR/sub/repo" "$TEST_REPO_DIR")" = "contains" ]
+  [ -z "$(_aw_worktree_base_overlap "$TEST_REPO_DIR" "${TEST_REPO_DIR}-worktrees/repo")" ]
+}
+
+@test "_aw_get_repo_info: warns when the worktree base is inside the repository" {
+  cd "$TEST_REPO_DIR"
+  gum() { [[ "$1" == "style" ]] && echo "${@: -1}"; return 0; }
+
+  AW_WORKTREE_BASE="$TEST_REPO_DIR/.trees" run _aw_get_repo_info
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Warning: worktree base $TEST_REPO_DIR/.trees/"*"is inside the repository"* ]]
+
+  AW_WORKTREE_BASE="${TEST_REPO_DIR}-worktrees" run _aw_get_repo_info
+  [ -z "$output" ]
+}
+
+@test "_aw_get_dir_size_kb: leaves a nested worktree base out of the total" {
+  mkdir -p "$TEST_REPO_DIR/.trees"
+  head -c 409600 /dev/zero > "$TEST_REPO_DIR/.trees/big"
+
+  local with_base=$(_aw_get_dir_size_kb "$TEST_REPO_DIR")
+  local without_base=$(_aw_get_dir_size_kb "$TEST_REPO_DIR" 30 "$TEST_REPO_DIR/.trees")
+  [ $((with_base - without_base)) -ge 400 ]
+}
+
+# ===== Repository lock =====
+
+@test "_aw_acquire_lock: creates the lock in the common git dir and releases it" {
+  cd "$TEST_REPO_DIR"
+  _aw_acquire_lock
+  [ -d "$TEST_REPO_DIR/.git/auto-worktree.lock" ]
+  [ "$(cat "$TEST_REPO_DIR/.git/auto-worktree.lock/pid")" = "$BASHPID" ]
+  _aw_release_lock
+  [ ! -d "$TEST_REPO_DIR/.git/auto-worktree.lock" ]
+}
+
+@test "_aw_acquire_lock: nested acquisitions release only at the outermost level" {
+  cd "$TEST_REPO_DIR"
+  _aw_acquire_lock
+  _aw_acquire_lock
+  _aw_release_lock
+  [ -d "$TEST_REPO_DIR/.git/auto-worktree.lock" ]
+  _aw_release_lock
+  [ ! -d "$TEST_REPO_DIR/.git/auto-worktree.lock" ]
+}
+
+@test "_aw_acquire_lock: times out with AW_EXIT_LOCKED while another process holds it" {
+  cd "$TEST_REPO_DIR"
+  sleep 30 &
+  local holder=$!
+  mkdir "$TEST_REPO_DIR/.git/auto-worktree.lock"
+  echo "$holder" > "$TEST_REPO_DIR/.git/auto-worktree.lock/pid"
+
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  run _aw_acquire_lock 0
+  kill "$holder" 2>/dev/null || true
+
+  [ "$status" -eq "$AW_EXIT_LOCKED" ]
+  [[ "$output" == *"PID $holder"* ]]
+}
+
+@test "_aw_acquire_lock: reclaims a lock left by a process that has exited" {
+  cd "$TEST_REPO_DIR"
+  bash -c 'exit 0' &
+  local dead_pid=$!
+  wait "$dead_pid"
+  mkdir "$TEST_REPO_DIR/.git/auto-worktree.lock"
+  echo "$dead_pid" > "$TEST_REPO_DIR/.git/auto-worktree.lock/pid"
+
+  _aw_acquire_lock 0
+  [ "$(cat "$TEST_REPO_DIR/.git/auto-worktree.lock/pid")" = "$BASHPID" ]
+  _aw_release_lock
+}
+
+@test "_aw_acquire_lock: a subshell waits for a lock held by its parent shell" {
+  cd "$TEST_REPO_DIR"
+  _aw_acquire_lock
+  local parent_pid=$(cat "$TEST_REPO_DIR/.git/auto-worktree.lock/pid")
+
+  gum() { :; }
+  # run executes in a subshell, which shares $$ with this shell
+  _AW_LOCK_DEPTH=0 run _aw_acquire_lock 0
+  [ "$status" -eq "$AW_EXIT_LOCKED" ]
+  [ "$(cat "$TEST_REPO_DIR/.git/auto-worktree.lock/pid")" = "$parent_pid" ]
+
+  _aw_release_lock
+}
+
+@test "_aw_with_lock: returns the command's status and releases the lock" {
+  cd "$TEST_REPO_DIR"
+  run _aw_with_lock false
+  [ "$status" -eq 1 ]
+  [ ! -d "$TEST_REPO_DIR/.git/auto-worktree.lock" ]
+}
+
+# ===== --verbose tracing =====
+
+@test "_aw_trace_start: logs git calls with duration and exit status to stderr" {
+  cd "$TEST_REPO_DIR"
+  _AW_VERBOSE=true
+  _aw_trace_start 2>"$BATS_TEST_TMPDIR/stderr"
+  # Callers silencing stderr don't hide the log
+  git rev-parse --git-dir >/dev/null 2>&1
+  git rev-parse --verify no-such-branch >/dev/null 2>&1 || true
+  _aw_trace_stop
+  _AW_VERBOSE=false
+
+  run cat "$BATS_TEST_TMPDIR/stderr"
+  [[ "$output" == *"DEBUG git rev-parse --git-dir → exit 0 in "*"ms"* ]]
+  [[ "$output" == *"WARN git rev-parse --verify no-such-branch → exit 128 in "*"ms"* ]]
+  ! typeset -f git >/dev/null
+}
+
+@test "_aw_trace_start: leaves tools that are already shell functions alone" {
+  cd "$TEST_REPO_DIR"
+  unset -f gum
+  gum() { return 0; }
+  _AW_VERBOSE=true
+  _aw_trace_start 2>"$BATS_TEST_TMPDIR/stderr"
+  git --version >/dev/null
+  _aw_trace_stop
+  grep -q "git --version" "$BATS_TEST_TMPDIR/stderr"
+  # gum was already a function, so it is left alone
+  [ "$(typeset -f gum | grep -c _aw_traced)" -eq 0 ]
+}
+
+@test "_aw_log: silent without --verbose" {
+  _AW_VERBOSE=false
+  run _aw_log debug "hello"
+  [ -z "$output" ]
+}
+
+@test "_aw_log: AW_LOG_LEVEL filters lower levels" {
+  _AW_VERBOSE=true
+  AW_LOG_LEVEL=warn run _aw_log debug "hidden"
+  [ -z "$output" ]
+  AW_LOG_LEVEL=warn run _aw_log error "shown"
+  [[ "$output" == *"ERROR shown"* ]]
+}
+
+@test "_aw_traced: a leftover wrapper removes itself outside verbose runs" {
+  cd "$TEST_REPO_DIR"
+  _AW_VERBOSE=true
+  _aw_trace_start
+  _aw_trace_stop
+  git() { _aw_traced git "$@"; }
+  _AW_VERBOSE=false
+  run git rev-parse --git-dir
+  [ "$status" -eq 0 ]
+  [[ "$output" != *"[aw "* ]]
+  git --version >/dev/null
+  ! typeset -f git >/dev/null
+}
@@ -0,0 +1,97 @@
+# BATS helper: fake_hook_executor.bash
+# Replaces _aw_execute_hook, the seam _aw_run_git_hooks uses to run a single
+# hook, so hook selection can be tested without running hook scripts.
+#
+# The fake:
+#   - Treats a hook as present when its file exists, and as executable unless
+#     fake_hook_not_executable was called for it
+#   - Records every hook it "runs" to $FAKE_HOOK_LOG as "<hook_path> <worktree_path>"
+#   - Fails hooks registered with fake_hook_fail
+#
+# Usage in a test file (after sourcing src/lib/hooks.sh):
+#   load 'helpers/fake_hook_executor'
+#
+#   setup() {
+#     source "${REPO_ROOT}/src/lib/hooks.sh"
+#     setup_fake_hook_executor
+#   }
+#
+#   @test "runs post-worktree" {
+#     fake_hook "$TEST_REPO_DIR/.git/hooks/post-worktree"
+#     _aw_run_git_hooks "$TEST_REPO_DIR"
+#     assert_hook_ran "$TEST_REPO_DIR/.git/hooks/post-worktree"
+#   }
+
+setup_fake_hook_executor() {
+  FAKE_HOOK_DIR="$(mktemp -d "$BATS_TMPDIR/fake-hooks-XXXXXX")"
+  FAKE_HOOK_LOG="$FAKE_HOOK_DIR/executed"
+  : > "$FAKE_HOOK_LOG"
+  : > "$FAKE_HOOK_DIR/failing"
+  : > "$FAKE_HOOK_DIR/not-executable"
+  export FAKE_HOOK_DIR FAKE_HOOK_LOG
+
+  _aw_execute_hook() {
+    local hook_path="$1"
+    local worktree_path="$2"
+
+    [[ -f "$hook_path" ]] || return 2
+    grep -qxF "$hook_path" "$FAKE_HOOK_DIR/not-executable" && return 2
+
+    echo "$hook_path $worktree_path" >> "$FAKE_HOOK_LOG"
+    grep -qxF "$hook_path" "$FAKE_HOOK_DIR/failing" && return 1
+    return 0
+  }
+}
+
+# Creates an (empty) hook file so the fake considers it present.
+#
+# Usage: fake_hook <hook_path>
+fake_hook() {
+  mkdir -p "$(dirname "$1")"
+  : > "$1"
+}
+
+# Makes a hook fail when the fake runs it.
+#
+# Usage: fake_hook_fail <hook_path>
+fake_hook_fail() {
+  fake_hook "$1"
+  echo "$1" >> "$FAKE_HOOK_DIR/failing"
+}
+
+# Makes a present hook count as not executable, so it is skipped.
+#
+# Usage: fake_hook_not_executable <hook_path>
+fake_hook_not_executable() {
+  fake_hook "$1"
+  echo "$1" >> "$FAKE_HOOK_DIR/not-executable"
+}
+
+# Prints the hook paths the fake ran, in order.
+fake_hooks_ran() {
+  cut -d' ' -f1 "$FAKE_HOOK_LOG"
+}
+
+# Assert that a hook was run (optionally for a given worktree).
+#
+# Usage: assert_hook_ran <hook_path> [worktree_path]
+assert_hook_ran() {
+  local expected="$1"
+  local ran
+  if [[ -n "${2:-}" ]]; then
+    expected="$1 $2"
+    ran=$(cat "$FAKE_HOOK_LOG")
+  else
+    ran=$(fake_hooks_ran)
+  fi
+  if ! grep -qxF "$expected" <<< "$ran"; then
+    echo "Expected hook to run: $expected" >&2
+    echo "Hooks run:" >&2
+    cat "$FAKE_HOOK_LOG" >&2
+    return 1
+  fi
+}
+
+teardown_fake_hook_executor() {
+  rm -rf "$FAKE_HOOK_DIR"
+}
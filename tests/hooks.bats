@@ -0,0 +1,316 @@
+#!/usr/bin/env bats
+# Tests for src/lib/hooks.sh
+#
+# Covers:
+#   - _aw_find_hook_paths (core.hooksPath > .husky > .git/hooks)
+#   - _aw_run_git_hooks with a fake _aw_execute_hook: first directory with a hook
+#     wins, missing/non-executable hooks are skipped, fail-on-hook-error, custom hooks
+#   - _aw_execute_hook (post-checkout parameters, worktree cwd, PATH fallbacks)
+#   - _aw_hook_extra_path (auto-worktree.hook-path, OS-specific defaults)
+#   - _aw_discover_hooks and the "Hooks to run" summary, --verbose hook logging
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+load 'helpers/fake_hook_executor'
+
+setup() {
+  gum() { if [[ "$1" == "style" ]]; then echo "${@: -1}"; fi; }
+  export -f gum
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/hooks.sh
+  source "${REPO_ROOT}/src/lib/hooks.sh"
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+
+  GIT_HOOKS="$TEST_REPO_DIR/.git/hooks"
+  HUSKY_HOOKS="$TEST_REPO_DIR/.husky"
+  # Keep sample hooks from git init out of the way
+  rm -rf "$GIT_HOOKS"
+}
+
+teardown() {
+  teardown_fake_hook_executor
+  teardown_git_repo
+}
+
+# ============================================================================
+# _aw_find_hook_paths
+# ============================================================================
+
+@test "_aw_find_hook_paths: orders core.hooksPath, .husky, then .git/hooks" {
+  mkdir -p "$GIT_HOOKS" "$HUSKY_HOOKS"
+  git config core.hooksPath "tools/hooks"
+
+  run _aw_find_hook_paths "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [ "${lines[0]}" = "$TEST_REPO_DIR/tools/hooks" ]
+  [ "${lines[1]}" = "$HUSKY_HOOKS" ]
+  [ "${lines[2]}" = "$TEST_REPO_DIR/.git/hooks" ]
+  [ "${#lines[@]}" -eq 3 ]
+}
+
+@test "_aw_find_hook_paths: keeps an absolute core.hooksPath as is" {
+  git config core.hooksPath "/opt/shared-hooks"
+  run _aw_find_hook_paths "$TEST_REPO_DIR"
+  [ "$output" = "/opt/shared-hooks" ]
+}
+
+@test "_aw_find_hook_paths: uses the shared hooks directory from a linked worktree" {
+  mkdir -p "$GIT_HOOKS"
+  local wt_path="${TEST_REPO_DIR}-wt"
+  git worktree add -q -b feature/hooks "$wt_path"
+
+  run _aw_find_hook_paths "$wt_path"
+  [ "$(cd "$output" && pwd -P)" = "$GIT_HOOKS" ]
+
+  rm -rf "$wt_path"
+}
+
+@test "_aw_find_hook_paths: prints nothing when no hook directory exists" {
+  run _aw_find_hook_paths "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
+}
+
+# ============================================================================
+# _aw_run_git_hooks (fake executor)
+# ============================================================================
+
+@test "_aw_run_git_hooks: runs a hook from the highest-priority directory only" {
+  setup_fake_hook_executor
+  git config core.hooksPath "tools/hooks"
+  fake_hook "$TEST_REPO_DIR/tools/hooks/post-worktree"
+  fake_hook "$HUSKY_HOOKS/post-worktree"
+  fake_hook "$GIT_HOOKS/post-worktree"
+  fake_hook "$HUSKY_HOOKS/post-clone"
+  fake_hook "$GIT_HOOKS/post-clone"
+
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+
+  run fake_hooks_ran
+  [ "${lines[0]}" = "$HUSKY_HOOKS/post-clone" ]
+  [ "${lines[1]}" = "$TEST_REPO_DIR/tools/hooks/post-worktree" ]
+  [ "${#lines[@]}" -eq 2 ]
+  assert_hook_ran "$HUSKY_HOOKS/post-clone" "$TEST_REPO_DIR"
+}
+
+@test "_aw_run_git_hooks: falls through to the next directory for non-executable hooks" {
+  setup_fake_hook_executor
+  fake_hook_not_executable "$HUSKY_HOOKS/post-worktree"
+  fake_hook "$GIT_HOOKS/post-worktree"
+
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+
+  run fake_hooks_ran
+  [ "$output" = "$GIT_HOOKS/post-worktree" ]
+}
+
+@test "_aw_run_git_hooks: missing hooks are not an error" {
+  setup_fake_hook_executor
+  mkdir -p "$GIT_HOOKS" "$HUSKY_HOOKS"
+  git config auto-worktree.fail-on-hook-error true
+  git config auto-worktree.custom-hooks "post-setup"
+
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
+  [ ! -s "$FAKE_HOOK_LOG" ]
+}
+
+@test "_aw_run_git_hooks: a failing hook warns and later hooks still run by default" {
+  setup_fake_hook_executor
+  fake_hook_fail "$GIT_HOOKS/post-clone"
+  fake_hook "$GIT_HOOKS/post-worktree"
+
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"✗ Hook post-clone failed"* ]]
+  [[ "$output" == *"Continuing despite hook failure"* ]]
+  assert_hook_ran "$GIT_HOOKS/post-worktree"
+}
+
+@test "_aw_run_git_hooks: fail-on-hook-error stops at the first failing hook" {
+  setup_fake_hook_executor
+  git config auto-worktree.fail-on-hook-error true
+  fake_hook_fail "$GIT_HOOKS/post-clone"
+  fake_hook "$GIT_HOOKS/post-worktree"
+
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"git config auto-worktree.fail-on-hook-error false"* ]]
+
+  run fake_hooks_ran
+  [ "$output" = "$GIT_HOOKS/post-clone" ]
+}
+
+@test "_aw_run_git_hooks: a failed hook isn't retried from another directory" {
+  setup_fake_hook_executor
+  fake_hook_fail "$HUSKY_HOOKS/post-worktree"
+  fake_hook "$GIT_HOOKS/post-worktree"
+
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+
+  run fake_hooks_ran
+  [ "$output" = "$HUSKY_HOOKS/post-worktree" ]
+}
+
+@test "_aw_run_git_hooks: runs custom hooks after the built-in ones, in order" {
+  setup_fake_hook_executor
+  git config auto-worktree.custom-hooks "post-setup, post-deps bootstrap"
+  fake_hook "$GIT_HOOKS/bootstrap"
+  fake_hook "$GIT_HOOKS/post-setup"
+  fake_hook "$HUSKY_HOOKS/post-deps"
+  fake_hook "$GIT_HOOKS/post-worktree"
+
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+
+  run fake_hooks_ran
+  [ "${lines[0]}" = "$GIT_HOOKS/post-worktree" ]
+  [ "${lines[1]}" = "$GIT_HOOKS/post-setup" ]
+  [ "${lines[2]}" = "$HUSKY_HOOKS/post-deps" ]
+  [ "${lines[3]}" = "$GIT_HOOKS/bootstrap" ]
+  [ "${#lines[@]}" -eq 4 ]
+}
+
+@test "_aw_run_git_hooks: runs nothing when auto-worktree.run-hooks is false" {
+  setup_fake_hook_executor
+  git config auto-worktree.run-hooks false
+  fake_hook "$GIT_HOOKS/post-worktree"
+
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [ ! -s "$FAKE_HOOK_LOG" ]
+}
+
+# ============================================================================
+# _aw_execute_hook (real executor)
+# ============================================================================
+
+@test "_aw_execute_hook: passes post-checkout parameters and runs in the worktree" {
+  mkdir -p "$GIT_HOOKS"
+  local record="$BATS_TEST_TMPDIR/hook-call"
+  printf '#!/bin/sh\necho "$PWD|$1|$2|$3|$PATH" > "%s"\n' "$record" > "$GIT_HOOKS/post-worktree"
+  chmod +x "$GIT_HOOKS/post-worktree"
+
+  run _aw_execute_hook "$GIT_HOOKS/post-worktree" "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"✓ Hook post-worktree completed successfully"* ]]
+
+  local pwd_arg prev new flag path_env
+  IFS='|' read -r pwd_arg prev new flag path_env < "$record"
+  [ "$(cd "$pwd_arg" && pwd -P)" = "$TEST_REPO_DIR" ]
+  [ "$prev" = "0000000000000000000000000000000000000000" ]
+  [ "$new" = "$(git rev-parse HEAD)" ]
+  [ "$flag" = "1" ]
+  [[ "$path_env" == "$PATH:"* ]]
+  [[ "$path_env" == *":/usr/local/bin:"* ]]
+}
+
+@test "_aw_execute_hook: returns 2 for missing or non-executable hooks" {
+  mkdir -p "$GIT_HOOKS"
+  run _aw_execute_hook "$GIT_HOOKS/post-worktree" "$TEST_REPO_DIR"
+  [ "$status" -eq 2 ]
+
+  printf '#!/bin/sh\nexit 0\n' > "$GIT_HOOKS/post-worktree"
+  run _aw_execute_hook "$GIT_HOOKS/post-worktree" "$TEST_REPO_DIR"
+  [ "$status" -eq 2 ]
+}
+
+@test "_aw_execute_hook: returns 1 when the hook exits non-zero" {
+  mkdir -p "$GIT_HOOKS"
+  printf '#!/bin/sh\nexit 3\n' > "$GIT_HOOKS/post-worktree"
+  chmod +x "$GIT_HOOKS/post-worktree"
+
+  run _aw_execute_hook "$GIT_HOOKS/post-worktree" "$TEST_REPO_DIR"
+  [ "$status" -eq 1 ]
+}
+
+# ============================================================================
+# _aw_hook_extra_path
+# ============================================================================
+
+@test "_aw_hook_extra_path: uses auto-worktree.hook-path when set" {
+  git config auto-worktree.hook-path "/opt/node/bin:/home/me/.pyenv/shims"
+  run _aw_hook_extra_path "$TEST_REPO_DIR"
+  [ "$output" = "/opt/node/bin:/home/me/.pyenv/shims" ]
+}
+
+@test "_aw_hook_extra_path: defaults to Homebrew directories on macOS only" {
+  uname() { echo "Darwin"; }
+  run _aw_hook_extra_path "$TEST_REPO_DIR"
+  [[ "$output" == "/opt/homebrew/bin:"* ]]
+  [[ "$output" == *":/usr/local/bin:"* ]]
+
+  uname() { echo "Linux"; }
+  run _aw_hook_extra_path "$TEST_REPO_DIR"
+  [ "$output" = "/usr/local/bin:/usr/bin:/bin:/usr/sbin:/sbin" ]
+}
+
+@test "_aw_execute_hook: appends auto-worktree.hook-path to PATH" {
+  mkdir -p "$GIT_HOOKS"
+  git config auto-worktree.hook-path "/opt/toolchain/bin"
+  local record="$BATS_TEST_TMPDIR/hook-path"
+  printf '#!/bin/sh\necho "$PATH" > "%s"\n' "$record" > "$GIT_HOOKS/post-worktree"
+  chmod +x "$GIT_HOOKS/post-worktree"
+
+  run _aw_execute_hook "$GIT_HOOKS/post-worktree" "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [ "$(cat "$record")" = "$PATH:/opt/toolchain/bin" ]
+}
+
+# ============================================================================
+# Hook summary
+# ============================================================================
+
+@test "_aw_discover_hooks: first executable hook per name, in order" {
+  mkdir -p "$HUSKY_HOOKS" "$GIT_HOOKS"
+  printf '#!/bin/sh\n' > "$HUSKY_HOOKS/post-worktree"
+  printf '#!/bin/sh\n' > "$GIT_HOOKS/post-worktree"
+  printf '#!/bin/sh\n' > "$GIT_HOOKS/post-clone"
+  printf '#!/bin/sh\n' > "$HUSKY_HOOKS/post-setup"
+  chmod +x "$HUSKY_HOOKS/post-worktree" "$GIT_HOOKS/post-worktree" "$GIT_HOOKS/post-clone"
+
+  run _aw_discover_hooks "$HUSKY_HOOKS"$'\n'"$GIT_HOOKS" post-clone post-worktree post-setup
+  [ "$status" -eq 0 ]
+  [ "${lines[0]}" = "post-clone"$'\t'"$GIT_HOOKS/post-clone" ]
+  [ "${lines[1]}" = "post-worktree"$'\t'"$HUSKY_HOOKS/post-worktree" ]
+  [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "_aw_run_git_hooks: lists the hooks before running them" {
+  mkdir -p "$GIT_HOOKS"
+  printf '#!/bin/sh\necho "installing"\n' > "$GIT_HOOKS/post-worktree"
+  chmod +x "$GIT_HOOKS/post-worktree"
+
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Hooks to run:"*"  post-worktree  $GIT_HOOKS/post-worktree"*"installing"* ]]
+}
+
+@test "_aw_run_git_hooks: no summary when no hook will run" {
+  mkdir -p "$GIT_HOOKS"
+  printf '#!/bin/sh\n' > "$GIT_HOOKS/post-worktree"
+
+  run _aw_run_git_hooks "$TEST_REPO_DIR"
+  [ "$status" -eq 0 ]
+  [[ "$output" != *"Hooks to run"* ]]
+}
+
+@test "_aw_run_git_hooks: --verbose logs hook directories and durations" {
+  mkdir -p "$GIT_HOOKS"
+  printf '#!/bin/sh\n' > "$GIT_HOOKS/post-worktree"
+  chmod +x "$GIT_HOOKS/post-worktree"
+
+  _AW_VERBOSE=true
+  local hooks_status=0
+  _aw_run_git_hooks "$TEST_REPO_DIR" >/dev/null 2>"$BATS_TMPDIR/hooks.log" || hooks_status=$?
+  _AW_VERBOSE=false
+  [ "$hooks_status" -eq 0 ]
+  grep -q "DEBUG hook directories: .*$GIT_HOOKS" "$BATS_TMPDIR/hooks.log"
+  grep -Eq "DEBUG hook post-worktree \($GIT_HOOKS/post-worktree\) → exit 0 in [0-9]+ms" "$BATS_TMPDIR/hooks.log"
+  rm -f "$BATS_TMPDIR/hooks.log"
+}
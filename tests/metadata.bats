@@ -0,0 +1,98 @@
+#!/usr/bin/env bats
+# Tests for src/lib/metadata.sh
+#
+# Covers:
+#   - _aw_set_branch_metadata / _aw_get_branch_metadata (round trip, unset on empty)
+#   - _aw_record_issue_metadata (all issue fields stored under branch.<name>)
+#   - _aw_format_issue_metadata (provider-aware description, empty when unrecorded)
+#   - _aw_touch_last_accessed / _aw_get_access_count (open counter)
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+
+setup() {
+  gum() { return 0; }
+  export -f gum
+
+  _aw_get_issue_provider() { echo "github"; }
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/providers/common.sh
+  source "${REPO_ROOT}/src/providers/common.sh"
+  # shellcheck source=../src/lib/metadata.sh
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git branch "work/42-fix-login"
+}
+
+teardown() {
+  teardown_git_repo
+}
+
+@test "_aw_set_branch_metadata: value round-trips through _aw_get_branch_metadata" {
+  _aw_set_branch_metadata "work/42-fix-login" "issue-id" "42"
+  run _aw_get_branch_metadata "work/42-fix-login" "issue-id"
+  [ "$status" -eq 0 ]
+  [ "$output" = "42" ]
+}
+
+@test "_aw_set_branch_metadata: stores under branch.<name>.aw-<key>" {
+  _aw_set_branch_metadata "work/42-fix-login" "provider" "github"
+  run git config --get "branch.work/42-fix-login.aw-provider"
+  [ "$output" = "github" ]
+}
+
+@test "_aw_set_branch_metadata: empty value removes the key" {
+  _aw_set_branch_metadata "work/42-fix-login" "issue-url" "https://example.com/42"
+  _aw_set_branch_metadata "work/42-fix-login" "issue-url" ""
+  run _aw_get_branch_metadata "work/42-fix-login" "issue-url"
+  [ -z "$output" ]
+}
+
+@test "_aw_record_issue_metadata: records provider, id, title and url" {
+  _aw_record_issue_metadata "work/42-fix-login" "github" "42" "Fix login" "https://github.com/o/r/issues/42"
+  [ "$(_aw_get_branch_metadata "work/42-fix-login" "provider")" = "github" ]
+  [ "$(_aw_get_branch_metadata "work/42-fix-login" "issue-id")" = "42" ]
+  [ "$(_aw_get_branch_metadata "work/42-fix-login" "issue-title")" = "Fix login" ]
+  [ "$(_aw_get_branch_metadata "work/42-fix-login" "issue-url")" = "https://github.com/o/r/issues/42" ]
+}
+
+@test "_aw_format_issue_metadata: describes a GitHub issue" {
+  _aw_record_issue_metadata "work/42-fix-login" "github" "42" "Fix login"
+  run _aw_format_issue_metadata "work/42-fix-login"
+  [ "$output" = "GitHub #42: Fix login" ]
+}
+
+@test "_aw_format_issue_metadata: empty when no issue was recorded" {
+  run _aw_format_issue_metadata "work/42-fix-login"
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
+}
+
+@test "_aw_touch_last_accessed: counts each opening" {
+  [ "$(_aw_get_access_count "work/42-fix-login")" = "0" ]
+
+  _aw_touch_last_accessed "work/42-fix-login"
+  _aw_touch_last_accessed "work/42-fix-login"
+  [ "$(_aw_get_access_count "work/42-fix-login")" = "2" ]
+  [[ "$(git config branch.work/42-fix-login.aw-last-accessed)" =~ ^[0-9]+$ ]]
+}
+
+@test "_aw_get_access_count: treats a malformed count as never opened" {
+  git config branch.work/42-fix-login.aw-access-count "lots"
+  [ "$(_aw_get_access_count "work/42-fix-login")" = "0" ]
+
+  _aw_touch_last_accessed "work/42-fix-login"
+  [ "$(_aw_get_access_count "work/42-fix-login")" = "1" ]
+}
+
+@test "metadata is removed with the branch" {
+  _aw_record_issue_metadata "work/42-fix-login" "github" "42" "Fix login"
+  git branch -D "work/42-fix-login" >/dev/null
+  run _aw_get_branch_metadata "work/42-fix-login" "issue-id"
+  [ -z "$output" ]
+}
@@ -3,9 +3,21 @@
 #
 # Covers:
 #   - _aw_extract_issue_id_from_branch (all 4 providers + edge cases)
-#   - _aw_get_default_branch (main and master detection)
+#   - _aw_get_default_branch (override, origin/HEAD, gh, main/master and checked-out branch detection)
+#   - _aw_cache_default_branch (one lookup per repository for batch commands)
 #   - _aw_milestone_terminology
+#   - _aw_detect_issue_type, _aw_format_issue_ref, _aw_issue_branch_suffix
+#   - _aw_list_issues / _aw_get_issue_details dispatch (linear)
+#   - _aw_get_pr_provider, _aw_format_pr_ref, _aw_get_pr_details dispatch
 #   - _aw_format_labels
+#   - _aw_compact_issue_line / _aw_compact_issue_list (narrow selector rows)
+#   - _aw_label_chip_sgr / _aw_colorize_issue_labels / _aw_color_enabled (label colors)
+#   - _aw_label_colors_file / _aw_take_label_colors (colors saved by the list call)
+#   - _aw_get_issue_list_limit (default, configured, invalid values)
+#   - _aw_get_age_warn_days / _aw_get_age_stale_days (defaults, configured, invalid values)
+#   - _aw_detect_issue_provider / _aw_init_issue_provider (provider from the remote, one-time hint)
+#   - _aw_get_issue_provider (falls back to the detected provider)
+#   - _aw_issue_finished_state (per-provider wording, empty while open)
 
 REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
 
@@ -26,6 +38,8 @@ setup() {
   source "${REPO_ROOT}/src/providers/common.sh"
   # shellcheck source=../src/lib/config.sh
   source "${REPO_ROOT}/src/lib/config.sh"
+  # shellcheck source=../src/providers/github.sh
+  source "${REPO_ROOT}/src/providers/github.sh"
 
   # Set up an isolated git repo for tests that need one
   setup_git_repo
@@ -136,6 +150,131 @@ teardown() {
   [ -n "$output" ]
 }
 
+@test "_aw_get_default_branch: falls back to the checked-out branch in a local-only repo" {
+  cd "$TEST_REPO_DIR"
+  git branch -m trunk
+
+  run _aw_get_default_branch
+  [ "$status" -eq 0 ]
+  [ "$output" = "trunk" ]
+}
+
+@test "_aw_get_default_branch: auto-worktree.default-branch overrides detection" {
+  cd "$TEST_REPO_DIR"
+  git branch -m main
+  git branch develop
+  git config auto-worktree.default-branch develop
+
+  run _aw_get_default_branch
+  [ "$status" -eq 0 ]
+  [ "$output" = "develop" ]
+}
+
+_add_origin_with() {
+  # Give the test repo an origin whose remote-tracking branches are the
+  # given names, without origin/HEAD (as after `git remote add`)
+  # Defaults to a GitHub remote; set ORIGIN_URL for another host
+  git remote add origin "${ORIGIN_URL:-https://github.com/example/repo.git}"
+  local name
+  for name in "$@"; do
+    git update-ref "refs/remotes/origin/$name" HEAD
+  done
+}
+
+@test "_aw_get_default_branch: origin/HEAD comes first" {
+  cd "$TEST_REPO_DIR"
+  git branch -m main
+  _add_origin_with main trunk
+  git symbolic-ref refs/remotes/origin/HEAD refs/remotes/origin/trunk
+  gh() { echo "from-gh"; }
+
+  run _aw_get_default_branch
+  [ "$status" -eq 0 ]
+  [ "$output" = "trunk" ]
+}
+
+@test "_aw_get_default_branch: asks gh without origin/HEAD and leaves origin/HEAD alone" {
+  cd "$TEST_REPO_DIR"
+  git branch -m main
+  _add_origin_with main develop
+  gh() {
+    [[ "$*" == "auth status --hostname github.com" ]] && return 0
+    [[ "$*" == "repo view --json defaultBranchRef --jq .defaultBranchRef.name" ]] && echo "develop"
+  }
+
+  run _aw_get_default_branch
+  [ "$status" -eq 0 ]
+  [ "$output" = "develop" ]
+  run git symbolic-ref refs/remotes/origin/HEAD
+  [ "$status" -ne 0 ]
+}
+
+@test "_aw_get_default_branch: gh isn't asked when it isn't logged in" {
+  cd "$TEST_REPO_DIR"
+  git branch -m main
+  _add_origin_with main develop
+  gh() {
+    [[ "$1" == "auth" ]] && return 1
+    echo "should not be asked"
+  }
+
+  run _aw_get_default_branch
+  [ "$output" = "main" ]
+}
+
+@test "_aw_get_default_branch: gh isn't asked for a remote outside GitHub" {
+  cd "$TEST_REPO_DIR"
+  git branch -m main
+  ORIGIN_URL="https://gitlab.com/example/repo.git" _add_origin_with main develop
+  gh() { [[ "$1" == "auth" ]] && return 0; echo "should not be asked"; }
+
+  run _aw_get_default_branch
+  [ "$output" = "main" ]
+}
+
+@test "_aw_get_default_branch: falls back to main/master when gh can't tell" {
+  cd "$TEST_REPO_DIR"
+  git branch -m master
+  _add_origin_with master
+  gh() { return 1; }
+
+  run _aw_get_default_branch
+  [ "$status" -eq 0 ]
+  [ "$output" = "master" ]
+  run git symbolic-ref refs/remotes/origin/HEAD
+  [ "$status" -ne 0 ]
+}
+
+@test "_aw_get_default_branch: gh isn't asked without an origin remote" {
+  cd "$TEST_REPO_DIR"
+  git branch -m main
+  gh() { echo "should not be asked"; }
+
+  run _aw_get_default_branch
+  [ "$output" = "main" ]
+}
+
+@test "_aw_cache_default_branch: later lookups reuse the answer for the same repository" {
+  cd "$TEST_REPO_DIR"
+  git branch -m main
+  _AW_GIT_ROOT="$TEST_REPO_DIR"
+  _aw_cache_default_branch
+  [ "$_AW_DEFAULT_BRANCH_CACHE" = "main" ]
+
+  git config auto-worktree.default-branch develop
+  [ "$(_aw_get_default_branch)" = "main" ]
+  # Caching again for the same repository keeps the first answer
+  _aw_cache_default_branch
+  [ "$_AW_DEFAULT_BRANCH_CACHE" = "main" ]
+
+  # Another repository (or a new command) looks it up again
+  _AW_GIT_ROOT="/elsewhere"
+  [ "$(_aw_get_default_branch)" = "develop" ]
+  _AW_GIT_ROOT="$TEST_REPO_DIR"
+  _AW_DEFAULT_BRANCH_CACHE=""
+  [ "$(_aw_get_default_branch)" = "develop" ]
+}
+
 # ===== _aw_milestone_terminology =====
 
 @test "_aw_milestone_terminology: github returns Milestone" {
@@ -168,6 +307,86 @@ teardown() {
   [ "$output" = "Milestone" ]
 }
 
+# ===== Provider dispatch =====
+
+@test "_aw_detect_issue_type: KEY-123 follows configured linear provider" {
+  run _aw_detect_issue_type "ENG-123" "linear"
+  [ "$status" -eq 0 ]
+  [ "$output" = "linear" ]
+}
+
+@test "_aw_detect_issue_type: KEY-123 falls back to jira for github provider" {
+  run _aw_detect_issue_type "PROJ-1" "github"
+  [ "$status" -eq 0 ]
+  [ "$output" = "jira" ]
+}
+
+@test "_aw_detect_issue_type: number follows configured gitlab provider" {
+  run _aw_detect_issue_type "#42" "gitlab"
+  [ "$status" -eq 0 ]
+  [ "$output" = "gitlab" ]
+}
+
+@test "_aw_detect_issue_type: rejects malformed IDs" {
+  run _aw_detect_issue_type "not-an-issue" "linear"
+  [ "$status" -eq 1 ]
+}
+
+@test "_aw_format_issue_ref: linear keys are shown as-is, numbers get #" {
+  [ "$(_aw_format_issue_ref "ENG-7" "linear")" = "ENG-7" ]
+  [ "$(_aw_format_issue_ref "7" "github")" = "#7" ]
+}
+
+@test "_aw_issue_branch_suffix: linear uses the issue identifier" {
+  run _aw_issue_branch_suffix "ENG-7" "linear"
+  [ "$output" = "ENG-7" ]
+}
+
+@test "_aw_provider_supports_prs: linear and jira do not host PRs" {
+  run _aw_provider_supports_prs "linear"
+  [ "$status" -eq 1 ]
+  run _aw_provider_supports_prs "github"
+  [ "$status" -eq 0 ]
+}
+
+@test "_aw_list_issues: dispatches to the linear implementation" {
+  _aw_linear_list_issues() { echo "ENG-1 | From linear"; }
+  run _aw_list_issues "linear"
+  [ "$status" -eq 0 ]
+  [ "$output" = "ENG-1 | From linear" ]
+}
+
+@test "_aw_get_issue_details: sets title and body via the linear implementation" {
+  _aw_linear_get_issue_details() { title="Linear title"; body="Linear body"; }
+  local title="" body=""
+  _aw_get_issue_details "ENG-1" "linear"
+  [ "$title" = "Linear title" ]
+  [ "$body" = "Linear body" ]
+}
+
+@test "_aw_get_issue_details: returns 1 for unknown provider" {
+  run _aw_get_issue_details "ENG-1" "unknown"
+  [ "$status" -eq 1 ]
+}
+
+@test "_aw_get_pr_provider: jira and linear fall back to github" {
+  [ "$(_aw_get_pr_provider "jira")" = "github" ]
+  [ "$(_aw_get_pr_provider "linear")" = "github" ]
+  [ "$(_aw_get_pr_provider "gitlab")" = "gitlab" ]
+}
+
+@test "_aw_format_pr_ref: gitlab uses ! and github uses #" {
+  [ "$(_aw_format_pr_ref "12" "gitlab")" = "!12" ]
+  [ "$(_aw_format_pr_ref "12" "github")" = "#12" ]
+}
+
+@test "_aw_get_pr_details: dispatches to the gitlab MR implementation" {
+  _aw_gitlab_get_mr_details() { head_ref="from-gitlab"; }
+  local head_ref=""
+  _aw_get_pr_details "12" "gitlab"
+  [ "$head_ref" = "from-gitlab" ]
+}
+
 # ===== _aw_format_labels =====
 
 @test "_aw_format_labels: single label wrapped in brackets" {
@@ -200,6 +419,161 @@ teardown() {
   [ "$output" = "[bug][enhancement]" ]
 }
 
+# ===== _aw_compact_issue_line =====
+
+@test "_aw_compact_issue_line: caps labels and shortens the title to fit" {
+  local line="#12 | A very long issue title that keeps going and going and going on forever | [bug] [ui] [p1] [needs-triage] [help wanted]"
+  run _aw_compact_issue_line "$line" 76
+  [ "$output" = "#12 | A very long issue title that keeps going an… | [bug] [ui] [p1] +2 more" ]
+}
+
+@test "_aw_compact_issue_line: leaves rows that fit untouched" {
+  run _aw_compact_issue_line "● PROJ-4 | Short | [a][b]" 76
+  [ "$output" = "● PROJ-4 | Short | [a][b]" ]
+}
+
+@test "_aw_compact_issue_line: shortens titles without labels and keeps the ID" {
+  run _aw_compact_issue_line "ENG-9 | A very long linear title that keeps going and going and going" 40
+  [ "$output" = "ENG-9 | A very long linear title that k…" ]
+
+  run _aw_compact_issue_list $'PROJ-1 | [x] in the title is not a label | [bug][ui][p1][p2]\n#2 | Fine' 40
+  [ "${lines[0]}" = "PROJ-1 | [x] in th… | [bug] [ui] [p1] +1 more" ]
+  [ "${lines[1]}" = "#2 | Fine" ]
+}
+
+@test "_aw_label_chip_sgr: truecolor when advertised, else the nearest 256 color" {
+  COLORTERM=truecolor
+  [ "$(_aw_label_chip_sgr d73a4a)" = "48;2;215;58;74;97" ]
+  [ "$(_aw_label_chip_sgr "#a2eeef")" = "48;2;162;238;239;30" ]
+  COLORTERM=""
+  [ "$(_aw_label_chip_sgr d73a4a)" = "48;5;167;97" ]
+  [ "$(_aw_label_chip_sgr ffffff)" = "48;5;231;30" ]
+  run _aw_label_chip_sgr red
+  [ "$status" -eq 1 ]
+}
+
+@test "_aw_colorize_issue_labels: colors label chips only" {
+  COLORTERM=""
+  local colors=$'bug\td73a4a\nui\tffffff'
+  local esc=$'\033'
+  run _aw_colorize_issue_labels $'#1 | [x] crash | [bug] [docs] [ui]\n#2 | No labels' "$colors"
+  [ "${lines[0]}" = "#1 | [x] crash | ${esc}[48;5;167;97m[bug]${esc}[0m [docs] ${esc}[48;5;231;30m[ui]${esc}[0m" ]
+  [ "${lines[1]}" = "#2 | No labels" ]
+  [ "$(_aw_strip_ansi "${lines[0]}")" = "#1 | [x] crash | [bug] [docs] [ui]" ]
+}
+
+@test "_aw_colorize_issue_labels: keeps rows as they are without colors" {
+  run _aw_colorize_issue_labels $'#1 | Crash | [bug]' ""
+  [ "$output" = "#1 | Crash | [bug]" ]
+}
+
+@test "_aw_label_colors_file: only for GitHub and Linear labels on a color terminal" {
+  _aw_color_enabled() { return 0; }
+  [ -z "$(_aw_label_colors_file jira)" ]
+
+  local colors_file=$(_aw_label_colors_file github)
+  [ -f "$colors_file" ]
+  printf 'bug\td73a4a\n' > "$colors_file"
+  _AW_LABEL_COLORS_FILE="$colors_file"
+  [ "$(_aw_take_label_colors)" = $'bug\td73a4a' ]
+  [ ! -f "$colors_file" ]
+
+  git config auto-worktree.issue-list-labels false
+  [ -z "$(_aw_label_colors_file linear)" ]
+  _aw_color_enabled() { return 1; }
+  git config auto-worktree.issue-list-labels true
+  [ -z "$(_aw_label_colors_file github)" ]
+}
+
+@test "_aw_color_enabled: off with NO_COLOR or a dumb terminal" {
+  NO_COLOR=1 run _aw_color_enabled
+  [ "$status" -eq 1 ]
+  TERM=dumb run _aw_color_enabled
+  [ "$status" -eq 1 ]
+}
+
+@test "_aw_get_issue_list_limit: defaults to 100 and ignores invalid values" {
+  [ "$(_aw_get_issue_list_limit)" = "100" ]
+  git config auto-worktree.issue-list-limit 30
+  [ "$(_aw_get_issue_list_limit)" = "30" ]
+  git config auto-worktree.issue-list-limit many
+  [ "$(_aw_get_issue_list_limit)" = "100" ]
+  git config auto-worktree.issue-list-limit 0
+  [ "$(_aw_get_issue_list_limit)" = "100" ]
+}
+
+@test "_aw_get_age_warn_days / _aw_get_age_stale_days: default to 1 and 4, ignore invalid values" {
+  [ "$(_aw_get_age_warn_days)" = "1" ]
+  [ "$(_aw_get_age_stale_days)" = "4" ]
+  git config auto-worktree.age-warn-days 3
+  git config auto-worktree.age-stale-days 07
+  [ "$(_aw_get_age_warn_days)" = "3" ]
+  [ "$(_aw_get_age_stale_days)" = "7" ]
+  git config auto-worktree.age-warn-days 0
+  git config auto-worktree.age-stale-days week
+  [ "$(_aw_get_age_warn_days)" = "1" ]
+  [ "$(_aw_get_age_stale_days)" = "4" ]
+}
+
+@test "_aw_detect_issue_provider: recognizes GitHub and GitLab remotes" {
+  run _aw_detect_issue_provider
+  [ "$status" -eq 1 ]
+
+  git remote add origin https://github.com/acme/app.git
+  [ "$(_aw_detect_issue_provider)" = "github" ]
+  git remote set-url origin git@gitlab.com:acme/app.git
+  [ "$(_aw_detect_issue_provider)" = "gitlab" ]
+  git remote set-url origin ssh://git@gitlab.example.com:2222/acme/app.git
+  [ "$(_aw_detect_issue_provider)" = "gitlab" ]
+  git remote set-url origin https://code.example.com/acme/app.git
+  run _aw_detect_issue_provider
+  [ "$status" -eq 1 ]
+  git config auto-worktree.gitlab-server https://code.example.com
+  [ "$(_aw_detect_issue_provider)" = "gitlab" ]
+  git remote set-url origin git@ghe.example.com:acme/app.git
+  git config auto-worktree.github-host ghe.example.com
+  [ "$(_aw_detect_issue_provider)" = "github" ]
+}
+
+@test "_aw_init_issue_provider: uses the detected provider and hints once" {
+  gum() { [[ "$1" == "style" ]] && echo "${@: -1}"; return 0; }
+  _aw_require_provider() { return 0; }
+  _aw_prompt_issue_provider() { echo "prompted"; return 1; }
+  git remote add origin git@github.com:acme/app.git
+
+  run _aw_init_issue_provider
+  [ "$status" -eq 0 ]
+  [ "${lines[0]}" = "Using GitHub issues, detected from the remote. To keep it, run: git config auto-worktree.issue-provider github" ]
+  [ "${lines[1]}" = "github" ]
+  [ -z "$(git config auto-worktree.issue-provider)" ]
+
+  run _aw_init_issue_provider
+  [ "$output" = "github" ]
+
+  # A configured provider wins over the remote
+  git config auto-worktree.issue-provider jira
+  run _aw_init_issue_provider
+  [ "$output" = "jira" ]
+
+  # Nothing to detect: fall back to the prompt
+  git config --unset auto-worktree.issue-provider
+  git remote remove origin
+  run _aw_init_issue_provider
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"prompted"* ]]
+}
+
+@test "_aw_get_issue_provider: falls back to the provider detected from the remote" {
+  [ -z "$(_aw_get_issue_provider)" ]
+
+  git remote add origin git@gitlab.com:acme/app.git
+  [ "$(_aw_get_issue_provider)" = "gitlab" ]
+  [ -z "$(_aw_get_configured_issue_provider)" ]
+
+  git config auto-worktree.issue-provider linear
+  [ "$(_aw_get_issue_provider)" = "linear" ]
+}
+
 # ===== _aw_get_config / _aw_set_config / _aw_unset_config =====
 
 @test "_aw_get_config: returns empty string for unset key" {
@@ -252,3 +626,26 @@ teardown() {
   run _aw_unset_config "never-existed-key-abc"
   [ "$status" -eq 0 ]
 }
+
+@test "_aw_issue_finished_state: distinguishes merged and closed GitHub issues" {
+  _aw_check_issue_merged() { [[ "$1" == "1" ]]; }
+  _aw_check_issue_closed() { [[ "$1" == "1" || "$1" == "2" ]]; }
+
+  [ "$(_aw_issue_finished_state 1 github)" = "merged" ]
+  [ "$(_aw_issue_finished_state 2 github)" = "closed" ]
+  run _aw_issue_finished_state 3 github
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
+}
+
+@test "_aw_issue_finished_state: uses each tracker's wording" {
+  _aw_gitlab_check_closed() { return 0; }
+  _aw_jira_check_resolved() { return 0; }
+  _aw_linear_check_completed() { return 1; }
+
+  [ "$(_aw_issue_finished_state 7 gitlab)" = "closed" ]
+  [ "$(_aw_issue_finished_state PROJ-7 jira)" = "resolved" ]
+  run _aw_issue_finished_state ENG-7 linear
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
+}
@@ -4,10 +4,13 @@
 REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
 
 load 'helpers/mock_cli'
+load 'helpers/setup_git_repo'
 
 setup() {
   setup_mock_cli
 
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
   # Source the provider under test
   # shellcheck source=../src/providers/github.sh
   source "${REPO_ROOT}/src/providers/github.sh"
@@ -49,6 +52,98 @@ teardown() {
   [[ "$output" == *"Simple issue"* ]]
 }
 
+@test "_aw_github_list_issues: requests labels by default" {
+  mock_cli gh "" ''
+
+  run _aw_github_list_issues
+  assert_cli_called gh "issue list --limit 100 --state open --json number,title,labels --template"
+}
+
+@test "_aw_github_list_issues: takes label colors from the issue list JSON" {
+  mock_cli gh "" '[{"number":42,"title":"Fix bug","labels":[{"name":"bug","color":"d73a4a"},{"name":"ui","color":"ffffff"}]},{"number":7,"title":"Plain","labels":[]}]'
+  local colors_file="$BATS_TEST_TMPDIR/label-colors"
+
+  _AW_LABEL_COLORS_FILE="$colors_file" run _aw_github_list_issues
+  [ "$status" -eq 0 ]
+  [ "${lines[0]}" = "#42 | Fix bug | [bug] [ui]" ]
+  [ "${lines[1]}" = "#7 | Plain" ]
+  [ "$(cat "$colors_file")" = $'bug\td73a4a\nui\tffffff' ]
+  # One gh call, with no separate label listing
+  [ "$(wc -l < "$MOCK_BIN_DIR/gh.calls")" -eq 1 ]
+  ! grep -q "label list" "$MOCK_BIN_DIR/gh.calls"
+}
+
+@test "_aw_github_list_issues: fetches auto-worktree.issue-list-limit issues" {
+  # The limit is read with git config, so this needs a repository to set it in
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git config auto-worktree.issue-list-limit 25
+  mock_cli gh "" ''
+
+  run _aw_github_list_issues
+  assert_cli_called gh "issue list --limit 25 --state open"
+
+  run _aw_github_list_issues_by_milestone "v1.0"
+  assert_cli_called gh "issue list --milestone v1.0 --limit 25 --state open"
+  teardown_git_repo
+}
+
+@test "_aw_github_list_prs: fetches auto-worktree.issue-list-limit PRs" {
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git config auto-worktree.issue-list-limit 25
+  mock_cli gh "" '[]'
+
+  run _aw_github_list_prs
+  assert_cli_called gh "pr list --limit 25 --state open"
+  teardown_git_repo
+}
+
+@test "_aw_github_list_issues: includes and marks closed issues when asked" {
+  mock_cli gh "" ''
+
+  _AW_ISSUE_INCLUDE_CLOSED=true run _aw_github_list_issues
+  assert_cli_called gh "issue list --limit 100 --state all --json number,title,labels,state"
+  grep -qF '{{if eq .state "CLOSED"}} (closed){{end}}' "$MOCK_BIN_DIR/gh.calls"
+}
+
+@test "_aw_github_list_prs: passes --author and leaves out --not-author via search" {
+  mock_cli gh "" '[]'
+
+  run _aw_github_list_prs "alice" "@me"
+  assert_cli_called gh "pr list --limit 100 --state open --author alice --search -author:@me"
+}
+
+@test "_aw_github_list_issues: leaves labels out when issue-list-labels is false" {
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git config auto-worktree.issue-list-labels false
+  mock_cli gh "" '#7 | Simple issue'
+
+  run _aw_github_list_issues
+  [ "$status" -eq 0 ]
+  assert_cli_called gh "open --json number,title --template"
+  teardown_git_repo
+}
+
+@test "_aw_github_issue_list_fields: only number and title when issue-list-labels is false" {
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  [ "$(_aw_github_issue_list_fields)" = "number,title,labels" ]
+
+  git config auto-worktree.issue-list-labels false
+  [ "$(_aw_github_issue_list_fields)" = "number,title" ]
+
+  # The milestone picker requests the same trimmed field list
+  mock_cli gh "" ''
+  run _aw_github_list_issues_by_milestone "v1.0"
+  assert_cli_called gh "issue list --milestone v1.0 --limit 100 --state open --json number,title --template"
+
+  git config auto-worktree.issue-list-labels true
+  [ "$(_aw_github_issue_list_fields)" = "number,title,labels" ]
+  teardown_git_repo
+}
+
 @test "_aw_github_list_issues: empty output when gh returns nothing" {
   mock_cli gh "" ''
 
@@ -203,20 +298,18 @@ EOF
 # Edge cases: gh command failure
 # ============================================================================
 
-@test "_aw_github_list_issues: succeeds even when gh exits non-zero (2>/dev/null)" {
-  # The function redirects stderr and relies on output; a failing gh produces
-  # empty output, which is acceptable (exit 0 from the function itself).
+@test "_aw_github_list_issues: reports gh failures instead of an empty list" {
+  # An empty list means no open issues, so a failing gh must not look like one
   cat > "$MOCK_BIN_DIR/gh" <<'EOF'
 #!/usr/bin/env bash
+echo "HTTP 502: Bad Gateway" >&2
 exit 1
 EOF
   chmod +x "$MOCK_BIN_DIR/gh"
 
   run _aw_github_list_issues
-  # Function itself should not propagate gh's non-zero due to 2>/dev/null + pipeline
-  # The template output will be empty but status is 0 (pipeline)
-  [ "$status" -eq 0 ]
-  [ -z "$output" ]
+  [ "$status" -eq 1 ]
+  [ "$output" = "HTTP 502: Bad Gateway" ]
 }
 
 @test "_aw_github_get_issue_details: returns 1 when gh fails" {
@@ -229,3 +322,422 @@ EOF
   run _aw_github_get_issue_details "42"
   [ "$status" -eq 1 ]
 }
+
+# ============================================================================
+# _aw_github_list_prs / _aw_github_get_pr_details
+# ============================================================================
+
+@test "_aw_github_list_labels: prints label names" {
+  mock_cli gh "label list" "bug"
+  run _aw_github_list_labels
+  [ "$status" -eq 0 ]
+  [ "$output" = "bug" ]
+  assert_cli_called gh "label list --limit 500 --json name"
+}
+
+@test "_aw_github_list_prs: formats PRs with checks, author and head branch" {
+  mock_cli gh "pr list" '[{"number":7,"title":"Add thing","author":{"login":"octo"},"headRefName":"feat/thing","baseRefName":"main","labels":[],"statusCheckRollup":[{"state":"SUCCESS"}],"reviews":[],"additions":3,"deletions":1,"reviewRequests":[]}]'
+  run _aw_github_list_prs
+  [ "$status" -eq 0 ]
+  [ "$output" = "#7 | ✓ | Add thing | @octo | +3/-1 | reviews:0 | feat/thing" ]
+}
+
+@test "_aw_github_get_pr_details: sets title, head_ref, base_ref and author" {
+  mock_cli gh "pr view" '{"number":7,"title":"Add thing","body":"Details","headRefName":"feat/thing","baseRefName":"main","author":{"login":"octo"}}'
+  local title="" body="" head_ref="" base_ref="" author=""
+  _aw_github_get_pr_details "#7"
+  [ "$title" = "Add thing" ]
+  [ "$body" = "Details" ]
+  [ "$head_ref" = "feat/thing" ]
+  [ "$base_ref" = "main" ]
+  [ "$author" = "octo" ]
+  assert_cli_called gh "pr view 7"
+}
+
+@test "_aw_github_get_pr_details: flags PRs from forks" {
+  mock_cli gh "pr view" '{"number":8,"title":"Fix","body":"","headRefName":"main","baseRefName":"main","author":{"login":"contrib"},"isCrossRepository":true}'
+  local title="" body="" head_ref="" base_ref="" author="" is_fork=""
+  _aw_github_get_pr_details "8"
+  [ "$is_fork" = "true" ]
+
+  mock_cli gh "pr view" '{"number":7,"title":"Add thing","body":"","headRefName":"feat/thing","baseRefName":"main","author":{"login":"octo"},"isCrossRepository":false}'
+  _aw_github_get_pr_details "7"
+  [ "$is_fork" = "false" ]
+}
+
+@test "_aw_github_get_pr_details: returns 1 when gh returns empty output" {
+  mock_cli gh "pr view" ""
+  run _aw_github_get_pr_details "7"
+  [ "$status" -eq 1 ]
+}
+
+@test "_aw_github_get_pr_checks: counts passed, failed and pending checks" {
+  mock_cli gh "pr checks" '[{"name":"build","state":"SUCCESS","bucket":"pass"},{"name":"lint","state":"SKIPPED","bucket":"skipping"},{"name":"test","state":"FAILURE","bucket":"fail"},{"name":"e2e","state":"IN_PROGRESS","bucket":"pending"}]'
+  run _aw_github_get_pr_checks "#7"
+  [ "$status" -eq 0 ]
+  [ "$output" = "2 1 1" ]
+  assert_cli_called gh "pr checks 7 --json name,state,bucket"
+}
+
+@test "_aw_github_get_pr_checks: returns 1 when gh returns empty output" {
+  mock_cli gh "pr checks" ""
+  run _aw_github_get_pr_checks "7"
+  [ "$status" -eq 1 ]
+}
+
+# ============================================================================
+# _aw_gh (stdout/stderr separation)
+# ============================================================================
+
+@test "_aw_gh: returns only stdout when gh also writes warnings" {
+  cat > "$MOCK_BIN_DIR/gh" <<'EOF'
+#!/usr/bin/env bash
+echo "Warning: 2 uncommitted changes" >&2
+echo '{"number":7}'
+EOF
+  chmod +x "$MOCK_BIN_DIR/gh"
+
+  local out
+  out=$(_aw_gh pr view 7 --json number 2>/dev/null)
+  [ "$out" = '{"number":7}' ]
+  [ "$(echo "$out" | jq -r .number)" = "7" ]
+}
+
+@test "_aw_gh: reports only gh's error message and keeps its exit status" {
+  cat > "$MOCK_BIN_DIR/gh" <<'EOF'
+#!/usr/bin/env bash
+echo '{"partial":'
+echo "" >&2
+echo "GraphQL: Could not resolve to a PullRequest with the number of 99." >&2
+exit 3
+EOF
+  chmod +x "$MOCK_BIN_DIR/gh"
+
+  local out status=0
+  out=$(_aw_gh pr view 99 2>"$BATS_TEST_TMPDIR/err") || status=$?
+  [ "$status" -eq 3 ]
+  [ -z "$out" ]
+  [ "$(cat "$BATS_TEST_TMPDIR/err")" = "GraphQL: Could not resolve to a PullRequest with the number of 99." ]
+}
+
+@test "_aw_github_create_pr: prints gh's error, not its output, on failure" {
+  cat > "$MOCK_BIN_DIR/gh" <<'EOF'
+#!/usr/bin/env bash
+echo "Creating pull request for feature into main"
+echo "pull request create failed: a pull request already exists" >&2
+exit 1
+EOF
+  chmod +x "$MOCK_BIN_DIR/gh"
+
+  local out status=0
+  out=$(_aw_github_create_pr false 2>"$BATS_TEST_TMPDIR/err") || status=$?
+  [ "$status" -eq 1 ]
+  [ -z "$out" ]
+  grep -q "a pull request already exists" "$BATS_TEST_TMPDIR/err"
+  [[ "$(cat "$BATS_TEST_TMPDIR/err")" != *"Creating pull request"* ]]
+}
+
+# ============================================================================
+# _aw_github_create_pr
+# ============================================================================
+
+@test "_aw_github_create_pr: passes --draft and prints the PR URL" {
+  mock_cli gh "pr create" 'https://github.com/o/r/pull/9'
+
+  run _aw_github_create_pr true
+  [ "$status" -eq 0 ]
+  [ "$output" = "https://github.com/o/r/pull/9" ]
+  assert_cli_called gh "pr create --fill --draft"
+}
+
+@test "_aw_github_create_pr: omits --draft by default" {
+  mock_cli gh "pr create" 'https://github.com/o/r/pull/10'
+
+  run _aw_github_create_pr false
+  [ "$status" -eq 0 ]
+  grep -qx "pr create --fill" "$MOCK_BIN_DIR/gh.calls"
+}
+
+@test "_aw_github_create_pr: requests the given reviewers" {
+  mock_cli gh "pr create" 'https://github.com/o/r/pull/11'
+
+  run _aw_github_create_pr false alice my-org/web
+  [ "$status" -eq 0 ]
+  grep -qx "pr create --fill --reviewer alice --reviewer my-org/web" "$MOCK_BIN_DIR/gh.calls"
+}
+
+# ============================================================================
+# CODEOWNERS reviewer suggestions
+# ============================================================================
+
+_codeowners_match() {
+  printf '%s\n' "$2" | grep -Eq -- "$(_aw_codeowners_pattern_regex "$1")"
+}
+
+@test "_aw_codeowners_pattern_regex: follows gitignore-style matching" {
+  _codeowners_match "*" "src/main.sh"
+  _codeowners_match "*.js" "web/app/index.js"
+  ! _codeowners_match "*.js" "web/app/index.jsx"
+  _codeowners_match "/docs/" "docs/guide/intro.md"
+  ! _codeowners_match "/docs/" "src/docs/intro.md"
+  _codeowners_match "apps/" "packages/apps/main.go"
+  _codeowners_match "src/lib" "src/lib/utils.sh"
+  ! _codeowners_match "src/lib" "vendor/src/lib/utils.sh"
+  _codeowners_match "docs/*" "docs/intro.md"
+  ! _codeowners_match "docs/*" "docs/guide/intro.md"
+  _codeowners_match "**/logs" "deploy/app/logs/today.log"
+  _codeowners_match "src/**/test.sh" "src/a/b/test.sh"
+  _codeowners_match "src/**/test.sh" "src/test.sh"
+  ! _codeowners_match "README.md" "README-md"
+}
+
+@test "_aw_github_codeowners_reviewers: owners of the changed files, last rule winning" {
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git branch -m main
+  mkdir -p .github src docs
+  cat > .github/CODEOWNERS <<'OWNERS'
+# Default owners
+*           @core-lead
+/src/       @alice @my-org/backend
+*.md        @writer docs@example.com
+/src/vendor/
+OWNERS
+  git add .github && git commit -q -m "codeowners"
+
+  git checkout -q -b feature/change
+  echo "x" > src/main.sh
+  echo "x" > docs/intro.md
+  mkdir -p src/vendor && echo "x" > src/vendor/lib.sh
+  git add . && git commit -q -m "change"
+
+  mock_cli gh "api user" 'alice'
+
+  run _aw_github_codeowners_reviewers main
+  [ "$status" -eq 0 ]
+  # alice is the current user and docs@example.com can't be requested;
+  # src/vendor/ has its ownership removed
+  [ "$output" = "writer
+my-org/backend" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_github_codeowners_reviewers: the last rule is checked, whether or not it is the catch-all" {
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git branch -m main
+  mkdir -p .github docs src
+  echo "*  @my-org/team" > .github/CODEOWNERS
+  git add .github && git commit -q -m "codeowners"
+
+  git checkout -q -b feature/change
+  echo "x" > src/main.sh
+  git add . && git commit -q -m "change"
+  mock_cli gh "api user" 'someone'
+
+  # A single catch-all rule
+  run _aw_github_codeowners_reviewers main
+  [ "$status" -eq 0 ]
+  [ "$output" = "my-org/team" ]
+
+  # The catch-all comes last, so it wins over /src/
+  printf '/src/  @alice\n*      @core-lead\n' > .github/CODEOWNERS
+  run _aw_github_codeowners_reviewers main
+  [ "$output" = "core-lead" ]
+
+  # /docs/ comes after the catch-all, so it wins for docs/
+  printf '*       @core-lead\n/docs/  @writer\n' > .github/CODEOWNERS
+  echo "x" > docs/intro.md
+  git add . && git commit -q -m "docs"
+  run _aw_github_codeowners_reviewers main
+  [ "$output" = "core-lead
+writer" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_github_codeowners_reviewers: returns 1 without a CODEOWNERS file" {
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+
+  run _aw_github_codeowners_reviewers main
+  [ "$status" -eq 1 ]
+
+  teardown_git_repo
+}
+
+# ============================================================================
+# _aw_github_repo_slug
+# ============================================================================
+
+@test "_aw_github_repo_slug: caches the result until the remote URL changes" {
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  # Not a GitHub remote, so gh has to be asked
+  git remote add origin https://git.example.com/octo/one.git
+
+  mock_cli gh "repo view" 'octo/one'
+  run _aw_github_repo_slug
+  [ "$output" = "octo/one" ]
+
+  # Served from the cache: gh isn't asked again
+  mock_cli gh "repo view" 'octo/stale'
+  run _aw_github_repo_slug
+  [ "$output" = "octo/one" ]
+  [ "$(wc -l < "$MOCK_BIN_DIR/gh.calls")" -eq 1 ]
+
+  AW_NO_CACHE=1 run _aw_github_repo_slug
+  [ "$output" = "octo/stale" ]
+
+  git remote set-url origin https://git.example.com/octo/two.git
+  mock_cli gh "repo view" 'octo/two'
+  run _aw_github_repo_slug
+  [ "$output" = "octo/two" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_github_repo_slug: expired cache entries are refreshed" {
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+
+  printf '%s\t%s\t%s\n' "" "100" "octo/old" > .git/auto-worktree-github-repo
+  mock_cli gh "repo view" 'octo/new'
+  run _aw_github_repo_slug
+  [ "$output" = "octo/new" ]
+  assert_cli_called gh "repo view --json owner,name"
+
+  teardown_git_repo
+}
+
+@test "_aw_github_repo_slug: returns 1 when gh can't identify the repository" {
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+
+  mock_cli gh "repo view" ''
+  run _aw_github_repo_slug
+  [ "$status" -eq 1 ]
+
+  teardown_git_repo
+}
+
+@test "_aw_github_repo_slug: reads owner/repo from a GitHub remote without calling gh" {
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git remote add origin git@github.com:octo/from-remote.git
+
+  mock_cli gh "repo view" 'octo/unused'
+  run _aw_github_repo_slug
+  [ "$output" = "octo/from-remote" ]
+  [ ! -f "$MOCK_BIN_DIR/gh.calls" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_github_repo_slug: prefers the remote in auto-worktree.github-remote" {
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git remote add origin git@github.com:me/project.git
+  git remote add upstream https://github.com/octo/project.git
+
+  run _aw_github_repo_slug
+  [ "$output" = "me/project" ]
+
+  git config auto-worktree.github-remote upstream
+  run _aw_github_repo_slug
+  [ "$output" = "octo/project" ]
+
+  # A preferred remote that doesn't exist falls back to origin
+  git config auto-worktree.github-remote missing
+  run _aw_github_repo_slug
+  [ "$output" = "me/project" ]
+
+  teardown_git_repo
+}
+
+@test "_aw_github_remote_url: falls back to the first remote without origin" {
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git remote add fork git@github.com:me/project.git
+
+  run _aw_github_remote_url
+  [ "$output" = "git@github.com:me/project.git" ]
+
+  git remote remove fork
+  run _aw_github_remote_url
+  [ "$status" -eq 1 ]
+
+  teardown_git_repo
+}
+
+@test "_aw_github_list_issues: lists issues from the auto-worktree.github-remote repository" {
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git remote add origin git@github.com:me/project.git
+  git remote add upstream https://github.com/octo/project.git
+  mock_cli gh "" ''
+
+  run _aw_github_list_issues
+  [[ "$(cat "$MOCK_BIN_DIR/gh.calls")" != *"--repo"* ]]
+
+  git config auto-worktree.github-remote upstream
+  run _aw_github_list_issues
+  assert_cli_called gh "issue list --repo github.com/octo/project --limit 100"
+
+  teardown_git_repo
+}
+
+# ============================================================================
+# _aw_github_parse_remote
+# ============================================================================
+
+@test "_aw_github_parse_remote: parses github.com HTTPS and SSH remotes" {
+  run _aw_github_parse_remote "https://github.com/octo/repo.git"
+  [ "$output" = "github.com octo/repo" ]
+  run _aw_github_parse_remote "git@github.com:octo/repo.git"
+  [ "$output" = "github.com octo/repo" ]
+  run _aw_github_parse_remote "ssh://git@github.com/octo/repo"
+  [ "$output" = "github.com octo/repo" ]
+}
+
+@test "_aw_github_parse_remote: uses auto-worktree.github-host for enterprise hosts" {
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+  git config auto-worktree.github-host "https://ghe.example.com/"
+
+  run _aw_github_parse_remote "https://ghe.example.com/team/service"
+  [ "$output" = "ghe.example.com team/service" ]
+  run _aw_github_parse_remote "git@ghe.example.com:team/service.git"
+  [ "$output" = "ghe.example.com team/service" ]
+
+  # Other hosts, look-alike hosts and malformed paths don't match
+  run _aw_github_parse_remote "https://github.com/octo/repo.git"
+  [ "$status" -eq 1 ]
+  run _aw_github_parse_remote "https://ghe-example.com/team/service"
+  [ "$status" -eq 1 ]
+  run _aw_github_parse_remote "https://ghe.example.com/team"
+  [ "$status" -eq 1 ]
+
+  teardown_git_repo
+}
+
+# ============================================================================
+# _aw_github_list_milestones
+# ============================================================================
+
+@test "_aw_github_list_milestones: filters open milestones in the request" {
+  _aw_github_repo_slug() { echo "octo/project"; }
+  mock_cli gh "" $'3\tv1.0\t4\t2\t2026-11-01T07:00:00Z\n5\tBacklog\t0\t0\t'
+
+  run _aw_github_list_milestones
+  [ "$status" -eq 0 ]
+  [ "${lines[0]}" = "3 | v1.0 | [4 open] [2 closed] [due: 2026-11-01]" ]
+  [ "${lines[1]}" = "5 | Backlog" ]
+  assert_cli_called gh "repos/octo/project/milestones?state=open&per_page=100 --jq .[:100][] | select(.state == \"open\")"
+}
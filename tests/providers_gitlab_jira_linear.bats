@@ -5,9 +5,18 @@
 #   - _aw_gitlab_cmd (no server / server configured)
 #   - _aw_gitlab_check_closed (closed / open / empty state)
 #   - _aw_gitlab_check_mr_merged (merged / open MR)
+#   - _aw_gitlab_list_mrs / _aw_gitlab_get_mr_details (shared PR shape)
+#   - _aw_gitlab_create_mr (--draft, URL extraction)
+#   - _aw_gitlab_list_issues (closed issues listed and marked with --include-closed)
 #   - _aw_format_labels (via common.sh, exercised in GitLab/JIRA context)
 #   - _aw_jira_check_resolved (resolved / open / empty status)
-#   - _aw_linear_list_milestones / _aw_linear_list_issues_by_milestone (unsupported)
+#   - _aw_jira_list_issues (default JQL, auto-worktree.jira-jql, --jql, ORDER BY, rejected JQL)
+#   - _aw_linear_list_milestones (project listing, team filter, missing API key)
+#   - _aw_linear_list_issues_by_milestone (project issues, missing argument)
+#   - auto-worktree.issue-list-limit passed to glab and the Linear API
+#   - _aw_retry_cli (transient errors retried with backoff, others fail fast, invalid delay)
+#   - _aw_linear_api (HTTP 5xx retried, GraphQL errors on an HTTP error status,
+#     HTTP status and curl errors reported)
 
 REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
 
@@ -19,7 +28,9 @@ setup() {
   _aw_get_gitlab_server()  { git config --get auto-worktree.gitlab-server 2>/dev/null || echo ""; }
   _aw_get_gitlab_project() { git config --get auto-worktree.gitlab-project 2>/dev/null || echo ""; }
   _aw_get_jira_project()   { git config --get auto-worktree.jira-project   2>/dev/null || echo ""; }
+  _aw_get_jira_jql()       { git config --get auto-worktree.jira-jql       2>/dev/null || echo ""; }
   _aw_get_linear_team()    { git config --get auto-worktree.linear-team    2>/dev/null || echo ""; }
+  _aw_get_issue_list_limit() { git config --get auto-worktree.issue-list-limit 2>/dev/null || echo 100; }
   _aw_get_issue_provider() { echo ""; }
 
   # Source common utilities and provider implementations
@@ -60,6 +71,34 @@ teardown() {
   [ "$output" = "glab --host gitlab.example.com" ]
 }
 
+# ============================================================================
+# _aw_gitlab_list_mrs / _aw_gitlab_get_mr_details
+# ============================================================================
+
+@test "_aw_gitlab_list_mrs: formats MRs like GitHub PRs" {
+  cd "$TEST_REPO_DIR"
+  cat > "$MOCK_BIN_DIR/glab" <<'MOCK'
+#!/usr/bin/env bash
+printf '!12\tFix pipeline\t(fix/pipeline)\t(about 1 day ago)\n'
+MOCK
+  chmod +x "$MOCK_BIN_DIR/glab"
+  run _aw_gitlab_list_mrs
+  [ "$status" -eq 0 ]
+  [ "$output" = "#12 | ○ | Fix pipeline | fix/pipeline" ]
+}
+
+@test "_aw_gitlab_get_mr_details: sets head_ref, base_ref and author" {
+  cd "$TEST_REPO_DIR"
+  mock_cli glab "mr view" '{"title":"Fix pipeline","description":"","sourceBranch":"fix/pipeline","targetBranch":"main","author":{"username":"dev"}}'
+  local title="" body="" head_ref="" base_ref="" author=""
+  _aw_gitlab_get_mr_details "!12"
+  [ "$title" = "Fix pipeline" ]
+  [ "$head_ref" = "fix/pipeline" ]
+  [ "$base_ref" = "main" ]
+  [ "$author" = "dev" ]
+  assert_cli_called glab "mr view 12"
+}
+
 # ============================================================================
 # _aw_gitlab_check_closed
 # ============================================================================
@@ -110,6 +149,18 @@ teardown() {
 # _aw_gitlab_check_mr_merged
 # ============================================================================
 
+@test "_aw_gitlab_create_mr: passes --draft and prints the MR URL" {
+  mock_cli glab "mr create" 'Creating draft merge request for feature into main in group/project
+
+!12 Add feature (feature)
+ https://gitlab.com/group/project/-/merge_requests/12'
+
+  run _aw_gitlab_create_mr true
+  [ "$status" -eq 0 ]
+  [ "$output" = "https://gitlab.com/group/project/-/merge_requests/12" ]
+  assert_cli_called glab "mr create --fill --yes --draft"
+}
+
 @test "_aw_gitlab_check_mr_merged: returns 1 for empty branch name" {
   run _aw_gitlab_check_mr_merged ""
   [ "$status" -eq 1 ]
@@ -167,6 +218,25 @@ teardown() {
   [ "$output" = "[bug][enhancement]" ]
 }
 
+# ============================================================================
+# _aw_gitlab_list_issues
+# ============================================================================
+
+@test "_aw_gitlab_list_issues: lists closed issues after open ones with --include-closed" {
+  cd "$TEST_REPO_DIR"
+  mock_cli glab "issue list" $'#5\tFix login\t(bug)\t(2 days ago)'
+
+  run _aw_gitlab_list_issues
+  [ "$output" = "#5 | Fix login | [bug]" ]
+  assert_cli_called glab "issue list --state opened"
+  ! grep -q -- "--state closed" "$MOCK_BIN_DIR/glab.calls"
+
+  _AW_ISSUE_INCLUDE_CLOSED=true run _aw_gitlab_list_issues
+  [ "${lines[0]}" = "#5 | Fix login | [bug]" ]
+  [ "${lines[1]}" = "#5 | Fix login (closed) | [bug]" ]
+  assert_cli_called glab "issue list --state closed"
+}
+
 # ============================================================================
 # _aw_jira_check_resolved
 # ============================================================================
@@ -228,26 +298,158 @@ teardown() {
 }
 
 # ============================================================================
-# Linear: unsupported functions return 1
+# _aw_jira_list_issues
 # ============================================================================
 
-@test "_aw_linear_list_milestones: returns 1 (unsupported)" {
-  run _aw_linear_list_milestones
+@test "_aw_jira_list_issues: custom JQL replaces the default and keeps the project filter" {
+  cd "$TEST_REPO_DIR"
+  mock_cli jira "issue list" $'PROJ-1\tFix login\t∅'
+  git config auto-worktree.jira-project PROJ
+
+  run _aw_jira_list_issues
+  [ "$status" -eq 0 ]
+  [ "$output" = "PROJ-1 | Fix login" ]
+  assert_cli_called jira "project = PROJ AND (status != Done"
+
+  git config auto-worktree.jira-jql "sprint in openSprints()"
+  run _aw_jira_list_issues
+  assert_cli_called jira "project = PROJ AND (sprint in openSprints())"
+
+  # --jql on the issue command wins over the config
+  _AW_JIRA_JQL='status = "In Review"' run _aw_jira_list_issues
+  assert_cli_called jira 'project = PROJ AND (status = "In Review")'
+}
+
+@test "_aw_jira_list_issues: keeps a custom ORDER BY outside the project filter" {
+  cd "$TEST_REPO_DIR"
+  mock_cli jira "issue list" $'PROJ-1\tFix login\t∅'
+  git config auto-worktree.jira-project PROJ
+
+  git config auto-worktree.jira-jql "sprint in openSprints() ORDER BY created DESC"
+  run _aw_jira_list_issues
+  [ "$status" -eq 0 ]
+  assert_cli_called jira "project = PROJ AND (sprint in openSprints()) ORDER BY created DESC"
+
+  _AW_JIRA_JQL="order by rank" run _aw_jira_list_issues
+  [ "$status" -eq 0 ]
+  assert_cli_called jira "project = PROJ order by rank"
+
+  # Without a project the query is passed through untouched
+  git config --unset auto-worktree.jira-project
+  run _aw_jira_list_issues
+  assert_cli_called jira "issue list --jql sprint in openSprints() ORDER BY created DESC"
+}
+
+@test "_aw_jira_list_issues: shows JIRA's error for a rejected custom JQL" {
+  cd "$TEST_REPO_DIR"
+  gum() { echo "${@: -1}"; }
+  cat > "$MOCK_BIN_DIR/jira" <<'EOF'
+#!/usr/bin/env bash
+echo "Error in the JQL Query: Expecting operator but got 'sprnt'" >&2
+exit 1
+EOF
+  chmod +x "$MOCK_BIN_DIR/jira"
+  git config auto-worktree.jira-jql "sprnt openSprints()"
+
+  run _aw_jira_list_issues
   [ "$status" -eq 1 ]
+  [[ "$output" == *"JIRA rejected the JQL: sprnt openSprints()"* ]]
+  [[ "$output" == *"Expecting operator but got 'sprnt'"* ]]
+
+  # No matches is an empty list, not an error
+  cat > "$MOCK_BIN_DIR/jira" <<'EOF'
+#!/usr/bin/env bash
+echo "No result found for given query in project \"PROJ\"" >&2
+exit 1
+EOF
+  run _aw_jira_list_issues
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
 }
 
-@test "_aw_linear_list_milestones: prints an error message to stderr" {
+# ============================================================================
+# Linear: projects (milestone equivalent) via the GraphQL API
+# ============================================================================
+
+LINEAR_PROJECTS_RESPONSE='{"data":{"projects":{"nodes":[{"id":"p1","name":"Q3 Launch","state":"started","targetDate":"2026-09-30","teams":{"nodes":[{"key":"ENG"}]}},{"id":"p2","name":"Old Work","state":"completed","targetDate":null,"teams":{"nodes":[{"key":"ENG"}]}},{"id":"p3","name":"Design Refresh","state":"planned","targetDate":null,"teams":{"nodes":[{"key":"DES"}]}}]}}}'
+
+@test "_aw_linear_list_milestones: returns 1 when LINEAR_API_KEY is unset" {
+  unset LINEAR_API_KEY
   run _aw_linear_list_milestones
   [ "$status" -eq 1 ]
-  # The error message should be non-empty (output goes to stderr, but bats
-  # captures combined output in $output when using run without --separate-stderr)
 }
 
-@test "_aw_linear_list_issues_by_milestone: returns 1 (unsupported)" {
-  run _aw_linear_list_issues_by_milestone "some-project"
+@test "_aw_linear_list_milestones: lists active projects with state and target date" {
+  cd "$TEST_REPO_DIR"
+  export LINEAR_API_KEY="lin_test"
+  mock_cli curl "graphql" "$LINEAR_PROJECTS_RESPONSE"
+  run _aw_linear_list_milestones
+  [ "$status" -eq 0 ]
+  [ "${lines[0]}" = "p1 | Q3 Launch | [started] [target: 2026-09-30]" ]
+  [ "${lines[1]}" = "p3 | Design Refresh | [planned]" ]
+  [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "_aw_linear_list_milestones: filters projects by configured team" {
+  cd "$TEST_REPO_DIR"
+  export LINEAR_API_KEY="lin_test"
+  git config auto-worktree.linear-team "DES"
+  mock_cli curl "graphql" "$LINEAR_PROJECTS_RESPONSE"
+  run _aw_linear_list_milestones
+  [ "$status" -eq 0 ]
+  [ "$output" = "p3 | Design Refresh | [planned]" ]
+}
+
+@test "_aw_linear_list_milestones: returns 1 when the API reports errors" {
+  cd "$TEST_REPO_DIR"
+  export LINEAR_API_KEY="lin_bad"
+  mock_cli curl "graphql" '{"errors":[{"message":"Authentication required"}]}'
+  run _aw_linear_list_milestones
   [ "$status" -eq 1 ]
 }
 
+@test "_aw_linear_list_issues_by_milestone: lists issues with labels" {
+  cd "$TEST_REPO_DIR"
+  export LINEAR_API_KEY="lin_test"
+  mock_cli curl "graphql" '{"data":{"issues":{"nodes":[{"identifier":"ENG-12","title":"Ship it","labels":{"nodes":[{"name":"bug"}]}},{"identifier":"ENG-13","title":"Docs","labels":{"nodes":[]}}]}}}'
+  run _aw_linear_list_issues_by_milestone "p1"
+  [ "$status" -eq 0 ]
+  [ "${lines[0]}" = "ENG-12 | Ship it | [bug]" ]
+  [ "${lines[1]}" = "ENG-13 | Docs" ]
+  assert_cli_called curl "api.linear.app/graphql"
+}
+
+@test "_aw_linear_list_issues_by_milestone: saves label colors when asked" {
+  cd "$TEST_REPO_DIR"
+  export LINEAR_API_KEY="lin_test"
+  mock_cli curl "graphql" '{"data":{"issues":{"nodes":[{"identifier":"ENG-12","title":"Ship it","labels":{"nodes":[{"name":"bug","color":"#eb5757"}]}}]}}}'
+  local colors_file="$BATS_TEST_TMPDIR/label-colors"
+
+  _AW_LABEL_COLORS_FILE="$colors_file" run _aw_linear_list_issues_by_milestone "p1"
+  [ "$status" -eq 0 ]
+  [ "${lines[0]}" = "ENG-12 | Ship it | [bug]" ]
+  [ "$(cat "$colors_file")" = $'bug\t#eb5757' ]
+  grep -q "labels { nodes { name color } }" "$MOCK_BIN_DIR/curl.calls"
+}
+
+@test "_aw_linear_list_issues_by_milestone: asks for issue-list-limit issues" {
+  cd "$TEST_REPO_DIR"
+  export LINEAR_API_KEY="lin_test"
+  git config auto-worktree.issue-list-limit 25
+  mock_cli curl "graphql" '{"data":{"issues":{"nodes":[]}}}'
+  run _aw_linear_list_issues_by_milestone "p1"
+  [ "$status" -eq 0 ]
+  grep -q '"first":25' "$MOCK_BIN_DIR/curl.calls"
+}
+
+@test "_aw_gitlab_list_issues: fetches issue-list-limit issues" {
+  cd "$TEST_REPO_DIR"
+  git config auto-worktree.issue-list-limit 25
+  mock_cli glab "" ''
+  run _aw_gitlab_list_issues
+  assert_cli_called glab "issue list --state opened --per-page 25"
+}
+
 @test "_aw_linear_list_issues_by_milestone: returns 1 with no argument" {
   run _aw_linear_list_issues_by_milestone
   [ "$status" -eq 1 ]
@@ -285,3 +487,128 @@ teardown() {
   run _aw_linear_check_completed "TEAM-000"
   [ "$status" -eq 1 ]
 }
+
+# ============================================================================
+# _aw_retry_cli
+# ============================================================================
+
+# Mock CLI that fails with the given error for the first N calls, then succeeds
+_mock_flaky_cli() {
+  local tool="$1"
+  local failures="$2"
+  local error="$3"
+  cat > "$MOCK_BIN_DIR/$tool" <<MOCK
+#!/usr/bin/env bash
+echo "\$*" >> "$MOCK_BIN_DIR/${tool}.calls"
+if [[ \$(wc -l < "$MOCK_BIN_DIR/${tool}.calls") -le $failures ]]; then
+  echo "$error" >&2
+  exit 1
+fi
+echo "PROJ-1 | Fixed"
+MOCK
+  chmod +x "$MOCK_BIN_DIR/$tool"
+}
+
+@test "_aw_retry_cli: retries transient network errors until the CLI succeeds" {
+  _mock_flaky_cli jira 2 "Error: dial tcp: connection reset by peer"
+  sleep() { echo "slept $1" >> "$MOCK_BIN_DIR/sleeps"; }
+
+  run _aw_retry_cli jira issue list
+  [ "$status" -eq 0 ]
+  [ "$output" = "PROJ-1 | Fixed" ]
+  [ "$(wc -l < "$MOCK_BIN_DIR/jira.calls")" -eq 3 ]
+  # Exponential backoff: 1s, then 2s
+  [ "$(cat "$MOCK_BIN_DIR/sleeps")" = $'slept 1\nslept 2' ]
+}
+
+@test "_aw_retry_cli: gives up after AW_CLI_RETRIES attempts" {
+  _mock_flaky_cli linear 5 "request timed out"
+  AW_CLI_RETRIES=2 AW_CLI_RETRY_DELAY=0 run _aw_retry_cli linear issue view ENG-1
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"request timed out"* ]]
+  [ "$(wc -l < "$MOCK_BIN_DIR/linear.calls")" -eq 2 ]
+}
+
+@test "_aw_retry_cli: does not retry non-transient errors" {
+  _mock_flaky_cli jira 5 "Error: issue does not exist"
+  AW_CLI_RETRY_DELAY=0 run _aw_retry_cli jira issue view PROJ-404
+  [ "$status" -eq 1 ]
+  [ "$(wc -l < "$MOCK_BIN_DIR/jira.calls")" -eq 1 ]
+}
+
+@test "_aw_retry_cli: falls back to the default delay when AW_CLI_RETRY_DELAY isn't a whole number" {
+  _mock_flaky_cli jira 2 "Error: dial tcp: connection reset by peer"
+  sleep() { echo "slept $1" >> "$MOCK_BIN_DIR/sleeps"; }
+
+  AW_CLI_RETRY_DELAY=0.5 run _aw_retry_cli jira issue list
+  [ "$status" -eq 0 ]
+  [ "$output" = "PROJ-1 | Fixed" ]
+  [ "$(cat "$MOCK_BIN_DIR/sleeps")" = $'slept 1\nslept 2' ]
+}
+
+@test "_aw_linear_api: retries HTTP 5xx responses" {
+  export LINEAR_API_KEY="lin_test"
+  cat > "$MOCK_BIN_DIR/curl" <<MOCK
+#!/usr/bin/env bash
+echo "\$*" >> "$MOCK_BIN_DIR/curl.calls"
+if [[ \$(wc -l < "$MOCK_BIN_DIR/curl.calls") -le 1 ]]; then
+  echo "curl: (22) The requested URL returned error: 503" >&2
+  exit 22
+fi
+echo '{"data":{"viewer":{"id":"u1"}}}'
+MOCK
+  chmod +x "$MOCK_BIN_DIR/curl"
+
+  AW_CLI_RETRY_DELAY=0 run _aw_linear_api '{ viewer { id } }'
+  [ "$status" -eq 0 ]
+  [ "$output" = '{"data":{"viewer":{"id":"u1"}}}' ]
+  [ "$(wc -l < "$MOCK_BIN_DIR/curl.calls")" -eq 2 ]
+  grep -qF -- "--fail-with-body" "$MOCK_BIN_DIR/curl.calls"
+}
+
+@test "_aw_linear_api: names the HTTP status when the request fails" {
+  export LINEAR_API_KEY="lin_test"
+  gum() { echo "${@: -1}"; }
+  cat > "$MOCK_BIN_DIR/curl" <<MOCK
+#!/usr/bin/env bash
+echo "curl: (22) The requested URL returned error: 401" >&2
+exit 22
+MOCK
+  chmod +x "$MOCK_BIN_DIR/curl"
+
+  run _aw_linear_api '{ viewer { id } }'
+  [ "$status" -eq 1 ]
+  [ "$output" = "Error: Linear API request failed: curl: (22) The requested URL returned error: 401" ]
+}
+
+@test "_aw_linear_api: shows curl's error when the API can't be reached" {
+  export LINEAR_API_KEY="lin_test"
+  gum() { echo "${@: -1}"; }
+  cat > "$MOCK_BIN_DIR/curl" <<MOCK
+#!/usr/bin/env bash
+echo "curl: (6) Could not resolve host: api.linear.app" >&2
+exit 6
+MOCK
+  chmod +x "$MOCK_BIN_DIR/curl"
+
+  AW_CLI_RETRY_DELAY=0 run _aw_linear_api '{ viewer { id } }'
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Linear API request failed: curl: (6) Could not resolve host: api.linear.app"* ]]
+}
+
+@test "_aw_linear_api: shows the GraphQL error from an HTTP error response" {
+  export LINEAR_API_KEY="lin_test"
+  cat > "$MOCK_BIN_DIR/curl" <<MOCK
+#!/usr/bin/env bash
+echo "\$*" >> "$MOCK_BIN_DIR/curl.calls"
+echo '{"errors":[{"message":"Cannot query field"}]}'
+echo "curl: (22) The requested URL returned error: 400" >&2
+exit 22
+MOCK
+  chmod +x "$MOCK_BIN_DIR/curl"
+
+  run _aw_linear_api '{ nope }'
+  [ "$status" -eq 1 ]
+  [ "$output" = "Cannot query field" ]
+  [ "$(wc -l < "$MOCK_BIN_DIR/curl.calls")" -eq 1 ]
+}
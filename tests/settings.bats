@@ -0,0 +1,264 @@
+#!/usr/bin/env bats
+# Tests for src/lib/settings.sh
+#
+# Covers:
+#   - _aw_is_known_setting (keys from _AW_SETTING_CATEGORIES)
+#   - _aw_settings_export (grouping, effective vs --local/--global values, escaping)
+#   - _aw_settings_import (round trip, scopes, unknown keys, invalid files)
+#   - _aw_settings (subcommand dispatch, usage error)
+#   - _aw_configure_jira / _aw_configure_gitlab / _aw_configure_linear (pre-filled, non-destructive)
+#   - _aw_setting_choices / _aw_settings_edit_key (pickers for enumerated keys, local/global writes, unset)
+
+REPO_ROOT="$(cd "${BATS_TEST_DIRNAME}/.." && pwd)"
+
+load 'helpers/setup_git_repo'
+
+setup() {
+  gum() {
+    if [[ "$1" == "style" ]]; then
+      echo "${@: -1}"
+    fi
+  }
+
+  # shellcheck source=../src/lib/utils.sh
+  source "${REPO_ROOT}/src/lib/utils.sh"
+  # shellcheck source=../src/lib/config.sh
+  source "${REPO_ROOT}/src/lib/config.sh"
+  # shellcheck source=../src/lib/settings.sh
+  source "${REPO_ROOT}/src/lib/settings.sh"
+
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+
+  # Keep the user's global git config out of the results
+  export GIT_CONFIG_GLOBAL="$TEST_REPO_DIR.gitconfig"
+  touch "$GIT_CONFIG_GLOBAL"
+}
+
+teardown() {
+  rm -f "$GIT_CONFIG_GLOBAL"
+  unset GIT_CONFIG_GLOBAL
+  teardown_git_repo
+}
+
+@test "_aw_is_known_setting: accepts keys from every category" {
+  _aw_is_known_setting issue-provider
+  _aw_is_known_setting ai-tool
+  _aw_is_known_setting run-hooks
+  _aw_is_known_setting worktree-base
+  _aw_is_known_setting issue-templates-dir
+  ! _aw_is_known_setting issue-provder
+  ! _aw_is_known_setting ""
+}
+
+@test "_aw_settings_export: groups set keys by category" {
+  git config auto-worktree.issue-provider github
+  git config auto-worktree.ai-tool claude
+  git config auto-worktree.run-hooks false
+
+  run _aw_settings_export
+  [ "$status" -eq 0 ]
+  [ "$(echo "$output" | jq -r '.provider["issue-provider"]')" = "github" ]
+  [ "$(echo "$output" | jq -r '.ai["ai-tool"]')" = "claude" ]
+  [ "$(echo "$output" | jq -r '.hooks["run-hooks"]')" = "false" ]
+  # Unset keys are left out; every category is present
+  [ "$(echo "$output" | jq -c '.worktrees')" = "{}" ]
+  [ "$(echo "$output" | jq -r 'keys | join(",")')" = "ai,hooks,provider,templates,worktrees" ]
+}
+
+@test "_aw_settings_export: --local and --global select a scope" {
+  git config --global auto-worktree.ai-tool codex
+  git config --global auto-worktree.editor vim
+  git config auto-worktree.ai-tool claude
+
+  run _aw_settings_export
+  [ "$(echo "$output" | jq -r '.ai["ai-tool"]')" = "claude" ]
+  [ "$(echo "$output" | jq -r '.worktrees.editor')" = "vim" ]
+
+  run _aw_settings_export --local
+  [ "$(echo "$output" | jq -r '.ai["ai-tool"]')" = "claude" ]
+  [ "$(echo "$output" | jq -r '.worktrees.editor // "unset"')" = "unset" ]
+
+  run _aw_settings_export --global
+  [ "$(echo "$output" | jq -r '.ai["ai-tool"]')" = "codex" ]
+}
+
+@test "_aw_settings_export: values are JSON-escaped" {
+  git config auto-worktree.ai-tool-cmd 'env FOO="a b" \run'
+
+  run _aw_settings_export
+  [ "$status" -eq 0 ]
+  [ "$(echo "$output" | jq -r '.ai["ai-tool-cmd"]')" = 'env FOO="a b" \run' ]
+}
+
+@test "_aw_settings: export and --json print JSON; unknown subcommands are usage errors" {
+  git config auto-worktree.branch-prefix feature
+
+  run _aw_settings export
+  [ "$(echo "$output" | jq -r '.worktrees["branch-prefix"]')" = "feature" ]
+
+  run _aw_settings --json --local
+  [ "$(echo "$output" | jq -r '.worktrees["branch-prefix"]')" = "feature" ]
+
+  run _aw_settings bogus
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  run _aw_settings export --bogus
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+}
+
+@test "_aw_settings_import: applies an exported file to another repository" {
+  git config auto-worktree.issue-provider jira
+  git config auto-worktree.jira-server https://example.atlassian.net
+  git config auto-worktree.ai-tool-cmd 'env FOO="a b"'
+  _aw_settings_export --local > "$TEST_REPO_DIR.json"
+
+  local source_repo="$TEST_REPO_DIR"
+  setup_git_repo
+  cd "$TEST_REPO_DIR"
+
+  run _aw_settings_import "$source_repo.json"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Imported 3 setting(s) into local git config"* ]]
+  [ "$(git config --local auto-worktree.issue-provider)" = "jira" ]
+  [ "$(git config --local auto-worktree.jira-server)" = "https://example.atlassian.net" ]
+  [ "$(git config --local auto-worktree.ai-tool-cmd)" = 'env FOO="a b"' ]
+
+  rm -rf "$source_repo" "$source_repo.json"
+}
+
+@test "_aw_settings_import: --global writes to the global config" {
+  echo '{"worktrees":{"editor":"code","install-deps":false}}' > "$TEST_REPO_DIR.json"
+
+  run _aw_settings_import --global "$TEST_REPO_DIR.json"
+  [ "$status" -eq 0 ]
+  [ "$(git config --global auto-worktree.editor)" = "code" ]
+  [ "$(git config --global auto-worktree.install-deps)" = "false" ]
+  [ -z "$(git config --local auto-worktree.editor)" ]
+
+  rm -f "$TEST_REPO_DIR.json"
+}
+
+@test "_aw_settings_import: refuses unknown keys without writing anything" {
+  echo '{"ai":{"ai-tool":"claude"},"provider":{"issue-provder":"github"}}' > "$TEST_REPO_DIR.json"
+
+  run _aw_settings_import "$TEST_REPO_DIR.json"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Unknown setting(s)"*"issue-provder"* ]]
+  [ -z "$(git config auto-worktree.ai-tool)" ]
+
+  rm -f "$TEST_REPO_DIR.json"
+}
+
+@test "_aw_settings_import: rejects files that are not in the export format" {
+  echo 'not json' > "$TEST_REPO_DIR.json"
+  run _aw_settings_import "$TEST_REPO_DIR.json"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Invalid settings file"* ]]
+
+  echo '{"ai":"claude"}' > "$TEST_REPO_DIR.json"
+  run _aw_settings_import "$TEST_REPO_DIR.json"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"category ai is not an object"* ]]
+
+  echo '{"ai":{"ai-tool":["claude"]}}' > "$TEST_REPO_DIR.json"
+  run _aw_settings_import "$TEST_REPO_DIR.json"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"ai.ai-tool is not a string"* ]]
+
+  rm -f "$TEST_REPO_DIR.json"
+}
+
+@test "_aw_settings_import: usage errors for a missing or unreadable file" {
+  run _aw_settings import
+  [ "$status" -eq "$AW_EXIT_USAGE" ]
+
+  run _aw_settings_import "$TEST_REPO_DIR/missing.json"
+  [ "$status" -eq 1 ]
+  [[ "$output" == *"Cannot read settings file"* ]]
+}
+
+@test "_aw_setting_choices: enumerated keys list their values, free-form keys nothing" {
+  [ "$(_aw_setting_choices issue-provider | tr '\n' ' ')" = "github gitlab jira linear " ]
+  [ "$(_aw_setting_choices ai-tool | tr '\n' ' ')" = "claude codex gemini jules skip " ]
+  [ "$(_aw_setting_choices run-hooks | tr '\n' ' ')" = "true false " ]
+  [ -z "$(_aw_setting_choices worktree-base)" ]
+}
+
+@test "_aw_settings_edit_key: picks enumerated values and writes the chosen scope" {
+  gum() {
+    case "$1" in
+      choose)
+        if [[ "$*" == *"Set globally"* ]]; then echo "Set globally"; else echo "jira"; fi
+        ;;
+      style) echo "${@: -1}" ;;
+    esac
+  }
+
+  run _aw_settings_edit_key issue-provider
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"auto-worktree.issue-provider set to 'jira' in global git config"* ]]
+  [ "$(git config --global --get auto-worktree.issue-provider)" = "jira" ]
+  [ -z "$(git config --local --get auto-worktree.issue-provider)" ]
+}
+
+@test "_aw_settings_edit_key: free-form keys use a text input; unset removes the key" {
+  gum() {
+    case "$1" in
+      choose) echo "Set for this repository" ;;
+      input) echo "~/trees" ;;
+      style) echo "${@: -1}" ;;
+    esac
+  }
+  _aw_settings_edit_key worktree-base
+  [ "$(git config --local --get auto-worktree.worktree-base)" = "~/trees" ]
+
+  gum() { [[ "$1" == "choose" ]] && echo "Unset for this repository"; return 0; }
+  _aw_settings_edit_key worktree-base
+  [ -z "$(git config --local --get auto-worktree.worktree-base)" ]
+}
+
+@test "_aw_configure_jira/gitlab/linear: inputs are pre-filled with the current values" {
+  git config auto-worktree.jira-server "https://acme.atlassian.net"
+  git config auto-worktree.jira-project "PROJ"
+  git config auto-worktree.gitlab-server "https://gitlab.acme.dev"
+  git config auto-worktree.gitlab-project "team/app"
+  git config auto-worktree.linear-team "ENG"
+
+  # Accepting every input unchanged echoes back its --value
+  gum() {
+    if [[ "$1" == "input" ]]; then
+      while [[ $# -gt 0 ]]; do
+        [[ "$1" == "--value" ]] && echo "$2"
+        shift
+      done
+    fi
+  }
+  _aw_configure_jira >/dev/null
+  _aw_configure_gitlab >/dev/null
+  _aw_configure_linear >/dev/null
+
+  [ "$(git config auto-worktree.jira-server)" = "https://acme.atlassian.net" ]
+  [ "$(git config auto-worktree.jira-project)" = "PROJ" ]
+  [ "$(git config auto-worktree.gitlab-server)" = "https://gitlab.acme.dev" ]
+  [ "$(git config auto-worktree.gitlab-project)" = "team/app" ]
+  [ "$(git config auto-worktree.linear-team)" = "ENG" ]
+}
+
+@test "_aw_configure_gitlab/linear: an empty input keeps the saved value, a new one replaces it" {
+  git config auto-worktree.gitlab-server "https://gitlab.acme.dev"
+  git config auto-worktree.gitlab-project "team/app"
+  git config auto-worktree.linear-team "ENG"
+
+  gum() {
+    if [[ "$1" == "input" ]] && [[ "$*" == *"group/project"* ]]; then
+      echo "team/api"
+    fi
+  }
+  _aw_configure_gitlab >/dev/null
+  _aw_configure_linear >/dev/null
+
+  [ "$(git config auto-worktree.gitlab-server)" = "https://gitlab.acme.dev" ]
+  [ "$(git config auto-worktree.gitlab-project)" = "team/api" ]
+  [ "$(git config auto-worktree.linear-team)" = "ENG" ]
+}
@@ -393,3 +393,107 @@ teardown() {
   [ "$status" -eq 0 ]
   [ "$output" = "123" ]
 }
+
+# ============================================================================
+# _aw_worktree_issue_id / _aw_mark_active_issues
+# ============================================================================
+
+@test "_aw_worktree_issue_id: prefers recorded issue metadata over the branch name" {
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  cd "$TEST_REPO_DIR"
+  git branch renamed-branch
+  _aw_record_issue_metadata "renamed-branch" "github" "77" "Renamed"
+  run _aw_worktree_issue_id "renamed-branch" "github"
+  [ "$output" = "77" ]
+  run _aw_worktree_issue_id "work/12-from-name" "github"
+  [ "$output" = "12" ]
+}
+
+@test "_aw_mark_active_issues: marks issues with worktrees and lists them first" {
+  cd "$TEST_REPO_DIR"
+  _AW_GIT_ROOT="$TEST_REPO_DIR"
+  git worktree add -q -b "work/2-second" "${TEST_REPO_DIR}-wt-2"
+
+  run _aw_mark_active_issues $'#1 | First\n#2 | Second\n#3 | Third' "github"
+  [ "$status" -eq 0 ]
+  [ "${lines[0]}" = "● #2 | Second" ]
+  [ "${lines[1]}" = "#1 | First" ]
+  [ "${lines[2]}" = "#3 | Third" ]
+
+  git worktree remove --force "${TEST_REPO_DIR}-wt-2"
+}
+
+@test "_aw_find_worktree_for_issue: finds a worktree through recorded metadata" {
+  source "${REPO_ROOT}/src/lib/metadata.sh"
+  cd "$TEST_REPO_DIR"
+  git worktree add -q -b "feature/no-number" "${TEST_REPO_DIR}-wt-meta"
+  _aw_record_issue_metadata "feature/no-number" "linear" "ENG-5" "Meta"
+
+  run _aw_find_worktree_for_issue "ENG-5" "linear"
+  [ "$status" -eq 0 ]
+  [ "$output" = "${TEST_REPO_DIR}-wt-meta" ]
+
+  git worktree remove --force "${TEST_REPO_DIR}-wt-meta"
+}
+
+# ============================================================================
+# _aw_copy_untracked_files
+# ============================================================================
+
+@test "_aw_copy_untracked_files: copies matching untracked files but not tracked ones" {
+  cd "$TEST_REPO_DIR"
+  echo ".env" > .gitignore
+  echo "tracked" > config.env
+  git add .gitignore config.env && git commit -q -m "track config"
+  echo "SECRET=1" > .env
+  mkdir -p app && echo "LOCAL=1" > app/.env
+  echo "changed" > config.env
+  git worktree add -q -b copy-files "${TEST_REPO_DIR}-wt-copy"
+  git config auto-worktree.copy-files ".env, **/.env *.env"
+
+  run _aw_copy_untracked_files "$TEST_REPO_DIR" "${TEST_REPO_DIR}-wt-copy"
+  [ "$status" -eq 0 ]
+  [[ "$output" == *"Copied 2 untracked file(s)"* ]]
+  [ "$(cat "${TEST_REPO_DIR}-wt-copy/.env")" = "SECRET=1" ]
+  [ "$(cat "${TEST_REPO_DIR}-wt-copy/app/.env")" = "LOCAL=1" ]
+  [ "$(cat "${TEST_REPO_DIR}-wt-copy/config.env")" = "tracked" ]
+
+  git worktree remove --force "${TEST_REPO_DIR}-wt-copy"
+}
+
+@test "_aw_copy_untracked_files: does nothing without auto-worktree.copy-files" {
+  cd "$TEST_REPO_DIR"
+  echo "SECRET=1" > .env
+  mkdir -p "${TEST_REPO_DIR}-wt-none"
+
+  run _aw_copy_untracked_files "$TEST_REPO_DIR" "${TEST_REPO_DIR}-wt-none"
+  [ "$status" -eq 0 ]
+  [ -z "$output" ]
+  [ ! -e "${TEST_REPO_DIR}-wt-none/.env" ]
+
+  rm -rf "${TEST_REPO_DIR}-wt-none"
+}
+
+@test "_aw_current_worktree: finds the worktree containing the working directory" {
+  git worktree add -q -b current-wt "${TEST_REPO_DIR}-wt-current"
+  mkdir -p "${TEST_REPO_DIR}-wt-current/src/deep"
+  cd "${TEST_REPO_DIR}-wt-current/src/deep"
+
+  run _aw_current_worktree
+  [ "$status" -eq 0 ]
+  [ "$output" = "$(cd "${TEST_REPO_DIR}-wt-current" && pwd -P)" ]
+
+  run _aw_current_worktree_branch
+  [ "$status" -eq 0 ]
+  [ "$output" = "current-wt" ]
+
+  cd "$TEST_REPO_DIR"
+  git worktree remove --force "${TEST_REPO_DIR}-wt-current"
+}
+
+@test "_aw_current_worktree: fails in the main checkout" {
+  cd "$TEST_REPO_DIR"
+  run _aw_current_worktree
+  [ "$status" -ne 0 ]
+  [ -z "$output" ]
+}